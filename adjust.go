@@ -0,0 +1,145 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "time"
+
+// An Adjuster moves a date according to some rule, like "the last Friday of
+// the month". Adjusters can be combined using [Date.With].
+type Adjuster interface {
+	Adjust(Date) Date
+}
+
+// AdjusterFunc is an adapter to use an ordinary function as an [Adjuster].
+type AdjusterFunc func(Date) Date
+
+// Adjust returns f(d).
+func (f AdjusterFunc) Adjust(d Date) Date {
+	return f(d)
+}
+
+// With applies the given adjusters to d, in order, and returns the result.
+// For example, this returns the last Friday before the end of next month:
+//
+//	d.With(FirstDayOfNextMonth(), LastDayOfMonth(), PreviousOrSame(time.Friday))
+func (d Date) With(adjs ...Adjuster) Date {
+	for _, a := range adjs {
+		d = a.Adjust(d)
+	}
+	return d
+}
+
+// FirstDayOfMonth returns an Adjuster moving a date to the first day of its
+// month.
+func FirstDayOfMonth() Adjuster {
+	return AdjusterFunc(func(d Date) Date {
+		return d - Date(d.Day()-1)
+	})
+}
+
+// LastDayOfMonth returns an Adjuster moving a date to the last day of its
+// month.
+func LastDayOfMonth() Adjuster {
+	return AdjusterFunc(func(d Date) Date {
+		year, month, _ := d.Date()
+		return Of(year, month+1, 0)
+	})
+}
+
+// FirstDayOfNextMonth returns an Adjuster moving a date to the first day of
+// the following month.
+func FirstDayOfNextMonth() Adjuster {
+	return AdjusterFunc(func(d Date) Date {
+		year, month, _ := d.Date()
+		return Of(year, month+1, 1)
+	})
+}
+
+// FirstDayOfYear returns an Adjuster moving a date to January 1st of its year.
+func FirstDayOfYear() Adjuster {
+	return AdjusterFunc(func(d Date) Date {
+		return d - Date(d.YearDay()-1)
+	})
+}
+
+// LastDayOfYear returns an Adjuster moving a date to December 31st of its
+// year.
+func LastDayOfYear() Adjuster {
+	return AdjusterFunc(func(d Date) Date {
+		return Of(d.Year(), time.December, 31)
+	})
+}
+
+// FirstDayOfNextYear returns an Adjuster moving a date to January 1st of the
+// following year.
+func FirstDayOfNextYear() Adjuster {
+	return AdjusterFunc(func(d Date) Date {
+		return Of(d.Year()+1, time.January, 1)
+	})
+}
+
+// Next returns an Adjuster moving a date to the first wd after it. If the date
+// already falls on wd, it is moved by a week.
+func Next(wd time.Weekday) Adjuster {
+	return AdjusterFunc(func(d Date) Date {
+		return relWeekday(d, wd, 1)
+	})
+}
+
+// NextOrSame returns an Adjuster moving a date to the first wd after it,
+// unless it already falls on wd.
+func NextOrSame(wd time.Weekday) Adjuster {
+	return AdjusterFunc(func(d Date) Date {
+		return relWeekday(d, wd, 0)
+	})
+}
+
+// Previous returns an Adjuster moving a date to the last wd before it. If the
+// date already falls on wd, it is moved by a week.
+func Previous(wd time.Weekday) Adjuster {
+	return AdjusterFunc(func(d Date) Date {
+		return relWeekday(d, wd, -1)
+	})
+}
+
+// PreviousOrSame returns an Adjuster moving a date to the last wd before it,
+// unless it already falls on wd.
+func PreviousOrSame(wd time.Weekday) Adjuster {
+	return AdjusterFunc(func(d Date) Date {
+		return relWeekday(d+1, wd, -1)
+	})
+}
+
+// FirstInMonth returns an Adjuster moving a date to the first wd in its month.
+func FirstInMonth(wd time.Weekday) Adjuster {
+	return NthInMonth(1, wd)
+}
+
+// LastInMonth returns an Adjuster moving a date to the last wd in its month.
+func LastInMonth(wd time.Weekday) Adjuster {
+	return NthInMonth(-1, wd)
+}
+
+// NthInMonth returns an Adjuster moving a date to the n-th wd in its month,
+// like "the second Tuesday". If n is negative, it counts from the end of the
+// month, so -1 is the last wd of the month. n must not be zero.
+//
+// If the month has fewer than n such weekdays, the result is in a different
+// month. For example, the fifth Monday of February 2024 is March 4th.
+func NthInMonth(n int, wd time.Weekday) Adjuster {
+	if n == 0 {
+		panic("NthInMonth: n must not be zero")
+	}
+	return AdjusterFunc(func(d Date) Date {
+		year, month, _ := d.Date()
+		if n > 0 {
+			first := relWeekday(Of(year, month, 1), wd, 0)
+			return first.AddWeeks(n - 1)
+		}
+		last := relWeekday(Of(year, month+1, 1), wd, -1)
+		return last.AddWeeks(n + 1)
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdjusters(t *testing.T) {
+	t.Parallel()
+	// 2024-05-14 is a Tuesday.
+	d := Of(2024, 5, 14)
+	tcs := []struct {
+		name string
+		a    Adjuster
+		d    Date
+		want Date
+	}{
+		{"FirstDayOfMonth", FirstDayOfMonth(), d, Of(2024, 5, 1)},
+		{"LastDayOfMonth", LastDayOfMonth(), d, Of(2024, 5, 31)},
+		{"LastDayOfMonth", LastDayOfMonth(), Of(2024, 2, 1), Of(2024, 2, 29)},
+		{"FirstDayOfNextMonth", FirstDayOfNextMonth(), Of(2024, 12, 31), Of(2025, 1, 1)},
+		{"FirstDayOfYear", FirstDayOfYear(), d, Of(2024, 1, 1)},
+		{"LastDayOfYear", LastDayOfYear(), d, Of(2024, 12, 31)},
+		{"FirstDayOfNextYear", FirstDayOfNextYear(), d, Of(2025, 1, 1)},
+		{"Next(Tuesday)", Next(time.Tuesday), d, Of(2024, 5, 21)},
+		{"Next(Wednesday)", Next(time.Wednesday), d, Of(2024, 5, 15)},
+		{"NextOrSame(Tuesday)", NextOrSame(time.Tuesday), d, d},
+		{"NextOrSame(Monday)", NextOrSame(time.Monday), d, Of(2024, 5, 20)},
+		{"Previous(Tuesday)", Previous(time.Tuesday), d, Of(2024, 5, 7)},
+		{"Previous(Monday)", Previous(time.Monday), d, Of(2024, 5, 13)},
+		{"PreviousOrSame(Tuesday)", PreviousOrSame(time.Tuesday), d, d},
+		{"PreviousOrSame(Wednesday)", PreviousOrSame(time.Wednesday), d, Of(2024, 5, 8)},
+		{"FirstInMonth(Wednesday)", FirstInMonth(time.Wednesday), d, Of(2024, 5, 1)},
+		{"FirstInMonth(Tuesday)", FirstInMonth(time.Tuesday), d, Of(2024, 5, 7)},
+		{"LastInMonth(Friday)", LastInMonth(time.Friday), d, Of(2024, 5, 31)},
+		{"LastInMonth(Thursday)", LastInMonth(time.Thursday), d, Of(2024, 5, 30)},
+		{"NthInMonth(2, Tuesday)", NthInMonth(2, time.Tuesday), d, Of(2024, 5, 14)},
+		{"NthInMonth(-2, Friday)", NthInMonth(-2, time.Friday), d, Of(2024, 5, 24)},
+		{"NthInMonth(5, Monday)", NthInMonth(5, time.Monday), Of(2024, 2, 1), Of(2024, 3, 4)},
+	}
+	for _, tc := range tcs {
+		if got := tc.a.Adjust(tc.d); got != tc.want {
+			t.Errorf("%s.Adjust(%v) = %v, want %v", tc.name, tc.d, got, tc.want)
+		}
+	}
+}
+
+func TestWith(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 14)
+	if got := d.With(); got != d {
+		t.Errorf("%v.With() = %v, want %v", d, got, d)
+	}
+	// The last Friday of next month.
+	got := d.With(FirstDayOfNextMonth(), LastDayOfMonth(), PreviousOrSame(time.Friday))
+	if want := Of(2024, 6, 28); got != want {
+		t.Errorf("%v.With(…) = %v, want %v", d, got, want)
+	}
+	addDays := func(n int) Adjuster {
+		return AdjusterFunc(func(d Date) Date { return d + Date(n) })
+	}
+	if got, want := d.With(addDays(1), FirstDayOfMonth(), addDays(3)), Of(2024, 5, 4); got != want {
+		t.Errorf("%v.With(…) = %v, want %v", d, got, want)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A LayoutIssue is a potential problem with a layout, detected by
+// [AnalyzeLayout].
+type LayoutIssue int
+
+// Issues detected by [AnalyzeLayout].
+const (
+	// TwoDigitYear means the layout uses a two-digit year. Formatting loses
+	// the century and parsing has to guess it.
+	TwoDigitYear LayoutIssue = iota + 1
+
+	// MissingYear means the layout contains no year. Parsing assumes year 0.
+	MissingYear
+
+	// MissingMonth means the layout contains no month and no day of the
+	// year. Parsing assumes January.
+	MissingMonth
+
+	// MissingDay means the layout contains no day of the month and no day of
+	// the year. Parsing assumes the first day of the month.
+	MissingDay
+
+	// AmbiguousOrder means the layout contains numerical months and days
+	// and does not start with the year. Readers might confuse day and month,
+	// as in "01/02/06".
+	AmbiguousOrder
+
+	// AdjacentDigits means an element of variable width, like "1" or "2",
+	// is directly adjacent to other digits. Parsing might then split the
+	// digits in unexpected ways, as in "112" for "12".
+	AdjacentDigits
+)
+
+var layoutIssueDescriptions = [...]string{
+	TwoDigitYear:   "two-digit year",
+	MissingYear:    "missing year",
+	MissingMonth:   "missing month",
+	MissingDay:     "missing day",
+	AmbiguousOrder: "ambiguous order of day and month",
+	AdjacentDigits: "variable-width element adjacent to digits",
+}
+
+// String returns a short description of i.
+func (i LayoutIssue) String() string {
+	if i <= 0 || int(i) >= len(layoutIssueDescriptions) {
+		return "LayoutIssue(" + strconv.Itoa(int(i)) + ")"
+	}
+	return layoutIssueDescriptions[i]
+}
+
+// A LayoutReport is the result of analyzing a layout.
+type LayoutReport struct {
+	Layout string
+	Issues []LayoutIssue
+}
+
+// OK reports whether no issues were found.
+func (r LayoutReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// Has reports whether r contains the issue i.
+func (r LayoutReport) Has(i LayoutIssue) bool {
+	for _, j := range r.Issues {
+		if i == j {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a description of the issues in r, separated by commas.
+func (r LayoutReport) String() string {
+	if r.OK() {
+		return "ok"
+	}
+	s := make([]string, len(r.Issues))
+	for i, issue := range r.Issues {
+		s[i] = issue.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+// AnalyzeLayout checks layout for elements which are ambiguous or lose
+// information, like two-digit years. It is intended to warn users configuring
+// a custom layout. The issues are reported in the order they are declared.
+func AnalyzeLayout(layout string) LayoutReport {
+	prog := parseLayout(layout)
+	var (
+		year, longYear  bool
+		month, numMonth bool
+		day, numDay     bool
+		yday            bool
+		yearFirst       bool
+		adjacent        bool
+	)
+	for i, in := range prog {
+		switch in.op {
+		case opYear:
+			year = true
+		case opLongYear, opUnderLongYear, opHoloceneYear:
+			longYear = true
+		case opLongMonth, opMonth, opStandaloneLongMonth, opStandaloneMonth:
+			month = true
+		case opNumMonth, opZeroMonth:
+			month, numMonth = true, true
+		case opDay, opZeroDay, opUnderDay, opOrdinalDay:
+			day, numDay = true, true
+		case opZeroYearDay, opUnderYearDay:
+			yday = true
+		}
+		if !yearFirst && !month && !day && !yday {
+			yearFirst = year || longYear
+		}
+		if in.op.variableWidth() {
+			if i > 0 && endsWithDigit(prog[i-1]) || i+1 < len(prog) && startsWithDigit(prog[i+1]) {
+				adjacent = true
+			}
+		}
+	}
+	r := LayoutReport{Layout: layout}
+	if year {
+		r.Issues = append(r.Issues, TwoDigitYear)
+	}
+	if !year && !longYear {
+		r.Issues = append(r.Issues, MissingYear)
+	}
+	if !month && !yday {
+		r.Issues = append(r.Issues, MissingMonth)
+	}
+	if !day && !yday {
+		r.Issues = append(r.Issues, MissingDay)
+	}
+	if numMonth && numDay && !yearFirst {
+		r.Issues = append(r.Issues, AmbiguousOrder)
+	}
+	if adjacent {
+		r.Issues = append(r.Issues, AdjacentDigits)
+	}
+	return r
+}
+
+// variableWidth reports whether op parses a variable number of digits.
+func (op fmtOp) variableWidth() bool {
+	switch op {
+	case opNumMonth, opDay, opUnderDay, opUnderYearDay, opOrdinalDay:
+		return true
+	}
+	return false
+}
+
+// startsWithDigit reports whether i formats to something starting with a
+// digit.
+func startsWithDigit(i inst) bool {
+	if i.op == opLiteral {
+		return isDigit(i.lit, 0)
+	}
+	return i.op.numeric()
+}
+
+// endsWithDigit reports whether i formats to something ending with a digit.
+func endsWithDigit(i inst) bool {
+	if i.op == opLiteral {
+		return isDigit(i.lit, len(i.lit)-1)
+	}
+	return i.op.numeric() && i.op != opOrdinalDay
+}
+
+// numeric reports whether op formats as a number.
+func (op fmtOp) numeric() bool {
+	switch op {
+	case opLiteral, opLongMonth, opMonth, opStandaloneLongMonth, opStandaloneMonth, opLongWeekDay, opWeekDay, opMinWeekDay, opNarrowMonth, opNarrowWeekDay, opSkip, opCustom:
+		return false
+	}
+	return true
+}
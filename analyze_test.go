@@ -0,0 +1,66 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAnalyzeLayout(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		layout string
+		want   []LayoutIssue
+	}{
+		{RFC3339, nil},
+		{ISOBasic, nil},
+		{RFC1123, nil},
+		{LongDate, nil},
+		{LongOrdinal, nil},
+		{"2006-002", nil},
+		{"2006/1/2", nil},
+		{RFC822, []LayoutIssue{TwoDigitYear}},
+		{Layout, []LayoutIssue{TwoDigitYear, AmbiguousOrder}},
+		{"01/02/06", []LayoutIssue{TwoDigitYear, AmbiguousOrder}},
+		{US, []LayoutIssue{AmbiguousOrder}},
+		{UKSlash, []LayoutIssue{AmbiguousOrder}},
+		{EuropeanDotted, []LayoutIssue{AmbiguousOrder}},
+		{"Jan 2", []LayoutIssue{MissingYear}},
+		{"2006", []LayoutIssue{MissingMonth, MissingDay}},
+		{"January 2006", []LayoutIssue{MissingDay}},
+		{"20060102", nil},
+		{"200612", []LayoutIssue{AdjacentDigits}},
+		{"2006-12", []LayoutIssue{AdjacentDigits}},
+		{"2006-1-2", nil},
+		{"2006x1x2", nil},
+		{"2006 1 2", nil},
+		{"", []LayoutIssue{MissingYear, MissingMonth, MissingDay}},
+	}
+	for _, tc := range tcs {
+		r := AnalyzeLayout(tc.layout)
+		if !slices.Equal(r.Issues, tc.want) {
+			t.Errorf("AnalyzeLayout(%q) = %v, want %v", tc.layout, r.Issues, tc.want)
+		}
+		if r.OK() != (len(tc.want) == 0) {
+			t.Errorf("AnalyzeLayout(%q).OK() = %v, want %v", tc.layout, r.OK(), len(tc.want) == 0)
+		}
+		for _, i := range tc.want {
+			if !r.Has(i) {
+				t.Errorf("AnalyzeLayout(%q).Has(%v) = false, want true", tc.layout, i)
+			}
+		}
+	}
+	if got, want := AnalyzeLayout("01/02/06").String(), "two-digit year, ambiguous order of day and month"; got != want {
+		t.Errorf("AnalyzeLayout(%q).String() = %q, want %q", "01/02/06", got, want)
+	}
+	if got, want := AnalyzeLayout(RFC3339).String(), "ok"; got != want {
+		t.Errorf("AnalyzeLayout(%q).String() = %q, want %q", RFC3339, got, want)
+	}
+	if got, want := LayoutIssue(42).String(), "LayoutIssue(42)"; got != want {
+		t.Errorf("LayoutIssue(42).String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// An Availability records for each date in a fixed [Range] whether it is
+// available, using one bit per date. It is useful for things like booking
+// calendars, where a year of availability fits into 46 bytes.
+//
+// Dates outside of the range are never available. The zero value has an empty
+// range. An Availability must not be used concurrently, if any goroutine
+// modifies it.
+type Availability struct {
+	b dayBitmap
+}
+
+// NewAvailability returns an Availability covering r, with no date available.
+func NewAvailability(r Range) *Availability {
+	if r.IsEmpty() {
+		r = Range{}
+	}
+	return &Availability{*newDayBitmap(r)}
+}
+
+// Range returns the range of dates covered by a.
+func (a *Availability) Range() Range {
+	return a.b.r
+}
+
+// Set marks d as available. It panics, if d is outside of the range of a.
+func (a *Availability) Set(d Date) {
+	a.check(d)
+	a.b.set(d)
+}
+
+// Clear marks d as unavailable. It panics, if d is outside of the range of a.
+func (a *Availability) Clear(d Date) {
+	a.check(d)
+	a.b.clear(d)
+}
+
+func (a *Availability) check(d Date) {
+	if !a.b.r.Contains(d) {
+		panic(fmt.Errorf("date %v outside of availability range %v", d, a.b.r))
+	}
+}
+
+// Available reports whether d is available. Dates outside of the range of a
+// are not available.
+func (a *Availability) Available(d Date) bool {
+	return a.b.r.Contains(d) && a.b.get(d)
+}
+
+// SetRange marks all dates in r as available. Dates outside of the range of a
+// are ignored.
+func (a *Availability) SetRange(r Range) {
+	if x, ok := r.Intersect(a.b.r); ok {
+		a.b.fill(x, true)
+	}
+}
+
+// ClearRange marks all dates in r as unavailable. Dates outside of the range
+// of a are ignored.
+func (a *Availability) ClearRange(r Range) {
+	if x, ok := r.Intersect(a.b.r); ok {
+		a.b.fill(x, false)
+	}
+}
+
+// SetAll marks all dates in s as available. Dates outside of the range of a
+// are ignored.
+func (a *Availability) SetAll(s *RangeSet) {
+	for r := range s.Ranges() {
+		a.SetRange(r)
+	}
+}
+
+// ClearAll marks all dates in s as unavailable. Dates outside of the range of
+// a are ignored.
+func (a *Availability) ClearAll(s *RangeSet) {
+	for r := range s.Ranges() {
+		a.ClearRange(r)
+	}
+}
+
+// Count returns the number of available dates in r, like the number of free
+// days in a month:
+//
+//	free := a.Count(MonthRange(2024, time.June))
+func (a *Availability) Count(r Range) int {
+	x, ok := r.Intersect(a.b.r)
+	if !ok {
+		return 0
+	}
+	return a.b.count(x)
+}
+
+// RangeSet returns the available dates as a RangeSet.
+func (a *Availability) RangeSet() *RangeSet {
+	s := new(RangeSet)
+	start, in := a.b.r.Start, false
+	for d := a.b.r.Start; d < a.b.r.End; d++ {
+		switch v := a.b.get(d); {
+		case v && !in:
+			start, in = d, true
+		case !v && in:
+			s.Add(Range{start, d})
+			in = false
+		}
+	}
+	if in {
+		s.Add(Range{start, a.b.r.End})
+	}
+	return s
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The
+// encoding consists of the start of the range as a [binary.Varint], its number
+// of days as a [binary.Uvarint] and then one bit per day, in little-endian
+// order.
+func (a *Availability) MarshalBinary() ([]byte, error) {
+	n := a.b.r.Days()
+	b := make([]byte, 0, 2*binary.MaxVarintLen64+(n+7)/8)
+	b = binary.AppendVarint(b, int64(a.b.r.Start))
+	b = binary.AppendUvarint(b, uint64(n))
+	for i := 0; i < n; i += 8 {
+		b = append(b, byte(a.b.bits[i/64]>>(i%64)))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (a *Availability) UnmarshalBinary(b []byte) error {
+	start, i := binary.Varint(b)
+	if i <= 0 || int64(int(start)) != start {
+		return errors.New("invalid availability range")
+	}
+	b = b[i:]
+	n, i := binary.Uvarint(b)
+	if i <= 0 || n > uint64(len(b)-i)*8 {
+		return errors.New("invalid availability range")
+	}
+	b = b[i:]
+	if uint64(len(b)) != (n+7)/8 {
+		return errors.New("availability data has wrong length")
+	}
+	r := Range{Date(start), Date(start) + Date(n)}
+	if r.End < r.Start {
+		return errors.New("availability range overflows")
+	}
+	bm := newDayBitmap(r)
+	for j, v := range b {
+		bm.bits[j/8] |= uint64(v) << (j % 8 * 8)
+	}
+	if n%8 != 0 && b[len(b)-1]>>(n%8) != 0 {
+		return errors.New("extra bits after availability data")
+	}
+	a.b = *bm
+	return nil
+}
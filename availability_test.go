@@ -0,0 +1,115 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAvailability(t *testing.T) {
+	t.Parallel()
+	r := YearRange(2024)
+	a := NewAvailability(r)
+	june := MonthRange(2024, time.June)
+	a.SetRange(june)
+	a.ClearAll(NewRangeSet(ClosedRange(Of(2024, 6, 10), Of(2024, 6, 14)), ClosedRange(Of(2024, 6, 30), Of(2024, 7, 5))))
+	a.Set(Of(2024, 12, 31))
+	a.Clear(Of(2024, 6, 1))
+	a.SetAll(NewRangeSet(ClosedRange(Of(2023, 12, 1), Of(2024, 1, 2))))
+
+	tcs := []struct {
+		r    Range
+		want int
+	}{
+		{june, 23},
+		{r, 26},
+		{MonthRange(2024, time.July), 0},
+		{MonthRange(2024, time.December), 1},
+		{MonthRange(2023, time.December), 0},
+		{Range{}, 0},
+	}
+	for _, tc := range tcs {
+		if got := a.Count(tc.r); got != tc.want {
+			t.Errorf("Count(%v) = %d, want %d", tc.r, got, tc.want)
+		}
+	}
+	for _, d := range []Date{Of(2023, 12, 31), Of(2024, 6, 1), Of(2024, 6, 10), Of(2025, 1, 1)} {
+		if a.Available(d) {
+			t.Errorf("Available(%v) = true, want false", d)
+		}
+	}
+	for _, d := range []Date{Of(2024, 1, 1), Of(2024, 6, 2), Of(2024, 6, 15), Of(2024, 12, 31)} {
+		if !a.Available(d) {
+			t.Errorf("Available(%v) = false, want true", d)
+		}
+	}
+
+	want := []Range{
+		ClosedRange(Of(2024, 1, 1), Of(2024, 1, 2)),
+		ClosedRange(Of(2024, 6, 2), Of(2024, 6, 9)),
+		ClosedRange(Of(2024, 6, 15), Of(2024, 6, 29)),
+		ClosedRange(Of(2024, 12, 31), Of(2024, 12, 31)),
+	}
+	if got := slices.Collect(a.RangeSet().Ranges()); !slices.Equal(got, want) {
+		t.Errorf("RangeSet() = %v, want %v", got, want)
+	}
+}
+
+func TestAvailabilityPanics(t *testing.T) {
+	t.Parallel()
+	a := NewAvailability(MonthRange(2024, time.June))
+	defer func() {
+		if recover() == nil {
+			t.Error("Set outside of range did not panic")
+		}
+	}()
+	a.Set(Of(2024, 7, 1))
+}
+
+func TestAvailabilityBinary(t *testing.T) {
+	t.Parallel()
+	for _, r := range []Range{{}, ClosedRange(Of(2024, 2, 3), Of(2024, 2, 12)), YearRange(2024), Range{-100, -36}} {
+		a := NewAvailability(r)
+		for d := range r.Dates() {
+			if d%3 == 0 || d%7 == 0 {
+				a.Set(d)
+			}
+		}
+		b, err := a.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() = %v", err)
+		}
+		var got Availability
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatalf("UnmarshalBinary(%x) = %v", b, err)
+		}
+		if got.Range() != r {
+			t.Errorf("UnmarshalBinary(%x).Range() = %v, want %v", b, got.Range(), r)
+		}
+		for d := range r.Dates() {
+			if got.Available(d) != a.Available(d) {
+				t.Errorf("UnmarshalBinary(%x).Available(%v) = %v, want %v", b, d, got.Available(d), a.Available(d))
+			}
+		}
+	}
+
+	invalid := []string{
+		"",
+		"\x02",
+		"\x02\x09\xff",
+		"\x02\x09\xff\x03",
+		"\x02\x09\xff\x01\x00",
+		"\x02\x10\xff",
+	}
+	for _, b := range invalid {
+		var a Availability
+		if err := a.UnmarshalBinary([]byte(b)); err == nil {
+			t.Errorf("UnmarshalBinary(%q) = nil, want error", b)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "time"
+
+// IsFirstOfMonth reports whether d is the first day of its month.
+func (d Date) IsFirstOfMonth() bool {
+	return d.Day() == 1
+}
+
+// IsLastOfMonth reports whether d is the last day of its month.
+func (d Date) IsLastOfMonth() bool {
+	return (d + 1).IsFirstOfMonth()
+}
+
+// IsFirstOfQuarter reports whether d is the first day of its quarter, that is
+// the first of January, April, July or October.
+func (d Date) IsFirstOfQuarter() bool {
+	_, month, day := d.Date()
+	return day == 1 && (month-1)%3 == 0
+}
+
+// IsLastOfQuarter reports whether d is the last day of its quarter, that is
+// the last of March, June, September or December.
+func (d Date) IsLastOfQuarter() bool {
+	return (d + 1).IsFirstOfQuarter()
+}
+
+// IsFirstOfYear reports whether d is the first of January.
+func (d Date) IsFirstOfYear() bool {
+	return d.YearDay() == 1
+}
+
+// IsLastOfYear reports whether d is the 31st of December.
+func (d Date) IsLastOfYear() bool {
+	return (d + 1).IsFirstOfYear()
+}
+
+// StartOfMonth returns the first day of the month of d.
+func (d Date) StartOfMonth() Date {
+	return d - Date(d.Day()-1)
+}
+
+// EndOfMonth returns the last day of the month of d.
+func (d Date) EndOfMonth() Date {
+	return d.YearMonth().Last()
+}
+
+// StartOfQuarter returns the first day of the quarter of d.
+func (d Date) StartOfQuarter() Date {
+	return d.YearQuarter().First()
+}
+
+// EndOfQuarter returns the last day of the quarter of d.
+func (d Date) EndOfQuarter() Date {
+	return d.YearQuarter().Last()
+}
+
+// StartOfYear returns the first of January of the year of d.
+func (d Date) StartOfYear() Date {
+	return d - Date(d.YearDay()-1)
+}
+
+// EndOfYear returns the 31st of December of the year of d.
+func (d Date) EndOfYear() Date {
+	return Of(d.Year(), time.December, 31)
+}
+
+// StartOfWeek returns the first day of the week of d, for weeks starting on
+// firstDay. For ISO 8601 weeks, firstDay is Monday. To move d to other days of
+// the week, use the [Next] and [Previous] adjusters.
+func (d Date) StartOfWeek(firstDay time.Weekday) Date {
+	return d - Date((7+d.Weekday()-firstDay%7)%7)
+}
+
+// EndOfWeek returns the last day of the week of d, for weeks starting on
+// firstDay.
+func (d Date) EndOfWeek(firstDay time.Weekday) Date {
+	return d.StartOfWeek(firstDay) + 6
+}
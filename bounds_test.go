@@ -0,0 +1,94 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodPredicates(t *testing.T) {
+	t.Parallel()
+	preds := []struct {
+		name string
+		f    func(Date) bool
+	}{
+		{"IsFirstOfMonth", Date.IsFirstOfMonth},
+		{"IsLastOfMonth", Date.IsLastOfMonth},
+		{"IsFirstOfQuarter", Date.IsFirstOfQuarter},
+		{"IsLastOfQuarter", Date.IsLastOfQuarter},
+		{"IsFirstOfYear", Date.IsFirstOfYear},
+		{"IsLastOfYear", Date.IsLastOfYear},
+	}
+	// want lists the expected results, in the order of preds.
+	tcs := []struct {
+		d    Date
+		want [6]bool
+	}{
+		{Of(2024, 1, 1), [6]bool{true, false, true, false, true, false}},
+		{Of(2024, 1, 31), [6]bool{false, true, false, false, false, false}},
+		{Of(2024, 2, 28), [6]bool{false, false, false, false, false, false}},
+		{Of(2024, 2, 29), [6]bool{false, true, false, false, false, false}},
+		{Of(2023, 2, 28), [6]bool{false, true, false, false, false, false}},
+		{Of(2024, 3, 31), [6]bool{false, true, false, true, false, false}},
+		{Of(2024, 4, 1), [6]bool{true, false, true, false, false, false}},
+		{Of(2024, 5, 1), [6]bool{true, false, false, false, false, false}},
+		{Of(2024, 5, 14), [6]bool{false, false, false, false, false, false}},
+		{Of(2024, 9, 30), [6]bool{false, true, false, true, false, false}},
+		{Of(2024, 12, 31), [6]bool{false, true, false, true, false, true}},
+	}
+	for _, tc := range tcs {
+		for i, p := range preds {
+			if got := p.f(tc.d); got != tc.want[i] {
+				t.Errorf("%v.%s() = %v, want %v", tc.d, p.name, got, tc.want[i])
+			}
+		}
+	}
+}
+
+func TestPeriodBounds(t *testing.T) {
+	t.Parallel()
+	bounds := []struct {
+		name string
+		f    func(Date) Date
+		want [2]Date // for 2024-05-14 and 2024-12-31
+	}{
+		{"StartOfMonth", Date.StartOfMonth, [2]Date{Of(2024, 5, 1), Of(2024, 12, 1)}},
+		{"EndOfMonth", Date.EndOfMonth, [2]Date{Of(2024, 5, 31), Of(2024, 12, 31)}},
+		{"StartOfQuarter", Date.StartOfQuarter, [2]Date{Of(2024, 4, 1), Of(2024, 10, 1)}},
+		{"EndOfQuarter", Date.EndOfQuarter, [2]Date{Of(2024, 6, 30), Of(2024, 12, 31)}},
+		{"StartOfYear", Date.StartOfYear, [2]Date{Of(2024, 1, 1), Of(2024, 1, 1)}},
+		{"EndOfYear", Date.EndOfYear, [2]Date{Of(2024, 12, 31), Of(2024, 12, 31)}},
+	}
+	for _, b := range bounds {
+		for i, d := range []Date{Of(2024, 5, 14), Of(2024, 12, 31)} {
+			if got := b.f(d); got != b.want[i] {
+				t.Errorf("%v.%s() = %v, want %v", d, b.name, got, b.want[i])
+			}
+		}
+	}
+
+	// 2024-05-14 is a Tuesday.
+	weeks := []struct {
+		d          Date
+		first      time.Weekday
+		start, end Date
+	}{
+		{Of(2024, 5, 14), time.Monday, Of(2024, 5, 13), Of(2024, 5, 19)},
+		{Of(2024, 5, 14), time.Sunday, Of(2024, 5, 12), Of(2024, 5, 18)},
+		{Of(2024, 5, 14), time.Tuesday, Of(2024, 5, 14), Of(2024, 5, 20)},
+		{Of(2024, 5, 14), time.Wednesday, Of(2024, 5, 8), Of(2024, 5, 14)},
+		{Of(2024, 5, 12), time.Monday, Of(2024, 5, 6), Of(2024, 5, 12)},
+	}
+	for _, tc := range weeks {
+		if got := tc.d.StartOfWeek(tc.first); got != tc.start {
+			t.Errorf("%v.StartOfWeek(%v) = %v, want %v", tc.d, tc.first, got, tc.start)
+		}
+		if got := tc.d.EndOfWeek(tc.first); got != tc.end {
+			t.Errorf("%v.EndOfWeek(%v) = %v, want %v", tc.d, tc.first, got, tc.end)
+		}
+	}
+}
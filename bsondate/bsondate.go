@@ -0,0 +1,130 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bsondate provides BSON representations of dates, for use with
+// MongoDB.
+//
+// The types implement the ValueMarshaler and ValueUnmarshaler interfaces of
+// go.mongodb.org/mongo-driver/v2/bson, without depending on it. Convert a
+// Date to one of them, to choose its representation:
+//
+//	type Booking struct {
+//		Arrival bsondate.String   `bson:"arrival"`
+//		Created bsondate.DateTime `bson:"created"`
+//	}
+//
+// When unmarshaling, both types accept both representations, so a field can
+// be migrated from one to the other. A BSON null is a no-op.
+package bsondate
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"gonih.org/date"
+)
+
+// BSON types, as defined in https://bsonspec.org/spec.html.
+const (
+	typeString   byte = 0x02
+	typeDateTime byte = 0x09
+	typeNull     byte = 0x0A
+)
+
+const msPerDay = 24 * 60 * 60 * 1000
+
+// String is a Date, which is represented in BSON as a string in ISO 8601
+// format, like "2024-05-14". This representation sorts correctly and is easy
+// to read, but can not be used with the date operators of MongoDB.
+type String date.Date
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface.
+func (s String) MarshalBSONValue() (typ byte, data []byte, err error) {
+	return typeString, appendString(nil, date.Date(s).String()), nil
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface.
+func (s *String) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshal((*date.Date)(s), typ, data)
+}
+
+// String returns the date formatted as ISO 8601.
+func (s String) String() string {
+	return date.Date(s).String()
+}
+
+// DateTime is a Date, which is represented in BSON as a UTC datetime at
+// midnight of the date. This representation can be used with the date
+// operators of MongoDB.
+type DateTime date.Date
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface.
+func (t DateTime) MarshalBSONValue() (typ byte, data []byte, err error) {
+	ms := date.Date(t).UnixDays() * msPerDay
+	return typeDateTime, binary.LittleEndian.AppendUint64(nil, uint64(ms)), nil
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface.
+func (t *DateTime) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshal((*date.Date)(t), typ, data)
+}
+
+// String returns the date formatted as ISO 8601.
+func (t DateTime) String() string {
+	return date.Date(t).String()
+}
+
+// unmarshal decodes a BSON string or datetime into d. A datetime is converted
+// to the date in UTC, discarding the time of day.
+func unmarshal(d *date.Date, typ byte, data []byte) error {
+	switch typ {
+	case typeNull:
+		return nil
+	case typeString:
+		s, err := readString(data)
+		if err != nil {
+			return err
+		}
+		v, err := date.Parse(date.RFC3339, s)
+		if err != nil {
+			return err
+		}
+		*d = v
+		return nil
+	case typeDateTime:
+		if len(data) != 8 {
+			return errors.New("BSON datetime must have 8 bytes")
+		}
+		ms := int64(binary.LittleEndian.Uint64(data))
+		days := ms / msPerDay
+		if ms%msPerDay < 0 {
+			days--
+		}
+		*d = date.FromUnixDays(days)
+		return nil
+	default:
+		return fmt.Errorf("cannot unmarshal BSON type %#02x into date", typ)
+	}
+}
+
+// appendString appends the BSON encoding of s to b.
+func appendString(b []byte, s string) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(s)+1))
+	b = append(b, s...)
+	return append(b, 0)
+}
+
+// readString decodes a BSON string.
+func readString(data []byte) (string, error) {
+	if len(data) < 5 {
+		return "", errors.New("BSON string truncated")
+	}
+	n := binary.LittleEndian.Uint32(data)
+	if uint64(n) != uint64(len(data)-4) || data[len(data)-1] != 0 {
+		return "", errors.New("invalid BSON string")
+	}
+	return string(data[4 : len(data)-1]), nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bsondate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"gonih.org/date"
+)
+
+func TestString(t *testing.T) {
+	t.Parallel()
+	d := date.Of(2024, 5, 14)
+	typ, data, err := String(d).MarshalBSONValue()
+	want := []byte("\x0b\x00\x00\x002024-05-14\x00")
+	if err != nil || typ != 0x02 || !bytes.Equal(data, want) {
+		t.Errorf("MarshalBSONValue() = %#02x, %q, %v, want 0x02, %q, <nil>", typ, data, err, want)
+	}
+	var s String
+	if err := s.UnmarshalBSONValue(typ, data); err != nil || date.Date(s) != d {
+		t.Errorf("UnmarshalBSONValue(%#02x, %q) = %v, want <nil>, result %v", typ, data, err, d)
+	}
+}
+
+func TestDateTime(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d  date.Date
+		ms int64
+	}{
+		{date.Of(2024, 5, 14), 1715644800000},
+		{date.Of(1970, 1, 1), 0},
+		{date.Of(1969, 12, 31), -86400000},
+	}
+	for _, tc := range tcs {
+		typ, data, err := DateTime(tc.d).MarshalBSONValue()
+		want := binary.LittleEndian.AppendUint64(nil, uint64(tc.ms))
+		if err != nil || typ != 0x09 || !bytes.Equal(data, want) {
+			t.Errorf("DateTime(%v).MarshalBSONValue() = %#02x, %x, %v, want 0x09, %x, <nil>", tc.d, typ, data, err, want)
+		}
+		var v DateTime
+		if err := v.UnmarshalBSONValue(typ, data); err != nil || date.Date(v) != tc.d {
+			t.Errorf("UnmarshalBSONValue(%#02x, %x) = %v, result %v, want <nil>, %v", typ, data, err, v, tc.d)
+		}
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+	ms := func(ms int64) []byte {
+		return binary.LittleEndian.AppendUint64(nil, uint64(ms))
+	}
+	tcs := []struct {
+		typ  byte
+		data []byte
+		want date.Date
+		err  bool
+	}{
+		{0x02, []byte("\x0b\x00\x00\x002024-05-14\x00"), date.Of(2024, 5, 14), false},
+		{0x09, ms(1715644800000), date.Of(2024, 5, 14), false},
+		{0x09, ms(1715644800000 + 23*3600*1000), date.Of(2024, 5, 14), false},
+		{0x09, ms(-1), date.Of(1969, 12, 31), false},
+		{0x0A, nil, 42, false},
+		{0x02, []byte("\x0b\x00\x00\x002024-05-14"), 0, true},
+		{0x02, []byte("\x0c\x00\x00\x002024-05-14\x00"), 0, true},
+		{0x02, []byte("\x0b\x00\x00\x002024-13-14\x00"), 0, true},
+		{0x02, []byte("\x00"), 0, true},
+		{0x09, ms(0)[:7], 0, true},
+		{0x10, []byte("\x01\x00\x00\x00"), 0, true},
+	}
+	for _, tc := range tcs {
+		s, dt := String(42), DateTime(42)
+		sErr := s.UnmarshalBSONValue(tc.typ, tc.data)
+		dtErr := dt.UnmarshalBSONValue(tc.typ, tc.data)
+		results := []struct {
+			name string
+			err  error
+			got  date.Date
+		}{
+			{"String", sErr, date.Date(s)},
+			{"DateTime", dtErr, date.Date(dt)},
+		}
+		for _, r := range results {
+			if tc.err {
+				if r.err == nil {
+					t.Errorf("%s.UnmarshalBSONValue(%#02x, %q) succeeded", r.name, tc.typ, tc.data)
+				}
+			} else if r.err != nil || r.got != tc.want {
+				t.Errorf("%s.UnmarshalBSONValue(%#02x, %q) = %v, result %v, want <nil>, %v", r.name, tc.typ, tc.data, r.err, r.got, tc.want)
+			}
+		}
+	}
+}
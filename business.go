@@ -0,0 +1,319 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// A BusinessCalendar determines which dates are business days. A date is a
+// business day, unless it falls on the weekend, it is a holiday observed in
+// one of the holiday calendars or it is an explicit closure date.
+//
+// The methods of a BusinessCalendar can be called concurrently, except for
+// [BusinessCalendar.Close], [BusinessCalendar.CloseOn] and
+// [BusinessCalendar.Precompute], which must not be called concurrently with
+// any other method.
+type BusinessCalendar struct {
+	weekend  WeekdaySet
+	cals     []Calendar
+	closures RangeSet
+	bitmap   *dayBitmap
+
+	mu sync.Mutex
+	// holidays maps years to the observed holidays in them.
+	holidays map[int]map[Date]bool
+}
+
+// NewBusinessCalendar returns a BusinessCalendar with the given weekend and
+// holiday calendars. Holidays are taken into account on the date they are
+// observed. It panics if weekend contains all days of the week.
+func NewBusinessCalendar(weekend WeekdaySet, holidays ...Calendar) *BusinessCalendar {
+	if weekend&allWeekdays == allWeekdays {
+		panic("NewBusinessCalendar: weekend contains all days of the week")
+	}
+	return &BusinessCalendar{
+		weekend:  weekend,
+		cals:     holidays,
+		holidays: make(map[int]map[Date]bool),
+	}
+}
+
+// Close marks all dates in r as closures, which are not business days. It
+// returns c, to allow chaining.
+func (c *BusinessCalendar) Close(r Range) *BusinessCalendar {
+	c.closures.Add(r)
+	if b := c.bitmap; b != nil {
+		if x, ok := r.Intersect(b.r); ok {
+			for d := range x.Dates() {
+				b.clear(d)
+			}
+		}
+	}
+	return c
+}
+
+// CloseOn marks the given dates as closures, which are not business days. It
+// returns c, to allow chaining.
+func (c *BusinessCalendar) CloseOn(ds ...Date) *BusinessCalendar {
+	for _, d := range ds {
+		c.Close(Range{d, d + 1})
+	}
+	return c
+}
+
+// Precompute computes the business days in the years from first to last,
+// inclusive, and stores them in a bitmap. For dates in these years,
+// IsBusinessDay then takes constant time and AddBusinessDays skips 64 days at
+// a time. This is useful in hot loops, at the cost of one bit of memory per
+// day. It returns c, to allow chaining.
+//
+// Precompute replaces any previously precomputed years.
+func (c *BusinessCalendar) Precompute(first, last int) *BusinessCalendar {
+	c.bitmap = nil
+	r := Range{YearRange(first).Start, YearRange(last).End}
+	if r.IsEmpty() {
+		return c
+	}
+	b := newDayBitmap(r)
+	for d := range r.Dates() {
+		if c.IsBusinessDay(d) {
+			b.set(d)
+		}
+	}
+	c.bitmap = b
+	return c
+}
+
+// IsBusinessDay reports whether d is a business day in c.
+func (c *BusinessCalendar) IsBusinessDay(d Date) bool {
+	if b := c.bitmap; b != nil && b.r.Contains(d) {
+		return b.get(d)
+	}
+	return !c.weekend.Contains(d.Weekday()) && !c.closures.Contains(d) && !c.isHoliday(d)
+}
+
+func (c *BusinessCalendar) isHoliday(d Date) bool {
+	if len(c.cals) == 0 {
+		return false
+	}
+	year := d.Year()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hs, ok := c.holidays[year]
+	if !ok {
+		hs = make(map[Date]bool)
+		r := YearRange(year)
+		for _, cal := range c.cals {
+			for _, h := range HolidaysIn(cal, r) {
+				hs[h.Observed] = true
+			}
+		}
+		c.holidays[year] = hs
+	}
+	return hs[d]
+}
+
+// NextBusinessDay returns the first business day after d.
+func (c *BusinessCalendar) NextBusinessDay(d Date) Date {
+	return c.AddBusinessDays(d, 1)
+}
+
+// PrevBusinessDay returns the last business day before d.
+func (c *BusinessCalendar) PrevBusinessDay(d Date) Date {
+	return c.AddBusinessDays(d, -1)
+}
+
+// BusinessDaysBetween returns the number of business days from a up to, but
+// not including, b. If b is before a, the result is negative, so that
+// BusinessDaysBetween(a, b) == -BusinessDaysBetween(b, a).
+//
+// For business days a and b, AddBusinessDays(a, BusinessDaysBetween(a, b))
+// returns b.
+func (c *BusinessCalendar) BusinessDaysBetween(a, b Date) int {
+	if b < a {
+		return -c.BusinessDaysBetween(b, a)
+	}
+	r := Range{a, b}
+	if bm := c.bitmap; bm != nil {
+		if x, ok := r.Intersect(bm.r); ok {
+			return c.countBusinessDays(Range{a, x.Start}) + bm.count(x) + c.countBusinessDays(Range{x.End, b})
+		}
+	}
+	return c.countBusinessDays(r)
+}
+
+// countBusinessDays returns the number of business days in r, checking each
+// date separately.
+func (c *BusinessCalendar) countBusinessDays(r Range) int {
+	n := 0
+	for d := range r.Dates() {
+		if c.IsBusinessDay(d) {
+			n++
+		}
+	}
+	return n
+}
+
+// IsFirstBusinessDayOfMonth reports whether d is the first business day of
+// its month.
+func (c *BusinessCalendar) IsFirstBusinessDayOfMonth(d Date) bool {
+	return c.IsBusinessDay(d) && c.AddBusinessDays(d, -1).Month() != d.Month()
+}
+
+// IsLastBusinessDayOfMonth reports whether d is the last business day of its
+// month. The method value c.IsLastBusinessDayOfMonth can be used as a
+// [Predicate].
+func (c *BusinessCalendar) IsLastBusinessDayOfMonth(d Date) bool {
+	return c.IsBusinessDay(d) && c.NextBusinessDay(d).Month() != d.Month()
+}
+
+// NearestBusinessDay returns the business day closest to d. If d is a
+// business day, it is returned unchanged. If two business days are equally
+// close, the later one is returned.
+//
+// With the usual Saturday and Sunday weekend, this moves a Saturday back to
+// Friday and a Sunday forward to Monday, as is common for observed dates.
+func (c *BusinessCalendar) NearestBusinessDay(d Date) Date {
+	if c.IsBusinessDay(d) {
+		return d
+	}
+	next, prev := c.AddBusinessDays(d, 1), c.AddBusinessDays(d, -1)
+	if next-d <= d-prev {
+		return next
+	}
+	return prev
+}
+
+// AddBusinessDays returns the date n business days after d, or before d if n
+// is negative. d itself does not need to be a business day. If n is zero, d
+// is returned unchanged.
+//
+// For example, adding one business day to a Friday returns the following
+// Monday, if that is a business day, and so does adding one business day to
+// the Saturday before.
+func (c *BusinessCalendar) AddBusinessDays(d Date, n int) Date {
+	step := Date(1)
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		if b := c.bitmap; b != nil && b.r.Contains(d+step) {
+			if step > 0 {
+				d, n = b.forward(d, n)
+			} else {
+				d, n = b.backward(d, n)
+			}
+			continue
+		}
+		d += step
+		if c.IsBusinessDay(d) {
+			n--
+		}
+	}
+	return d
+}
+
+// dayBitmap stores one bit per date in a range.
+type dayBitmap struct {
+	r    Range
+	bits []uint64
+}
+
+func newDayBitmap(r Range) *dayBitmap {
+	return &dayBitmap{r: r, bits: make([]uint64, (r.Days()+63)/64)}
+}
+
+func (b *dayBitmap) get(d Date) bool {
+	i := int(d - b.r.Start)
+	return b.bits[i/64]&(1<<(i%64)) != 0
+}
+
+func (b *dayBitmap) set(d Date) {
+	i := int(d - b.r.Start)
+	b.bits[i/64] |= 1 << (i % 64)
+}
+
+func (b *dayBitmap) clear(d Date) {
+	i := int(d - b.r.Start)
+	b.bits[i/64] &^= 1 << (i % 64)
+}
+
+// fill sets or clears all dates in r, which must be contained in b.
+func (b *dayBitmap) fill(r Range, v bool) {
+	b.words(r, func(i int, mask uint64) {
+		if v {
+			b.bits[i] |= mask
+		} else {
+			b.bits[i] &^= mask
+		}
+	})
+}
+
+// count returns the number of set dates in r, which must be contained in b.
+func (b *dayBitmap) count(r Range) int {
+	n := 0
+	b.words(r, func(i int, mask uint64) {
+		n += bits.OnesCount64(b.bits[i] & mask)
+	})
+	return n
+}
+
+// words calls f with the index and a mask of the bits of each word of b
+// covering dates in r.
+func (b *dayBitmap) words(r Range, f func(i int, mask uint64)) {
+	lo, hi := int(r.Start-b.r.Start), int(r.End-b.r.Start)
+	for lo < hi {
+		i := lo / 64
+		mask := ^uint64(0) << (lo % 64)
+		if end := (i + 1) * 64; hi < end {
+			mask &= ^uint64(0) >> (end - hi)
+		}
+		f(i, mask)
+		lo = (i + 1) * 64
+	}
+}
+
+// forward moves d forward by up to n set dates in b, starting at d+1, which
+// must be in b. It returns the new date and the number of dates left, which
+// is positive only if the end of b was reached.
+func (b *dayBitmap) forward(d Date, n int) (Date, int) {
+	i := int(d-b.r.Start) + 1
+	for i < b.r.Days() {
+		w := b.bits[i/64] >> (i % 64)
+		if c := bits.OnesCount64(w); c < n {
+			n -= c
+			i = (i/64 + 1) * 64
+			continue
+		}
+		for ; n > 1; n-- {
+			w &= w - 1 // clear lowest bit
+		}
+		return b.r.Start + Date(i+bits.TrailingZeros64(w)), 0
+	}
+	return b.r.Last(), n
+}
+
+// backward moves d backward by up to n set dates in b, starting at d-1, which
+// must be in b. It returns the new date and the number of dates left, which
+// is positive only if the start of b was reached.
+func (b *dayBitmap) backward(d Date, n int) (Date, int) {
+	i := int(d-b.r.Start) - 1
+	for i >= 0 {
+		w := b.bits[i/64] << (63 - i%64)
+		if c := bits.OnesCount64(w); c < n {
+			n -= c
+			i = i/64*64 - 1
+			continue
+		}
+		for ; n > 1; n-- {
+			w &^= 1 << (63 - bits.LeadingZeros64(w)) // clear highest bit
+		}
+		return b.r.Start + Date(i-bits.LeadingZeros64(w)), 0
+	}
+	return b.r.Start, n
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestBusinessCalendar(t *testing.T) {
+	t.Parallel()
+	holidays := NewHolidayCalendar(
+		FixedHoliday("New Year's Day", time.January, 1, ObserveNearestWeekday),
+		FixedHoliday("Christmas Day", time.December, 25, ObserveNearestWeekday),
+	)
+	c := NewBusinessCalendar(Weekend, holidays).
+		CloseOn(Of(2021, 12, 27)).
+		Close(Range{Of(2022, 1, 10), Of(2022, 1, 15)})
+
+	tcs := []struct {
+		d    Date
+		want bool
+	}{
+		{Of(2021, 12, 23), true},
+		{Of(2021, 12, 24), false}, // Christmas, observed
+		{Of(2021, 12, 25), false}, // Saturday
+		{Of(2021, 12, 27), false}, // closure
+		{Of(2021, 12, 28), true},
+		{Of(2021, 12, 31), false}, // New Year's Day 2022, observed
+		{Of(2022, 1, 3), true},
+		{Of(2022, 1, 10), false}, // closure
+		{Of(2022, 1, 14), false}, // closure
+		{Of(2022, 1, 17), true},
+	}
+	for _, tc := range tcs {
+		if got := c.IsBusinessDay(tc.d); got != tc.want {
+			t.Errorf("IsBusinessDay(%v) = %v, want %v", tc.d, got, tc.want)
+		}
+	}
+
+	adds := []struct {
+		d    Date
+		n    int
+		want Date
+	}{
+		{Of(2021, 12, 23), 0, Of(2021, 12, 23)},
+		{Of(2021, 12, 25), 0, Of(2021, 12, 25)},
+		{Of(2021, 12, 23), 1, Of(2021, 12, 28)},
+		{Of(2021, 12, 24), 1, Of(2021, 12, 28)},
+		{Of(2021, 12, 23), 4, Of(2022, 1, 3)},
+		{Of(2022, 1, 7), 1, Of(2022, 1, 17)},
+		{Of(2022, 1, 17), -1, Of(2022, 1, 7)},
+		{Of(2021, 12, 28), -1, Of(2021, 12, 23)},
+		{Of(2021, 12, 26), -2, Of(2021, 12, 22)},
+	}
+	for _, tc := range adds {
+		if got := c.AddBusinessDays(tc.d, tc.n); got != tc.want {
+			t.Errorf("AddBusinessDays(%v, %d) = %v, want %v", tc.d, tc.n, got, tc.want)
+		}
+	}
+	if got, want := c.NextBusinessDay(Of(2021, 12, 23)), Of(2021, 12, 28); got != want {
+		t.Errorf("NextBusinessDay(%v) = %v, want %v", Of(2021, 12, 23), got, want)
+	}
+	if got, want := c.PrevBusinessDay(Of(2021, 12, 28)), Of(2021, 12, 23); got != want {
+		t.Errorf("PrevBusinessDay(%v) = %v, want %v", Of(2021, 12, 28), got, want)
+	}
+
+	between := []struct {
+		a, b Date
+		want int
+	}{
+		{Of(2021, 12, 23), Of(2021, 12, 23), 0},
+		{Of(2021, 12, 23), Of(2021, 12, 24), 1},
+		{Of(2021, 12, 23), Of(2021, 12, 28), 1},
+		{Of(2021, 12, 23), Of(2022, 1, 3), 4},
+		{Of(2021, 12, 24), Of(2021, 12, 27), 0},
+		{Of(2022, 1, 7), Of(2022, 1, 17), 1},
+		{Of(2022, 1, 17), Of(2022, 1, 7), -1},
+	}
+	for _, tc := range between {
+		if got := c.BusinessDaysBetween(tc.a, tc.b); got != tc.want {
+			t.Errorf("BusinessDaysBetween(%v, %v) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestNearestBusinessDay(t *testing.T) {
+	t.Parallel()
+	c := NewBusinessCalendar(Weekend).CloseOn(Of(2024, 5, 15), Of(2024, 5, 23))
+	tcs := []struct {
+		d    Date
+		want Date
+	}{
+		{Of(2024, 5, 14), Of(2024, 5, 14)},
+		{Of(2024, 5, 15), Of(2024, 5, 16)}, // tie, later day wins
+		{Of(2024, 5, 18), Of(2024, 5, 17)}, // Saturday
+		{Of(2024, 5, 19), Of(2024, 5, 20)}, // Sunday
+		{Of(2024, 5, 25), Of(2024, 5, 24)}, // Saturday
+		{Of(2024, 5, 23), Of(2024, 5, 24)}, // tie, later day wins
+	}
+	for _, tc := range tcs {
+		if got := c.NearestBusinessDay(tc.d); got != tc.want {
+			t.Errorf("NearestBusinessDay(%v) = %v, want %v", tc.d, got, tc.want)
+		}
+	}
+	// Friday and Monday closed: Saturday is closer to Thursday.
+	c.CloseOn(Of(2024, 5, 31), Of(2024, 6, 3))
+	if got, want := c.NearestBusinessDay(Of(2024, 6, 1)), Of(2024, 5, 30); got != want {
+		t.Errorf("NearestBusinessDay(%v) = %v, want %v", Of(2024, 6, 1), got, want)
+	}
+	if got, want := c.NearestBusinessDay(Of(2024, 6, 2)), Of(2024, 6, 4); got != want {
+		t.Errorf("NearestBusinessDay(%v) = %v, want %v", Of(2024, 6, 2), got, want)
+	}
+}
+
+func TestBusinessCalendarWeekend(t *testing.T) {
+	t.Parallel()
+	// 2024-05-14 is a Tuesday.
+	c := NewBusinessCalendar(WeekdaysOf(time.Friday, time.Saturday))
+	if got, want := c.AddBusinessDays(Of(2024, 5, 16), 1), Of(2024, 5, 19); got != want {
+		t.Errorf("AddBusinessDays(%v, 1) = %v, want %v", Of(2024, 5, 16), got, want)
+	}
+	c = NewBusinessCalendar(0)
+	if got, want := c.AddBusinessDays(Of(2024, 5, 16), 3), Of(2024, 5, 19); got != want {
+		t.Errorf("AddBusinessDays(%v, 3) = %v, want %v", Of(2024, 5, 16), got, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("NewBusinessCalendar(Weekend|Workdays) did not panic")
+		}
+	}()
+	NewBusinessCalendar(Weekend | Workdays)
+}
+
+func TestBusinessCalendarPrecompute(t *testing.T) {
+	t.Parallel()
+	newCal := func() *BusinessCalendar {
+		return NewBusinessCalendar(Weekend, NewHolidayCalendar(
+			FixedHoliday("New Year's Day", time.January, 1, ObserveNearestWeekday),
+			EasterHoliday("Good Friday", -2, nil),
+			FixedHoliday("Christmas Day", time.December, 25, ObserveNearestWeekday),
+		)).Close(Range{Of(2023, 7, 1), Of(2023, 9, 1)})
+	}
+	slow := newCal().CloseOn(Of(2022, 5, 5))
+	fast := newCal().Precompute(2021, 2024).CloseOn(Of(2022, 5, 5))
+
+	for d := range (Range{Of(2020, 1, 1), Of(2026, 1, 1)}).Dates() {
+		if got, want := fast.IsBusinessDay(d), slow.IsBusinessDay(d); got != want {
+			t.Fatalf("IsBusinessDay(%v) = %v, want %v", d, got, want)
+		}
+	}
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for range 10000 {
+		d := Of(2020, 1, 1) + Date(rnd.IntN(6*365))
+		n := rnd.IntN(1000) - 500
+		if got, want := fast.AddBusinessDays(d, n), slow.AddBusinessDays(d, n); got != want {
+			t.Fatalf("AddBusinessDays(%v, %d) = %v, want %v", d, n, got, want)
+		}
+		e := d + Date(n)
+		if got, want := fast.BusinessDaysBetween(d, e), slow.BusinessDaysBetween(d, e); got != want {
+			t.Fatalf("BusinessDaysBetween(%v, %v) = %d, want %d", d, e, got, want)
+		}
+	}
+}
+
+func BenchmarkAddBusinessDays(b *testing.B) {
+	cal := func() *BusinessCalendar {
+		return NewBusinessCalendar(Weekend, NewHolidayCalendar(
+			FixedHoliday("New Year's Day", time.January, 1, ObserveNearestWeekday),
+			FixedHoliday("Christmas Day", time.December, 25, ObserveNearestWeekday),
+		))
+	}
+	d := Of(2024, 5, 14)
+	b.Run("Rules", func(b *testing.B) {
+		c := cal()
+		for i := 0; i < b.N; i++ {
+			c.AddBusinessDays(d, 250)
+		}
+	})
+	b.Run("Precomputed", func(b *testing.B) {
+		c := cal().Precompute(2020, 2030)
+		for i := 0; i < b.N; i++ {
+			c.AddBusinessDays(d, 250)
+		}
+	})
+}
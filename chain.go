@@ -0,0 +1,120 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "time"
+
+// A Chain wraps a Date to apply a sequence of adjustments by chaining method
+// calls, which reads better than nested function calls for complex
+// computations. For example, this returns the first business day of next
+// month:
+//
+//	d := date.From(today).AddMonths(1).FirstDayOfMonth().AddDays(-1).NextBusinessDay(cal).Date()
+//
+// Every method returns a new Chain, so a Chain can be stored and reused.
+type Chain struct {
+	d Date
+}
+
+// From returns a Chain starting at d.
+func From(d Date) Chain {
+	return Chain{d}
+}
+
+// Date returns the date of c.
+func (c Chain) Date() Date {
+	return c.d
+}
+
+// AddDays moves c by n days.
+func (c Chain) AddDays(n int) Chain {
+	return Chain{c.d + Date(n)}
+}
+
+// AddWeeks moves c by n weeks, as by [Date.AddWeeks].
+func (c Chain) AddWeeks(n int) Chain {
+	return Chain{c.d.AddWeeks(n)}
+}
+
+// AddMonths moves c by n months, as by [Date.AddMonths].
+func (c Chain) AddMonths(n int) Chain {
+	return Chain{c.d.AddMonths(n)}
+}
+
+// AddQuarters moves c by n quarters, as by [Date.AddQuarters].
+func (c Chain) AddQuarters(n int) Chain {
+	return Chain{c.d.AddQuarters(n)}
+}
+
+// AddYears moves c by n years. Like for AddMonths, February 29th is moved to
+// February 28th in years which are not leap years.
+func (c Chain) AddYears(n int) Chain {
+	return Chain{c.d.AddMonths(12 * n)}
+}
+
+// With applies the given adjusters, as by [Date.With].
+func (c Chain) With(adjs ...Adjuster) Chain {
+	return Chain{c.d.With(adjs...)}
+}
+
+// FirstDayOfMonth moves c to the first day of its month.
+func (c Chain) FirstDayOfMonth() Chain {
+	return c.With(FirstDayOfMonth())
+}
+
+// LastDayOfMonth moves c to the last day of its month.
+func (c Chain) LastDayOfMonth() Chain {
+	return c.With(LastDayOfMonth())
+}
+
+// FirstDayOfYear moves c to January 1st of its year.
+func (c Chain) FirstDayOfYear() Chain {
+	return c.With(FirstDayOfYear())
+}
+
+// LastDayOfYear moves c to December 31st of its year.
+func (c Chain) LastDayOfYear() Chain {
+	return c.With(LastDayOfYear())
+}
+
+// Next moves c to the first wd after it, as by [Next].
+func (c Chain) Next(wd time.Weekday) Chain {
+	return c.With(Next(wd))
+}
+
+// NextOrSame moves c to the first wd after it, unless it already falls on wd,
+// as by [NextOrSame].
+func (c Chain) NextOrSame(wd time.Weekday) Chain {
+	return c.With(NextOrSame(wd))
+}
+
+// Previous moves c to the last wd before it, as by [Previous].
+func (c Chain) Previous(wd time.Weekday) Chain {
+	return c.With(Previous(wd))
+}
+
+// PreviousOrSame moves c to the last wd before it, unless it already falls on
+// wd, as by [PreviousOrSame].
+func (c Chain) PreviousOrSame(wd time.Weekday) Chain {
+	return c.With(PreviousOrSame(wd))
+}
+
+// NextBusinessDay moves c to the first business day of cal after it.
+func (c Chain) NextBusinessDay(cal *BusinessCalendar) Chain {
+	return Chain{cal.NextBusinessDay(c.d)}
+}
+
+// AddBusinessDays moves c by n business days of cal, as by
+// [BusinessCalendar.AddBusinessDays].
+func (c Chain) AddBusinessDays(cal *BusinessCalendar, n int) Chain {
+	return Chain{cal.AddBusinessDays(c.d, n)}
+}
+
+// NearestBusinessDay moves c to the closest business day of cal, as by
+// [BusinessCalendar.NearestBusinessDay].
+func (c Chain) NearestBusinessDay(cal *BusinessCalendar) Chain {
+	return Chain{cal.NearestBusinessDay(c.d)}
+}
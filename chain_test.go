@@ -0,0 +1,46 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+	cal := NewBusinessCalendar(Weekend).CloseOn(Of(2024, 6, 3))
+	d := Of(2024, 2, 29)
+	tcs := []struct {
+		name string
+		got  Chain
+		want Date
+	}{
+		{"From", From(d), d},
+		{"AddDays", From(d).AddDays(-29), Of(2024, 1, 31)},
+		{"AddWeeks", From(d).AddWeeks(1), Of(2024, 3, 7)},
+		{"AddMonths", From(d).AddMonths(1), Of(2024, 3, 29)},
+		{"AddQuarters", From(d).AddQuarters(-1), Of(2023, 11, 29)},
+		{"AddYears", From(d).AddYears(1), Of(2025, 2, 28)},
+		{"FirstDayOfMonth", From(d).FirstDayOfMonth(), Of(2024, 2, 1)},
+		{"LastDayOfMonth", From(d).AddDays(1).LastDayOfMonth(), Of(2024, 3, 31)},
+		{"FirstDayOfYear", From(d).FirstDayOfYear(), Of(2024, 1, 1)},
+		{"LastDayOfYear", From(d).LastDayOfYear(), Of(2024, 12, 31)},
+		{"Next", From(d).Next(time.Thursday), Of(2024, 3, 7)},
+		{"NextOrSame", From(d).NextOrSame(time.Thursday), d},
+		{"Previous", From(d).Previous(time.Monday), Of(2024, 2, 26)},
+		{"PreviousOrSame", From(d).PreviousOrSame(time.Friday), Of(2024, 2, 23)},
+		{"With", From(d).With(FirstDayOfNextMonth()), Of(2024, 3, 1)},
+		{"NextBusinessDay", From(Of(2024, 5, 31)).NextBusinessDay(cal), Of(2024, 6, 4)},
+		{"AddBusinessDays", From(Of(2024, 6, 4)).AddBusinessDays(cal, -2), Of(2024, 5, 30)},
+		{"NearestBusinessDay", From(Of(2024, 6, 1)).NearestBusinessDay(cal), Of(2024, 5, 31)},
+	}
+	for _, tc := range tcs {
+		if got := tc.got.Date(); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command godate does date arithmetic and conversions, for use in scripts.
+//
+// Usage:
+//
+//	godate [-in layout] [-out layout] [-cal calendar] command [arg...]
+//
+// The commands are:
+//
+//	format date...      print the dates in the output layout
+//	add date n unit     add n days, weeks, months, quarters or years to date
+//	diff a b            print the number of days and the period from a to b
+//	week date           print the ISO 8601 week date of date
+//	bizdays first last  list the business days from first to last
+//
+// Dates are parsed using the input layout, which defaults to "2006-01-02". A
+// date not matching it is parsed as an English expression relative to the
+// current date in the local time zone, like "today" or "next Friday". Dates
+// are printed using the output layout, which defaults to the input layout.
+//
+// Business days are the days from Monday to Friday, which are not holidays of
+// the calendar given by -cal. Known calendars are de, jp, uk, us, nyse and
+// target2.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gonih.org/date"
+	"gonih.org/date/holidays/de"
+	"gonih.org/date/holidays/jp"
+	"gonih.org/date/holidays/market"
+	"gonih.org/date/holidays/uk"
+	"gonih.org/date/holidays/us"
+)
+
+const usage = "usage: godate [-in layout] [-out layout] [-cal calendar] command [arg...]"
+
+// calendars are the holiday calendars known to -cal.
+var calendars = map[string]date.Calendar{
+	"de":      de.National,
+	"jp":      jp.National,
+	"uk":      uk.EnglandAndWales,
+	"us":      us.Federal,
+	"nyse":    market.NYSE,
+	"target2": market.TARGET2,
+}
+
+// units are the names of units accepted by the add command.
+var units = map[string]date.Unit{
+	"day":      date.Days,
+	"days":     date.Days,
+	"week":     date.Weeks,
+	"weeks":    date.Weeks,
+	"month":    date.Months,
+	"months":   date.Months,
+	"quarter":  date.Quarters,
+	"quarters": date.Quarters,
+	"year":     date.Years,
+	"years":    date.Years,
+}
+
+// today returns the date relative expressions are interpreted against. It is
+// replaced by tests.
+var today = func() date.Date {
+	return date.Today(time.Local)
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "godate:", err)
+		os.Exit(1)
+	}
+}
+
+// cmd holds the state of an invocation.
+type cmd struct {
+	in, out string
+	cal     date.Calendar
+	w       io.Writer
+}
+
+// run runs godate with the given arguments, writing output to stdout and
+// usage messages to stderr.
+func run(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("godate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, usage)
+		fs.PrintDefaults()
+	}
+	in := fs.String("in", date.RFC3339, "`layout` to parse dates")
+	out := fs.String("out", "", "`layout` to print dates (default the input layout)")
+	cal := fs.String("cal", "", "holiday `calendar` for business days")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	c := &cmd{in: *in, out: *out, w: stdout}
+	if c.out == "" {
+		c.out = c.in
+	}
+	if *cal != "" {
+		var ok bool
+		if c.cal, ok = calendars[*cal]; !ok {
+			return fmt.Errorf("unknown calendar %q", *cal)
+		}
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	args = fs.Args()[1:]
+	switch fs.Arg(0) {
+	case "format":
+		return c.format(args)
+	case "add":
+		return c.add(args)
+	case "diff":
+		return c.diff(args)
+	case "week":
+		return c.week(args)
+	case "bizdays":
+		return c.bizdays(args)
+	}
+	return fmt.Errorf("unknown command %q", fs.Arg(0))
+}
+
+// parse parses the date s, as documented.
+func (c *cmd) parse(s string) (date.Date, error) {
+	d, err := date.Parse(c.in, s)
+	if err == nil {
+		return d, nil
+	}
+	if d, rerr := date.ParseRelative(s, today()); rerr == nil {
+		return d, nil
+	}
+	return 0, err
+}
+
+// parseArgs checks that there are n arguments and parses the first m of them
+// as dates.
+func (c *cmd) parseArgs(args []string, n, m int, use string) ([]date.Date, error) {
+	if len(args) != n {
+		return nil, errors.New("usage: godate " + use)
+	}
+	ds := make([]date.Date, m)
+	for i := range ds {
+		d, err := c.parse(args[i])
+		if err != nil {
+			return nil, err
+		}
+		ds[i] = d
+	}
+	return ds, nil
+}
+
+func (c *cmd) format(args []string) error {
+	for _, s := range args {
+		d, err := c.parse(s)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(c.w, d.Format(c.out))
+	}
+	return nil
+}
+
+func (c *cmd) add(args []string) error {
+	ds, err := c.parseArgs(args, 3, 1, "add date n unit")
+	if err != nil {
+		return err
+	}
+	n, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid number %q", args[1])
+	}
+	d := ds[0]
+	switch u, ok := units[strings.ToLower(args[2])]; {
+	case !ok:
+		return fmt.Errorf("unknown unit %q", args[2])
+	case u == date.Days:
+		d += date.Date(n)
+	case u == date.Weeks:
+		d = d.AddWeeks(n)
+	case u == date.Months:
+		d = d.AddMonths(n)
+	case u == date.Quarters:
+		d = d.AddQuarters(n)
+	case u == date.Years:
+		d = d.AddMonths(12 * n)
+	}
+	fmt.Fprintln(c.w, d.Format(c.out))
+	return nil
+}
+
+func (c *cmd) diff(args []string) error {
+	ds, err := c.parseArgs(args, 2, 2, "diff a b")
+	if err != nil {
+		return err
+	}
+	p := date.Between(ds[0], ds[1])
+	fmt.Fprintf(c.w, "%d days (%d years, %d months, %d days)\n", ds[1]-ds[0], p.Years, p.Months, p.Days)
+	return nil
+}
+
+func (c *cmd) week(args []string) error {
+	ds, err := c.parseArgs(args, 1, 1, "week date")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.w, date.FormatISOWeek(ds[0]))
+	return nil
+}
+
+func (c *cmd) bizdays(args []string) error {
+	ds, err := c.parseArgs(args, 2, 2, "bizdays first last")
+	if err != nil {
+		return err
+	}
+	var bc *date.BusinessCalendar
+	if c.cal != nil {
+		bc = date.NewBusinessCalendar(date.Weekend, c.cal)
+	} else {
+		bc = date.NewBusinessCalendar(date.Weekend)
+	}
+	for d := range date.ClosedRange(ds[0], ds[1]).Dates() {
+		if bc.IsBusinessDay(d) {
+			fmt.Fprintln(c.w, d.Format(c.out))
+		}
+	}
+	return nil
+}
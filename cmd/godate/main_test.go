@@ -0,0 +1,68 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"gonih.org/date"
+)
+
+func init() {
+	today = func() date.Date { return date.Of(2024, 5, 14) }
+}
+
+func TestRun(t *testing.T) {
+	tcs := []struct {
+		args string
+		want string
+	}{
+		{"format 2024-05-14", "2024-05-14\n"},
+		{"-out 02.01.2006 format 2024-05-14 2024-12-24", "14.05.2024\n24.12.2024\n"},
+		{"-in 01/02/2006 format 05/14/2024", "05/14/2024\n"},
+		{"format today tomorrow", "2024-05-14\n2024-05-15\n"},
+		{"add 2024-05-14 3 days", "2024-05-17\n"},
+		{"add 2024-05-14 -2 weeks", "2024-04-30\n"},
+		{"add 2024-01-31 1 month", "2024-02-29\n"},
+		{"add 2024-02-29 1 year", "2025-02-28\n"},
+		{"add 2024-05-14 1 Quarter", "2024-08-14\n"},
+		{"diff 2024-01-31 2024-03-01", "30 days (0 years, 1 months, 1 days)\n"},
+		{"diff 2024-05-14 2023-05-14", "-366 days (-1 years, 0 months, 0 days)\n"},
+		{"week 2024-12-30", "2025-W01-1\n"},
+		{"bizdays 2024-05-24 2024-05-28", "2024-05-24\n2024-05-27\n2024-05-28\n"},
+		{"-cal us bizdays 2024-05-24 2024-05-28", "2024-05-24\n2024-05-28\n"},
+	}
+	for _, tc := range tcs {
+		var out strings.Builder
+		if err := run(strings.Fields(tc.args), &out, io.Discard); err != nil || out.String() != tc.want {
+			t.Errorf("godate %s = %q, %v, want %q, <nil>", tc.args, out.String(), err, tc.want)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tcs := []struct {
+		args string
+		want string
+	}{
+		{"", "help requested"},
+		{"frobnicate", "unknown command"},
+		{"-cal mars bizdays 2024-05-24 2024-05-28", "unknown calendar"},
+		{"format 14.05.2024", "cannot parse"},
+		{"add 2024-05-14 3", "usage"},
+		{"add 2024-05-14 x days", "invalid number"},
+		{"add 2024-05-14 3 fortnights", "unknown unit"},
+		{"diff 2024-05-14", "usage"},
+	}
+	for _, tc := range tcs {
+		err := run(strings.Fields(tc.args), io.Discard, io.Discard)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("godate %s = %v, want error containing %q", tc.args, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "gonih.org/date/internal/cache"
+
+// memoize layout strings compiled by parseLayoutCompat.
+var memoCompat = cache.Cache[string, []inst]{MaxSize: defaultLayoutCacheSize, Size: layoutSize}
+
+// parseLayoutCompat is like parseLayout, but only recognizes the elements
+// supported by package time.
+func parseLayoutCompat(layout string) []inst {
+	return compileLayout(layout, modeCompat)
+}
+
+// parseTimeCompat parses value like time.Parse, as documented for
+// [Parser.TimeCompat].
+func parseTimeCompat(layout, value string) (Date, error) {
+	if d, ok := parseFast(layout, value); ok {
+		return d, nil
+	}
+	return parseProg(memoCompat.Get(layout, parseLayoutCompat), layout, value, Parser{}, nil)
+}
+
+// SetTimeCompat sets whether f formats dates exactly like [time.Time.Format]
+// and returns f. Like for [Parser.TimeCompat], only the elements of the layout
+// supported by package time are then recognized. The case set with
+// SetNameCase, the locale set with SetLocale and SetExtended are ignored.
+func (f *Formatter) SetTimeCompat(on bool) *Formatter {
+	f.compat = on
+	f.compile()
+	return f
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeCompat(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 22)
+	formats := []struct {
+		layout string
+		want   string
+		compat string
+	}{
+		{RFC3339, "2024-05-22", "2024-05-22"},
+		{"Jan 2nd 2006", "May 22nd 2024", "May 22nd 2024"},
+		{"Jan 2nd", "May 22nd", "May 22nd"},
+		{"Mo 2006", "Mo 2024", "Mo 2024"},
+		{"J 2006", "J 2024", "J 2024"},
+		{"«Q» 2006", "Q2 2024", "«Q» 2024"},
+	}
+	for _, tc := range formats {
+		if got := NewFormatter(tc.layout).Format(d); got != tc.want {
+			t.Errorf("NewFormatter(%q).Format(%v) = %q, want %q", tc.layout, d, got, tc.want)
+		}
+		f := NewFormatter(tc.layout).SetNameCase(UpperCase).SetExtended(true).SetTimeCompat(true)
+		if got := f.Format(d); got != tc.compat {
+			t.Errorf("NewFormatter(%q).SetTimeCompat(true).Format(%v) = %q, want %q", tc.layout, d, got, tc.compat)
+		}
+		if want := d.Time(0, 0, 0, 0, time.UTC).Format(tc.layout); tc.compat != want {
+			t.Errorf("(time.Time).Format(%q) = %q, want %q", tc.layout, want, tc.compat)
+		}
+		if got := NewFormatter(tc.layout).SetTimeCompat(true).SetTimeCompat(false).Format(d); got != tc.want {
+			t.Errorf("NewFormatter(%q).SetTimeCompat(true).SetTimeCompat(false).Format(%v) = %q, want %q", tc.layout, d, got, tc.want)
+		}
+	}
+
+	parses := []struct {
+		layout string
+		value  string
+		want   Date
+		err    bool
+	}{
+		{"Jan 2nd 2006", "May 22 2024", Of(2024, 5, 22), true},
+		{"Jan 2nd 2006", "May 22nd 2024", Of(2024, 5, 22), false},
+		{"Jan 2nd 2006", "May 23nd 2024", Of(2024, 5, 23), false},
+		{"Mo 2006", "Mo 2024", Of(2024, 1, 1), false},
+		{"Mo 2006", "We 2024", 0, true},
+		{"«Q» 2006", "«Q» 2024", Of(2024, 1, 1), false},
+		{" 2006", "2024", 0, true},
+	}
+	for _, tc := range parses {
+		ps := Parser{TimeCompat: true, TrimSpace: true, FuzzyMonths: true, Extended: true}
+		got, err := ps.Parse(tc.layout, tc.value)
+		if (err != nil) != tc.err || !tc.err && got != tc.want {
+			t.Errorf("Parse(%q, %q) = %v, %v, want %v, %v", tc.layout, tc.value, got, err, tc.want, tc.err)
+		}
+		T, errT := time.Parse(tc.layout, tc.value)
+		if (errT != nil) != tc.err || !tc.err && Of(T.Date()) != tc.want {
+			t.Errorf("time.Parse(%q, %q) = %v, %v, want %v, %v", tc.layout, tc.value, T, errT, tc.want, tc.err)
+		}
+	}
+}
+
+func FuzzTimeCompat(f *testing.F) {
+	f.Add([]byte{byte(opDay), 0, 2, 'n', 'd'}, "22nd", 0)
+	f.Add([]byte{byte(opOrdinalDay), byte(opMinWeekDay)}, "22ndMo", 0)
+	f.Fuzz(func(t *testing.T, progBytes []byte, value string, date int) {
+		layout, ok := decodeProg(progBytes)
+		if !ok {
+			return
+		}
+		d, errD := Parser{TimeCompat: true}.Parse(layout, value)
+		T, errT := time.Parse(layout, value)
+		if (errD == nil) != (errT == nil) {
+			t.Fatalf("Parse(%q, %q) returned different error from time.Parse: got %v, want %v", layout, value, errD, errT)
+		}
+		if td := Of(T.Date()); d != td {
+			t.Fatalf("Parse(%q, %q) returned different date than time.Parse: got %#v, want %#v", layout, value, d, td)
+		}
+		d = Date(date)
+		got, want := NewFormatter(layout).SetTimeCompat(true).Format(d), d.Time(8, 0, 0, 0, time.UTC).Format(layout)
+		if got != want {
+			t.Fatalf("%#v.Format(%q) returned different string from (time.Time).Format: got %q, want %q", d, layout, got, want)
+		}
+	})
+}
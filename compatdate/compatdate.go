@@ -0,0 +1,108 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package compatdate converts between Date and the date types of other
+// libraries, to migrate code to package date incrementally.
+//
+// The conversions do not depend on the other libraries. They rely on the
+// methods and representations of their types instead:
+//
+//   - The Date of github.com/rickb777/date counts days since the Unix epoch.
+//     Convert it with [FromEpochDays] and [ToEpochDays]:
+//
+//     d := compatdate.FromEpochDays(rd.DaysSinceEpoch())
+//     rd := rickdate.NewOfDays(compatdate.ToEpochDays[rickdate.PeriodOfDays](d))
+//
+//   - The Date of github.com/fxtlabs/date wraps a [time.Time] at midnight UTC.
+//     Convert it with [FromCivil] and its constructor:
+//
+//     d := compatdate.FromCivil(fd)
+//     fd := fxtdate.New(d.Date())
+//
+//   - The Date of cloud.google.com/go/civil is a struct with the fields
+//     Year, Month and Day. Convert it with [FromYMD] and [ToYMD], as shown
+//     for [Fields].
+//
+//   - The functions of github.com/jinzhu/now return a time.Time, which is
+//     converted with [FromCivil], using the date in its location. Convert a
+//     Date to a time.Time with [date.Date.Time] to pass it to now.With.
+//
+// Types of other libraries with the same methods can be converted the same
+// way. To migrate models using time.Time for dates, [Copy] copies between
+// structs, converting fields of type time.Time and Date.
+package compatdate
+
+import (
+	"fmt"
+	"time"
+
+	"gonih.org/date"
+)
+
+// Civil is implemented by types representing a calendar date, like
+// [time.Time] and the date types of most libraries.
+type Civil interface {
+	Year() int
+	Month() time.Month
+	Day() int
+}
+
+// FromCivil returns the Date of c.
+func FromCivil(c Civil) date.Date {
+	if d, ok := c.(interface {
+		Date() (int, time.Month, int)
+	}); ok {
+		// Calculating the date once is cheaper, for types like time.Time.
+		return date.Of(d.Date())
+	}
+	return date.Of(c.Year(), c.Month(), c.Day())
+}
+
+// Integer is the set of integer types a number of days can be stored in.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// FromEpochDays returns the Date n days after the Unix epoch, 1970-01-01.
+func FromEpochDays[T Integer](n T) date.Date {
+	return date.FromUnixDays(int64(n))
+}
+
+// ToEpochDays returns the number of days from the Unix epoch, 1970-01-01, to
+// d, as a T. It panics, if the number overflows T.
+func ToEpochDays[T Integer](d date.Date) T {
+	n := d.UnixDays()
+	if int64(T(n)) != n {
+		var zero T
+		panic(fmt.Errorf("compatdate: %v overflows %T", d, zero))
+	}
+	return T(n)
+}
+
+// Fields returns pointers to the year, month and day fields of a struct of
+// type T, for use with [FromYMD] and [ToYMD]. For example, for the Date of
+// cloud.google.com/go/civil:
+//
+//	fields := func(cd *civil.Date) (*int, *time.Month, *int) {
+//		return &cd.Year, &cd.Month, &cd.Day
+//	}
+//	d := compatdate.FromYMD(cd, fields)
+//	cd := compatdate.ToYMD(d, fields)
+type Fields[T any] func(*T) (year *int, month *time.Month, day *int)
+
+// FromYMD returns the Date of v, reading its year, month and day through f.
+func FromYMD[T any](v T, f Fields[T]) date.Date {
+	year, month, day := f(&v)
+	return date.Of(*year, *month, *day)
+}
+
+// ToYMD returns a T representing d, setting its year, month and day through f.
+// Other fields of the T are zero.
+func ToYMD[T any](d date.Date, f Fields[T]) T {
+	var v T
+	year, month, day := f(&v)
+	*year, *month, *day = d.Date()
+	return v
+}
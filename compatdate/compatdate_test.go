@@ -0,0 +1,96 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package compatdate
+
+import (
+	"testing"
+	"time"
+
+	"gonih.org/date"
+)
+
+// periodOfDays mirrors the representation of github.com/rickb777/date.
+type periodOfDays int32
+
+// wrapped mirrors github.com/fxtlabs/date, which has no Date method.
+type wrapped struct{ t time.Time }
+
+func (w wrapped) Year() int         { return w.t.Year() }
+func (w wrapped) Month() time.Month { return w.t.Month() }
+func (w wrapped) Day() int          { return w.t.Day() }
+
+// civilDate mirrors cloud.google.com/go/civil.
+type civilDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func TestYMD(t *testing.T) {
+	t.Parallel()
+	fields := func(cd *civilDate) (*int, *time.Month, *int) {
+		return &cd.Year, &cd.Month, &cd.Day
+	}
+	tcs := []struct {
+		cd civilDate
+		d  date.Date
+	}{
+		{civilDate{2024, 5, 14}, date.Of(2024, 5, 14)},
+		{civilDate{1970, 1, 1}, date.UnixEpoch},
+		{civilDate{-1, 12, 31}, date.Of(-1, 12, 31)},
+	}
+	for _, tc := range tcs {
+		if got := FromYMD(tc.cd, fields); got != tc.d {
+			t.Errorf("FromYMD(%+v) = %v, want %v", tc.cd, got, tc.d)
+		}
+		if got := ToYMD(tc.d, fields); got != tc.cd {
+			t.Errorf("ToYMD(%v) = %+v, want %+v", tc.d, got, tc.cd)
+		}
+	}
+}
+
+func TestFromCivil(t *testing.T) {
+	t.Parallel()
+	want := date.Of(2024, 5, 14)
+	tcs := []Civil{
+		time.Date(2024, 5, 14, 23, 59, 0, 0, time.UTC),
+		time.Date(2024, 5, 14, 0, 30, 0, 0, time.FixedZone("", 14*3600)),
+		wrapped{time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC)},
+		want,
+	}
+	for _, c := range tcs {
+		if got := FromCivil(c); got != want {
+			t.Errorf("FromCivil(%v) = %v, want %v", c, got, want)
+		}
+	}
+}
+
+func TestEpochDays(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		n periodOfDays
+		d date.Date
+	}{
+		{0, date.UnixEpoch},
+		{19857, date.Of(2024, 5, 14)},
+		{-1, date.Of(1969, 12, 31)},
+	}
+	for _, tc := range tcs {
+		if got := FromEpochDays(tc.n); got != tc.d {
+			t.Errorf("FromEpochDays(%d) = %v, want %v", tc.n, got, tc.d)
+		}
+		if got := ToEpochDays[periodOfDays](tc.d); got != tc.n {
+			t.Errorf("ToEpochDays(%v) = %d, want %d", tc.d, got, tc.n)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ToEpochDays[int8](%v) did not panic", date.Of(2024, 5, 14))
+		}
+	}()
+	ToEpochDays[int8](date.Of(2024, 5, 14))
+}
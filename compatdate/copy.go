@@ -0,0 +1,181 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package compatdate
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"gonih.org/date"
+	"gonih.org/date/internal/cache"
+)
+
+var (
+	timeType = reflect.TypeFor[time.Time]()
+	dateType = reflect.TypeFor[date.Date]()
+)
+
+// A field copies a field of the source struct to a field of the destination
+// struct.
+type field struct {
+	src, dst []int // indices, as used by reflect.Value.FieldByIndex
+	conv     conversion
+}
+
+// conversion is the conversion done when copying a field.
+type conversion int
+
+const (
+	assign     conversion = iota // the field is assigned unchanged
+	timeToDate                   // time.Time or *time.Time to date.Date or *date.Date
+	dateToTime                   // date.Date or *date.Date to time.Time or *time.Time
+)
+
+// plans caches the fields to copy, for pairs of source and destination types.
+var plans cache.Cache[[2]reflect.Type, []field]
+
+// Copy copies the fields of the struct src to the fields with the same name
+// of the struct pointed to by dst. It is meant to migrate a model using
+// [time.Time] for dates to one using [date.Date], or back, without writing a
+// mapper for every type.
+//
+// Fields of types time.Time and date.Date are converted into each other, as
+// are pointers to them, with nil being copied as nil. A time.Time is converted
+// to its date in loc, a Date to midnight of the date in loc. Other fields are
+// copied, if their types are assignable. Nested structs are copied
+// field-by-field.
+//
+// The name of the field copied to can be changed with a struct tag on the
+// field of src, like `compatdate:"Birthday"`. A tag of `compatdate:"-"` skips
+// the field. Unexported fields, as well as fields of src which do not exist in
+// dst, are skipped. Copy returns an error, if dst is not a pointer to a struct,
+// src is not a struct or a pointer to one, or the types of matching fields can
+// not be converted.
+//
+// For example:
+//
+//	type Legacy struct {
+//		Name     string
+//		Birthday time.Time
+//	}
+//	type Person struct {
+//		Name     string
+//		Birthday date.Date
+//	}
+//	var p Person
+//	err := compatdate.Copy(&p, legacy, time.Local)
+func Copy(dst, src any, loc *time.Location) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("compatdate: destination must be a non-nil pointer to a struct, not %T", dst)
+	}
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Pointer && !sv.IsNil() {
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("compatdate: source must be a struct or a pointer to one, not %T", src)
+	}
+	dv = dv.Elem()
+	fields, err := plans.GetErr([2]reflect.Type{sv.Type(), dv.Type()}, func(k [2]reflect.Type) ([]field, error) {
+		return plan(k[0], k[1], nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		f.copy(dv.FieldByIndex(f.dst), sv.FieldByIndex(f.src), loc)
+	}
+	return nil
+}
+
+// plan returns the fields to copy from the struct type st to the struct type
+// dt. The indices of the fields are prefixed with sidx and didx.
+func plan(st, dt reflect.Type, sidx, didx []int) ([]field, error) {
+	var fields []field
+	for i := range st.NumField() {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := sf.Name
+		if tag, ok := sf.Tag.Lookup("compatdate"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+		df, ok := dt.FieldByName(name)
+		if !ok || len(df.Index) > 1 || !df.IsExported() {
+			continue
+		}
+		si, di := append(sidx[:len(sidx):len(sidx)], sf.Index...), append(didx[:len(didx):len(didx)], df.Index...)
+		conv, ok := convert(sf.Type, df.Type)
+		if ok {
+			fields = append(fields, field{si, di, conv})
+			continue
+		}
+		if sf.Type.Kind() == reflect.Struct && df.Type.Kind() == reflect.Struct {
+			nested, err := plan(sf.Type, df.Type, si, di)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, nested...)
+			continue
+		}
+		return nil, fmt.Errorf("compatdate: can not copy %v.%s of type %v to %v.%s of type %v", st, sf.Name, sf.Type, dt, df.Name, df.Type)
+	}
+	return fields, nil
+}
+
+// convert returns the conversion to copy a field of type st to one of type
+// dt, if any.
+func convert(st, dt reflect.Type) (conversion, bool) {
+	if st.AssignableTo(dt) {
+		return assign, true
+	}
+	if st.Kind() == reflect.Pointer && dt.Kind() == reflect.Pointer {
+		st, dt = st.Elem(), dt.Elem()
+	} else if st.Kind() == reflect.Pointer || dt.Kind() == reflect.Pointer {
+		return 0, false
+	}
+	switch {
+	case st == timeType && dt == dateType:
+		return timeToDate, true
+	case st == dateType && dt == timeType:
+		return dateToTime, true
+	}
+	return 0, false
+}
+
+// copy copies s to d, which are the fields described by f.
+func (f field) copy(d, s reflect.Value, loc *time.Location) {
+	if f.conv == assign {
+		d.Set(s)
+		return
+	}
+	ptr := s.Kind() == reflect.Pointer
+	if ptr {
+		if s.IsNil() {
+			d.SetZero()
+			return
+		}
+		s = s.Elem()
+	}
+	var v reflect.Value
+	if f.conv == timeToDate {
+		v = reflect.ValueOf(date.Of(s.Interface().(time.Time).In(loc).Date()))
+	} else {
+		v = reflect.ValueOf(s.Interface().(date.Date).Time(0, 0, 0, 0, loc))
+	}
+	if ptr {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p
+	}
+	d.Set(v)
+}
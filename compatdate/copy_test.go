@@ -0,0 +1,117 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package compatdate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gonih.org/date"
+)
+
+type legacyAddress struct {
+	City  string
+	Moved time.Time
+}
+
+type legacy struct {
+	Name     string
+	Birthday time.Time
+	Died     *time.Time
+	Joined   time.Time `compatdate:"Member"`
+	Secret   string    `compatdate:"-"`
+	Address  legacyAddress
+	Unknown  int
+	ignored  int
+}
+
+type address struct {
+	City  string
+	Moved date.Date
+}
+
+type person struct {
+	Name     string
+	Birthday date.Date
+	Died     *date.Date
+	Member   date.Date
+	Secret   string
+	Address  address
+}
+
+func TestCopy(t *testing.T) {
+	t.Parallel()
+	tz := time.FixedZone("", 2*3600)
+	src := legacy{
+		Name:     "Ada",
+		Birthday: time.Date(1815, 12, 10, 23, 0, 0, 0, time.UTC),
+		Joined:   time.Date(1833, 6, 5, 12, 0, 0, 0, time.UTC),
+		Secret:   "x",
+		Address:  legacyAddress{"London", time.Date(1835, 7, 8, 0, 0, 0, 0, tz)},
+		Unknown:  42,
+		ignored:  23,
+	}
+	var got person
+	if err := Copy(&got, &src, tz); err != nil {
+		t.Fatalf("Copy(person, legacy) = %v, want <nil>", err)
+	}
+	want := person{
+		Name:     "Ada",
+		Birthday: date.Of(1815, 12, 11),
+		Member:   date.Of(1833, 6, 5),
+		Address:  address{"London", date.Of(1835, 7, 8)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Copy(person, legacy) = %+v, want %+v", got, want)
+	}
+
+	died := date.Of(1852, 11, 27)
+	got.Died = &died
+	var back legacy
+	if err := Copy(&back, got, time.UTC); err != nil {
+		t.Fatalf("Copy(legacy, person) = %v, want <nil>", err)
+	}
+	if want := time.Date(1815, 12, 11, 0, 0, 0, 0, time.UTC); !back.Birthday.Equal(want) {
+		t.Errorf("Copy(legacy, person).Birthday = %v, want %v", back.Birthday, want)
+	}
+	if want := time.Date(1852, 11, 27, 0, 0, 0, 0, time.UTC); back.Died == nil || !back.Died.Equal(want) {
+		t.Errorf("Copy(legacy, person).Died = %v, want %v", back.Died, want)
+	}
+	if !back.Joined.IsZero() {
+		t.Errorf("Copy(legacy, person).Joined = %v, want zero", back.Joined)
+	}
+	if back.Secret != "" {
+		t.Errorf("Copy(legacy, person).Secret = %q, want \"\"", back.Secret)
+	}
+}
+
+// ints is a named slice type, to check that pointers to it are not confused
+// with pointers to its underlying type.
+type ints []int
+
+func TestCopyErrors(t *testing.T) {
+	t.Parallel()
+	var p person
+	tcs := []struct {
+		dst, src any
+		want     string
+	}{
+		{p, legacy{}, "destination must be"},
+		{(*person)(nil), legacy{}, "destination must be"},
+		{&p, 42, "source must be"},
+		{&p, struct{ Name int }{}, "can not copy"},
+		{&p, struct{ Address struct{ City int } }{}, "can not copy"},
+		{&struct{ V *ints }{}, struct{ V *[]int }{}, "can not copy"},
+	}
+	for _, tc := range tcs {
+		err := Copy(tc.dst, tc.src, time.UTC)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Copy(%T, %T) = %v, want error containing %q", tc.dst, tc.src, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+// The methods in this file implement the interfaces used by CSV libraries
+// mapping records to structs, like github.com/gocarina/gocsv:
+//
+//	type Marshaler interface {
+//		MarshalCSV() (string, error)
+//	}
+//	type Unmarshaler interface {
+//		UnmarshalCSV(string) error
+//	}
+
+// MarshalCSV returns the date formatted in ISO 8601 format.
+func (d Date) MarshalCSV() (string, error) {
+	return d.String(), nil
+}
+
+// UnmarshalCSV parses a date in ISO 8601 format.
+func (d *Date) UnmarshalCSV(s string) error {
+	v, err := Parse(RFC3339, s)
+	if err == nil {
+		*d = v
+	}
+	return err
+}
+
+// MarshalCSV returns the date formatted using f.Layout.
+func (f Formatted) MarshalCSV() (string, error) {
+	return f.String(), nil
+}
+
+// UnmarshalCSV parses the date using f.Layout, which is left unchanged. Note
+// that CSV libraries usually unmarshal into new records, with an empty layout,
+// so the date must then be in [RFC3339] format.
+func (f *Formatted) UnmarshalCSV(s string) error {
+	return f.UnmarshalText([]byte(s))
+}
+
+// MarshalCSV returns the date formatted in ISO 8601 format, or an empty string
+// if it is absent.
+func (o Optional) MarshalCSV() (string, error) {
+	b, err := o.MarshalText()
+	return string(b), err
+}
+
+// UnmarshalCSV parses a date in ISO 8601 format. An empty string is an absent
+// date, so empty cells can be unmarshaled.
+func (o *Optional) UnmarshalCSV(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
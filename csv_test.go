@@ -0,0 +1,56 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"reflect"
+	"testing"
+)
+
+// csvMarshaler and csvUnmarshaler are the interfaces of
+// github.com/gocarina/gocsv.
+type (
+	csvMarshaler interface {
+		MarshalCSV() (string, error)
+	}
+	csvUnmarshaler interface {
+		csvMarshaler
+		UnmarshalCSV(string) error
+	}
+)
+
+func TestCSV(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 14)
+	tcs := []struct {
+		v   csvUnmarshaler // a pointer to the value to marshal
+		new csvUnmarshaler // a pointer to the value to unmarshal into
+		s   string
+	}{
+		{&d, new(Date), "2024-05-14"},
+		{&Formatted{d, "01/02/2006"}, &Formatted{Layout: "01/02/2006"}, "05/14/2024"},
+		{&Formatted{Date: d}, &Formatted{}, "2024-05-14"},
+		{&Optional{}, &Optional{d, true}, ""},
+		{&Optional{d, true}, &Optional{}, "2024-05-14"},
+	}
+	for _, tc := range tcs {
+		s, err := tc.v.MarshalCSV()
+		if err != nil || s != tc.s {
+			t.Errorf("%v.MarshalCSV() = %q, %v, want %q, <nil>", tc.v, s, err, tc.s)
+		}
+		if err := tc.new.UnmarshalCSV(tc.s); err != nil {
+			t.Errorf("UnmarshalCSV(%q) = %v, want <nil>", tc.s, err)
+		}
+		if !reflect.DeepEqual(tc.new, tc.v) {
+			t.Errorf("UnmarshalCSV(%q) = %v, want %v", tc.s, tc.new, tc.v)
+		}
+	}
+	for _, v := range []csvUnmarshaler{new(Date), new(Formatted), new(Optional)} {
+		if err := v.UnmarshalCSV("14.05.2024"); err == nil {
+			t.Errorf("%T.UnmarshalCSV(%q) = <nil>, want error", v, "14.05.2024")
+		}
+	}
+}
@@ -32,13 +32,20 @@
 //
 // There is no equivalent to time.Duration. The correct unit for that would be
 // a Day. Given that Date already represents a number of days, it can be
-// directly compared/added to/subtracted from.
+// directly compared/added to/subtracted from. For amounts of calendar time,
+// like "one month", use a [Period].
+//
+// Support for encoding/json/v2, like the MarshalJSONTo and UnmarshalJSONFrom
+// methods of Date, is experimental, as that package is. It is only built with
+// Go 1.27 or later and GOEXPERIMENT=jsonv2.
 package date
 
 import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
+	"sync/atomic"
 	"time"
 )
 
@@ -109,9 +116,63 @@ func daysIn(m time.Month, year int) int {
 	return int(daysBefore[m] - daysBefore[m-1])
 }
 
+// Dates in the years tableFirstYear to tableLastYear are converted using
+// lookup tables, instead of the divisions needed in general.
+const (
+	tableFirstYear = 1900
+	tableLastYear  = 2100
+)
+
+var (
+	// tableStart is the absolute date of the start of tableFirstYear.
+	tableStart = uint64(daysSinceEpoch(tableFirstYear))
+
+	// yearStarts[i] is the offset from tableStart of the start of year
+	// tableFirstYear+i. The last entry is the end of tableLastYear.
+	yearStarts [tableLastYear - tableFirstYear + 2]uint32
+
+	// monthDays[leap][yday] is the month and day of the given day of the
+	// year, packed as month<<8 | day.
+	monthDays [2][366]uint16
+)
+
+func init() {
+	for i := range yearStarts {
+		yearStarts[i] = uint32(uint64(daysSinceEpoch(tableFirstYear+i)) - tableStart)
+	}
+	for leap, year := range [2]int{2001, 2000} {
+		for yday := range 365 + leap {
+			_, month, day, _ := absDateSlow(uint64(daysSinceEpoch(year)+yday), true)
+			monthDays[leap][yday] = uint16(month)<<8 | uint16(day)
+		}
+	}
+}
+
 // absDate computes the year, day of year and when full=true, the month and day
 // in which an absolute date occurs.
 func absDate(abs uint64, full bool) (year int, month time.Month, day int, yday int) {
+	i := abs - tableStart
+	if i >= uint64(yearStarts[len(yearStarts)-1]) {
+		return absDateSlow(abs, full)
+	}
+	// Accumulated leap days are fewer than 365, so the estimate is too high
+	// by at most one.
+	y := i / 365
+	if i < uint64(yearStarts[y]) {
+		y--
+	}
+	year = tableFirstYear + int(y)
+	yday = int(i - uint64(yearStarts[y]))
+	if !full {
+		return year, 0, 0, yday
+	}
+	leap := yearStarts[y+1] - yearStarts[y] - 365
+	md := monthDays[leap][yday]
+	return year, time.Month(md >> 8), int(md & 0xff), yday
+}
+
+// absDateSlow is the general implementation of absDate.
+func absDateSlow(abs uint64, full bool) (year int, month time.Month, day int, yday int) {
 	d := abs
 
 	// Account for 400 year cycles.
@@ -257,11 +318,83 @@ func Of(year int, month time.Month, day int) Date {
 	return Date(d - internalToAbsolute)
 }
 
-// Today returns the current date in the given location.
+// The range of years supported by [OfChecked]. Within it, Of and
+// [Date.Date] are inverse to each other. It is the same as the range of years
+// of [time.Time].
+const (
+	MinYear = absoluteZeroYear
+	MaxYear = 292277026596
+)
+
+// The range of valid dates, the first day of MinYear and the last day of
+// MaxYear. A Date outside of it can not be converted to a year, month and day
+// correctly.
+const (
+	MinDate Date = -106751990353932
+	MaxDate Date = 106751991886489
+)
+
+// IsValid reports whether d is in the range MinDate…MaxDate.
+func (d Date) IsValid() bool {
+	return MinDate <= d && d <= MaxDate
+}
+
+// OfChecked is like [Of], but returns an error instead of an invalid Date, if
+// the normalized date is not in the years MinYear…MaxYear. This is useful for
+// validating dates computed from untrusted input or far outside of historic
+// time, like in geology or astronomy, where Of would silently overflow.
+func OfChecked(year int, month time.Month, day int) (Date, error) {
+	// Normalize the month, detecting overflow of the year.
+	q, m := norm(0, int(month)-1, 12)
+	if addOverflows(year, q) {
+		return 0, fmt.Errorf("year %d%+d overflows int", year, q)
+	}
+	if year += q; year < MinYear || year > MaxYear {
+		return 0, fmt.Errorf("year %d out of range [%d, %d]", year, MinYear, MaxYear)
+	}
+	first := Of(year, time.Month(m)+1, 1)
+	lo, hi := Of(MinYear, time.January, 1), Of(MaxYear, time.December, 31)
+	if day < int(lo-first)+1 || day > int(hi-first)+1 {
+		return 0, fmt.Errorf("day %d of %v %d out of range", day, time.Month(m)+1, year)
+	}
+	return first + Date(day-1), nil
+}
+
+// Today returns the current date in the given location. Use [Current] for the
+// current date in the default location.
 func Today(loc *time.Location) Date {
 	return Of(time.Now().In(loc).Date())
 }
 
+// defaultLoc is the location set by SetDefaultLocation, or nil for time.Local.
+var defaultLoc atomic.Pointer[time.Location]
+
+// SetDefaultLocation sets the location used by [Current]. It is meant for
+// applications using a single time zone for their business, so they don't
+// need to pass it to every call. Code which needs the date in a specific
+// location should use [Today] instead. If loc is nil, [time.Local] is used,
+// which is also the default.
+//
+// SetDefaultLocation is safe for concurrent use, but should usually be
+// called once during initialization.
+func SetDefaultLocation(loc *time.Location) {
+	defaultLoc.Store(loc)
+}
+
+// DefaultLocation returns the location set by [SetDefaultLocation].
+func DefaultLocation() *time.Location {
+	if loc := defaultLoc.Load(); loc != nil {
+		return loc
+	}
+	return time.Local
+}
+
+// Current returns the current date in the default location, as set by
+// [SetDefaultLocation]. It is equivalent to Today(DefaultLocation()).
+func Current() Date {
+	return Today(DefaultLocation())
+}
+
 // abs returns the absolute date of d.
 func (d Date) abs() uint64 {
 	return uint64(d + internalToAbsolute)
@@ -281,6 +414,26 @@ func (d Date) AddDate(years, months, days int) Date {
 	return Of(year+years, month+time.Month(months), day+days)
 }
 
+// AddDateChecked is like [Date.AddDate], but returns an error instead of an
+// invalid Date, if d is not valid or the result is not in the range
+// MinDate…MaxDate. This is useful for arithmetic on untrusted input, where
+// AddDate would silently overflow.
+func (d Date) AddDateChecked(years, months, days int) (Date, error) {
+	if !d.IsValid() {
+		return 0, fmt.Errorf("date %d out of range [%d, %d]", int(d), int(MinDate), int(MaxDate))
+	}
+	year, month, day := d.Date()
+	if addOverflows(year, years) || addOverflows(int(month), months) || addOverflows(day, days) {
+		return 0, fmt.Errorf("adding %d years, %d months and %d days to %v overflows int", years, months, days, d)
+	}
+	return OfChecked(year+years, month+time.Month(months), day+days)
+}
+
+// addOverflows reports whether a+b overflows int.
+func addOverflows(a, b int) bool {
+	return b > 0 && a > math.MaxInt-b || b < 0 && a < math.MinInt-b
+}
+
 // Date returns the normalized year, month and day specified by d.
 func (d Date) Date() (year int, month time.Month, day int) {
 	year, month, day, _ = absDate(d.abs(), true)
@@ -293,12 +446,35 @@ func (d Date) Day() int {
 	return day
 }
 
+// Fields returns the year, month, day, day of the year and day of the week of
+// d. It is more efficient than calling the individual methods, if more than
+// one of them is needed.
+func (d Date) Fields() Fields {
+	year, month, day, yday := absDate(d.abs(), true)
+	return Fields{year, month, day, yday + 1, d.Weekday()}
+}
+
+// Fields is the breakdown of a Date into its civil calendar fields.
+type Fields struct {
+	Year    int
+	Month   time.Month
+	Day     int
+	YearDay int // in the range [1,366]
+	Weekday time.Weekday
+}
+
 // GoString implements fmt.GoStringer and formats d to be printed in Go source code.
 func (d Date) GoString() string {
 	year, month, day := d.Date()
 	return fmt.Sprintf("date.Of(%d, %d, %d)", year, month, day)
 }
 
+// ISOWeekdayNumber returns the ISO 8601 number of the day of the week of d,
+// from 1 for Monday to 7 for Sunday.
+func (d Date) ISOWeekdayNumber() int {
+	return isoWeekday(d.Weekday())
+}
+
 // ISOWeek returns the ISO 8601 year and week number in which d occurs. Week
 // ranges from 1 to 53. Jan 01 to Jan 03 of year n might belong to week 52 or
 // 53 of year n-1, and Dec 29 to Dec 31 might belong to week 1 of year n+1.
@@ -315,18 +491,58 @@ func (d Date) ISOWeek() (year, week int) {
 	return year, yday/7 + 1
 }
 
+// IsZero reports whether d is the zero Date, 0001-01-01. Like the zero
+// time.Time, the zero Date can be used to represent an unset date.
+//
+// IsZero is used by the omitzero option of encoding/json and
+// encoding/json/v2, so a zero Date field tagged with omitzero is omitted when
+// marshaling.
+func (d Date) IsZero() bool {
+	return d == 0
+}
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface. The date is
 // represented as a [binary.Varint] representing the number of days since
 // 0001-01-01.
+//
+// This encoding is also used by encoding/gob. It is stable and independent of
+// the internal representation of Date, so stored values will remain
+// decodable.
 func (d Date) MarshalBinary() ([]byte, error) {
-	b := make([]byte, binary.MaxVarintLen64)
-	return b[:binary.PutVarint(b, int64(d))], nil
+	return d.AppendBinary(make([]byte, 0, binary.MaxVarintLen64))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface. It appends
+// the encoding of d used by [Date.MarshalBinary] to b.
+func (d Date) AppendBinary(b []byte) ([]byte, error) {
+	return binary.AppendVarint(b, int64(d)), nil
 }
 
 // MarshalText implements the encoding.TextMarshaler interface. The date is
 // formatted in ISO 8601 format.
 func (d Date) MarshalText() ([]byte, error) {
-	return []byte(d.String()), nil
+	return d.AppendText(make([]byte, 0, len(RFC3339)))
+}
+
+// AppendText implements the encoding.TextAppender interface. It appends d in
+// ISO 8601 format to b, without allocating if b has sufficient capacity.
+func (d Date) AppendText(b []byte) ([]byte, error) {
+	return d.AppendFormat(b, RFC3339), nil
+}
+
+// Set implements the [flag.Value] interface, so a Date can be used as a
+// command line flag. The date must be in ISO 8601 format. For example:
+//
+//	since := date.Current().AddDate(0, -1, 0)
+//	flag.Var(&since, "since", "only show events on or after this date")
+func (d *Date) Set(s string) error {
+	return d.UnmarshalText([]byte(s))
+}
+
+// Type returns "date". Together with [Date.Set] and [Date.String], it
+// implements the Value interface of the github.com/spf13/pflag package.
+func (d *Date) Type() string {
+	return "date"
 }
 
 // Month returns the month of the year specified by d.
@@ -340,7 +556,8 @@ func (d Date) Month() time.Month {
 // The returned string is meant for debugging; for a stable serialized
 // representation, use d.MarshalText or t.MarshalBinary.
 func (d Date) String() string {
-	return d.Format(RFC3339)
+	var buf [len(RFC3339)]byte
+	return string(d.AppendFormat(buf[:0], RFC3339))
 }
 
 // Time returns the given moment in time in the given location.
@@ -366,6 +583,10 @@ func (d *Date) UnmarshalBinary(b []byte) error {
 // UnmarshalText implements the encoding.TextUnmarshaler interface. The date
 // must be in ISO 8601 format.
 func (d *Date) UnmarshalText(b []byte) error {
+	if v, ok := parseFast(RFC3339, b); ok {
+		*d = v
+		return nil
+	}
 	v, err := Parse(RFC3339, string(b))
 	if err == nil {
 		*d = v
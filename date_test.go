@@ -6,6 +6,11 @@
 package date
 
 import (
+	"bytes"
+	"encoding/gob"
+	"flag"
+	"io"
+	"math"
 	"math/rand"
 	"strconv"
 	"testing"
@@ -61,6 +66,41 @@ func TestToday(t *testing.T) {
 	}
 }
 
+// TestCurrent must not run in parallel, as it changes the default location,
+// which other tests might observe.
+func TestCurrent(t *testing.T) {
+	if got := DefaultLocation(); got != time.Local {
+		t.Errorf("DefaultLocation() = %v, want %v", got, time.Local)
+	}
+	loc := time.FixedZone("LINT", 14*60*60)
+	SetDefaultLocation(loc)
+	t.Cleanup(func() { SetDefaultLocation(nil) })
+	if got := DefaultLocation(); got != loc {
+		t.Errorf("DefaultLocation() = %v after SetDefaultLocation(%v), want %v", got, loc, loc)
+	}
+	// The day might change between reading the clock and calling Current.
+	before := Of(time.Now().In(loc).Date())
+	got := Current()
+	after := Of(time.Now().In(loc).Date())
+	if got != before && got != after {
+		t.Errorf("Current() = %v, want %v or %v", got, before, after)
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	if !Date(0).IsZero() {
+		t.Errorf("Date(0).IsZero() = false, want true")
+	}
+	if d := Of(1, 1, 1); !d.IsZero() {
+		t.Errorf("%v.IsZero() = false, want true", d)
+	}
+	for _, d := range []Date{-1, 1, Of(2024, 5, 14)} {
+		if d.IsZero() {
+			t.Errorf("%v.IsZero() = true, want false", d)
+		}
+	}
+}
+
 func addAll(f *testing.F) {
 	for _, tc := range tcs {
 		f.Add(tc.year, int(tc.month), tc.day)
@@ -120,6 +160,78 @@ func FuzzMarshalBinary(f *testing.F) {
 	})
 }
 
+// TestBinaryStable checks that the binary encoding, which is also used by
+// encoding/gob, does not change. Values stored with earlier versions must
+// remain decodable.
+func TestBinaryStable(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d   Date
+		bin string
+	}{
+		{Of(2024, 5, 14), "\x96\x9bZ"},
+		{Of(1, 1, 1), "\x00"},
+		{Of(0, 12, 31), "\x01"},
+	}
+	for _, tc := range tcs {
+		if b, err := tc.d.MarshalBinary(); err != nil || string(b) != tc.bin {
+			t.Errorf("%v.MarshalBinary() = %q, %v, want %q, <nil>", tc.d, b, err, tc.bin)
+		}
+		// The type ids in gob streams depend on the types encoded before,
+		// so only check that gob round-trips, using MarshalBinary.
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(tc.d); err != nil {
+			t.Errorf("gob encoding of %v = %v, want <nil>", tc.d, err)
+			continue
+		}
+		if !bytes.Contains(buf.Bytes(), []byte(tc.bin)) {
+			t.Errorf("gob encoding of %v = %q, want it to contain %q", tc.d, buf.Bytes(), tc.bin)
+		}
+		var got Date
+		if err := gob.NewDecoder(&buf).Decode(&got); err != nil || got != tc.d {
+			t.Errorf("gob decoding of %v = %v, %v, want %v, <nil>", tc.d, got, err, tc.d)
+		}
+	}
+}
+
+func TestAppend(t *testing.T) {
+	d := Of(2024, 5, 14)
+	buf := make([]byte, 0, 64)
+	if b, err := d.AppendText(append(buf, "x="...)); err != nil || string(b) != "x=2024-05-14" {
+		t.Errorf("%v.AppendText(%q) = %q, %v, want %q, <nil>", d, "x=", b, err, "x=2024-05-14")
+	}
+	if b, err := d.AppendBinary(append(buf, 'x')); err != nil || string(b) != "x\x96\x9bZ" {
+		t.Errorf("%v.AppendBinary(%q) = %q, %v, want %q, <nil>", d, "x", b, err, "x\x96\x9bZ")
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = d.AppendText(buf[:0])
+		buf, _ = d.AppendBinary(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("AppendText and AppendBinary allocate %v times, want 0", allocs)
+	}
+}
+
+func TestFlag(t *testing.T) {
+	t.Parallel()
+	var since Date
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&since, "since", "")
+	if err := fs.Parse([]string{"-since", "2024-05-14"}); err != nil || since != Of(2024, 5, 14) {
+		t.Errorf("Parse(-since 2024-05-14) = %v, since = %v, want <nil>, %v", err, since, Of(2024, 5, 14))
+	}
+	if got := fs.Lookup("since").Value.String(); got != "2024-05-14" {
+		t.Errorf("Value.String() = %q, want %q", got, "2024-05-14")
+	}
+	if err := fs.Parse([]string{"-since", "May 14"}); err == nil {
+		t.Errorf("Parse(-since May 14) = <nil>, want error")
+	}
+	if got := since.Type(); got != "date" {
+		t.Errorf("Type() = %q, want %q", got, "date")
+	}
+}
+
 func FuzzUnmarshalBinary(f *testing.F) {
 	rnd := rand.New(rand.NewSource(0))
 	for i := 0; i < 100; i++ {
@@ -173,3 +285,159 @@ func check(t *testing.T, year, month, day int) {
 		t.Errorf("Of(%d, %d, %d).ISOWeek() = (%d, %d), want (%d, %d)", year, month, day, gotIY, gotIW, wantIY, wantIW)
 	}
 }
+
+// FuzzAbsDate checks that the table-based absDate agrees with the general
+// implementation.
+func FuzzAbsDate(f *testing.F) {
+	f.Add(int(Of(1900, 1, 1)))
+	f.Add(int(Of(1899, 12, 31)))
+	f.Add(int(Of(2000, 2, 29)))
+	f.Add(int(Of(2100, 12, 31)))
+	f.Add(int(Of(2101, 1, 1)))
+	f.Fuzz(func(t *testing.T, n int) {
+		d := Of(1900, 1, 1) + Date(n%100000)
+		for _, full := range []bool{false, true} {
+			y1, m1, d1, yd1 := absDate(d.abs(), full)
+			y2, m2, d2, yd2 := absDateSlow(d.abs(), full)
+			if y1 != y2 || m1 != m2 || d1 != d2 || yd1 != yd2 {
+				t.Fatalf("absDate(%d, %v) = %d, %d, %d, %d, want %d, %d, %d, %d", d.abs(), full, y1, m1, d1, yd1, y2, m2, d2, yd2)
+			}
+		}
+	})
+}
+
+func TestAbsDateTable(t *testing.T) {
+	t.Parallel()
+	for d := Of(1899, 1, 1); d < Of(2102, 1, 1); d++ {
+		y1, m1, d1, yd1 := absDate(d.abs(), true)
+		y2, m2, d2, yd2 := absDateSlow(d.abs(), true)
+		if y1 != y2 || m1 != m2 || d1 != d2 || yd1 != yd2 {
+			t.Fatalf("absDate(%d, true) = %d, %d, %d, %d, want %d, %d, %d, %d", d.abs(), y1, m1, d1, yd1, y2, m2, d2, yd2)
+		}
+	}
+}
+
+func BenchmarkAbsDate(b *testing.B) {
+	for _, tc := range []struct {
+		name string
+		f    func(uint64, bool) (int, time.Month, int, int)
+	}{{"Table", absDate}, {"Slow", absDateSlow}} {
+		b.Run(tc.name, func(b *testing.B) {
+			d := Of(2024, 5, 14).abs()
+			var sink int
+			for i := 0; i < b.N; i++ {
+				y, m, day, _ := tc.f(d+uint64(i%1000), true)
+				sink += y + int(m) + day
+			}
+			_ = sink
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+	for _, d := range []Date{0, Of(1899, 12, 31), Of(2024, 2, 29), Of(2024, 12, 31), Of(-44, 3, 15), Of(12024, 5, 14)} {
+		want := Fields{d.Year(), d.Month(), d.Day(), d.YearDay(), d.Weekday()}
+		if got := d.Fields(); got != want {
+			t.Errorf("%v.Fields() = %+v, want %+v", d, got, want)
+		}
+	}
+}
+
+func TestOfChecked(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		year  int
+		month time.Month
+		day   int
+		want  Date
+		err   bool
+	}{
+		{2024, 5, 22, Of(2024, 5, 22), false},
+		{2024, 14, 0, Of(2025, 1, 31), false},
+		{2024, -1, -400, Of(2023, 11, -400), false},
+		{MinYear, 1, 1, Of(MinYear, 1, 1), false},
+		{MaxYear, 12, 31, Of(MaxYear, 12, 31), false},
+		{MaxYear, 13, 1, 0, true},
+		{MaxYear, 12, 32, 0, true},
+		{MinYear, 1, 0, 0, true},
+		{MinYear, 0, 1, 0, true},
+		{MaxYear + 1, 1, 1, 0, true},
+		{MinYear - 1, 1, 1, 0, true},
+		{math.MaxInt, 1, 1, 0, true},
+		{math.MaxInt, 13, 1, 0, true},
+		{math.MinInt, -1, 1, 0, true},
+		{2024, math.MaxInt, 1, 0, true},
+		{2024, math.MinInt, 1, 0, true},
+		{2024, 1, math.MaxInt, 0, true},
+		{2024, 1, math.MinInt, 0, true},
+	}
+	for _, tc := range tcs {
+		got, err := OfChecked(tc.year, tc.month, tc.day)
+		if (err != nil) != tc.err || got != tc.want {
+			t.Errorf("OfChecked(%d, %d, %d) = %v, %v, want %v, %v", tc.year, tc.month, tc.day, got, err, tc.want, tc.err)
+		}
+		if err != nil {
+			continue
+		}
+		if y, m, d := got.Date(); Of(y, m, d) != got || y < MinYear || y > MaxYear {
+			t.Errorf("OfChecked(%d, %d, %d).Date() = %d, %d, %d, not in range", tc.year, tc.month, tc.day, y, m, d)
+		}
+	}
+}
+
+func TestDateValid(t *testing.T) {
+	t.Parallel()
+	if got := Of(MinYear, time.January, 1); got != MinDate {
+		t.Errorf("Of(MinYear, January, 1) = %d, want MinDate = %d", got, MinDate)
+	}
+	if got := Of(MaxYear, time.December, 31); got != MaxDate {
+		t.Errorf("Of(MaxYear, December, 31) = %d, want MaxDate = %d", got, MaxDate)
+	}
+	tcs := []struct {
+		d    Date
+		want bool
+	}{
+		{0, true},
+		{MinDate, true},
+		{MaxDate, true},
+		{MinDate - 1, false},
+		{MaxDate + 1, false},
+		{math.MaxInt, false},
+	}
+	for _, tc := range tcs {
+		if got := tc.d.IsValid(); got != tc.want {
+			t.Errorf("Date(%d).IsValid() = %v, want %v", tc.d, got, tc.want)
+		}
+	}
+}
+
+func TestAddDateChecked(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 1, 31)
+	tcs := []struct {
+		d                   Date
+		years, months, days int
+		want                Date
+		err                 bool
+	}{
+		{d, 0, 1, 0, Of(2024, 3, 2), false},
+		{d, -1, 2, 3, d.AddDate(-1, 2, 3), false},
+		{d, 0, 0, -int(d), 0, false},
+		{MaxDate, 0, 0, 1, 0, true},
+		{MinDate, 0, 0, -1, 0, true},
+		{MaxDate, 0, 0, -1, MaxDate - 1, false},
+		{d, math.MaxInt, 0, 0, 0, true},
+		{d, math.MinInt, 0, 0, 0, true},
+		{d, 0, math.MaxInt, 0, 0, true},
+		{d, 0, 0, math.MaxInt, 0, true},
+		{d, MaxYear, 0, 0, 0, true},
+		{MaxDate + 1, 0, 0, 0, 0, true},
+	}
+	for _, tc := range tcs {
+		got, err := tc.d.AddDateChecked(tc.years, tc.months, tc.days)
+		if (err != nil) != tc.err || got != tc.want {
+			t.Errorf("Date(%d).AddDateChecked(%d, %d, %d) = %v, %v, want %v, %v", tc.d, tc.years, tc.months, tc.days, got, err, tc.want, tc.err)
+		}
+	}
+}
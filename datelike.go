@@ -0,0 +1,40 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "time"
+
+// DateLike is the set of methods shared by Date and [time.Time] to access
+// their calendar date. Functions accepting a DateLike can be passed either,
+// without converting.
+//
+// The date of a time.Time is the date in its location. Its Format method
+// interprets layouts as described by package time, which for elements of
+// dates is the same as for [Date.Format], but also formats the time of day.
+type DateLike interface {
+	Date() (year int, month time.Month, day int)
+	Year() int
+	Month() time.Month
+	Day() int
+	YearDay() int
+	Weekday() time.Weekday
+	ISOWeek() (year, week int)
+	Format(layout string) string
+}
+
+var (
+	_ DateLike = Date(0)
+	_ DateLike = time.Time{}
+)
+
+// FromDateLike returns the Date of d. If d is a Date, it is returned
+// unchanged.
+func FromDateLike(d DateLike) Date {
+	if d, ok := d.(Date); ok {
+		return d
+	}
+	return Of(d.Date())
+}
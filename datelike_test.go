@@ -0,0 +1,37 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateLike(t *testing.T) {
+	t.Parallel()
+	loc := time.FixedZone("UTC+14", 14*60*60)
+	d := Of(2024, 12, 30)
+	tcs := []DateLike{
+		d,
+		d.Time(12, 0, 0, 0, time.UTC),
+		// The date of a time.Time is the date in its location.
+		d.Time(23, 0, 0, 0, time.UTC).In(loc).Add(-24 * time.Hour),
+	}
+	for _, x := range tcs {
+		if got := FromDateLike(x); got != d {
+			t.Errorf("FromDateLike(%v) = %v, want %v", x, got, d)
+		}
+		if x.Year() != 2024 || x.Month() != time.December || x.Day() != 30 || x.YearDay() != 365 || x.Weekday() != time.Monday {
+			t.Errorf("%v has wrong fields", x)
+		}
+		if y, w := x.ISOWeek(); y != 2025 || w != 1 {
+			t.Errorf("%v.ISOWeek() = %d, %d, want 2025, 1", x, y, w)
+		}
+		if got, want := x.Format(RFC3339), "2024-12-30"; got != want {
+			t.Errorf("%v.Format(RFC3339) = %q, want %q", x, got, want)
+		}
+	}
+}
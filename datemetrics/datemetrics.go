@@ -0,0 +1,102 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package datemetrics exposes metrics about the internal caches of package
+// date, so operators can alert on a thrashing cache.
+//
+// The metrics are exposed through [expvar] with [Publish], or in the
+// Prometheus text exposition format with [Handler]. To register them with a
+// prometheus.Registerer instead, without a separate endpoint, use
+// prometheus.NewCounterFunc and prometheus.NewGaugeFunc with
+// [date.LayoutCacheStats]:
+//
+//	prometheus.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+//		Name: "godate_layout_cache_misses_total",
+//		Help: "Number of lookups of layouts, which had to be compiled.",
+//	}, func() float64 {
+//		return float64(date.LayoutCacheStats().Misses)
+//	}))
+package datemetrics
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+
+	"gonih.org/date"
+)
+
+// metric is a metric about a cache.
+type metric struct {
+	name  string
+	typ   string // "counter" or "gauge"
+	help  string
+	value func(date.CacheStats) int64
+}
+
+var metrics = []metric{
+	{"hits_total", "counter", "Number of lookups of cached values.", func(s date.CacheStats) int64 { return s.Hits }},
+	{"misses_total", "counter", "Number of lookups computing the value.", func(s date.CacheStats) int64 { return s.Misses }},
+	{"evictions_total", "counter", "Number of values evicted to make room for others.", func(s date.CacheStats) int64 { return s.Evictions }},
+	{"entries", "gauge", "Number of values currently cached.", func(s date.CacheStats) int64 { return int64(s.Len) }},
+	{"size_bytes", "gauge", "Approximate memory used by the cached values.", func(s date.CacheStats) int64 { return s.Size }},
+}
+
+// caches are the caches metrics are exposed for, by the name used in the
+// metrics.
+var caches = []struct {
+	name  string
+	stats func() date.CacheStats
+}{
+	{"layout", date.LayoutCacheStats},
+}
+
+// Publish publishes the statistics of the caches as the expvar variable
+// name, like
+//
+//	{"layout": {"Hits": 42, "Misses": 3, "Evictions": 0, "Len": 3, "Size": 600}}
+//
+// Like expvar.Publish, it panics if name is already in use.
+func Publish(name string) {
+	expvar.Publish(name, expvarFunc())
+}
+
+// expvarFunc returns the expvar.Var published by Publish.
+func expvarFunc() expvar.Func {
+	return expvar.Func(func() any {
+		m := make(map[string]date.CacheStats, len(caches))
+		for _, c := range caches {
+			m[c.name] = c.stats()
+		}
+		return m
+	})
+}
+
+// WritePrometheus writes the metrics of the caches to w, in the Prometheus
+// text exposition format. The metrics are named like
+// godate_layout_cache_hits_total.
+func WritePrometheus(w io.Writer) error {
+	for _, c := range caches {
+		s := c.stats()
+		for _, m := range metrics {
+			name := "godate_" + c.name + "_cache_" + m.name
+			_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %d\n", name, m.help, name, m.typ, name, m.value(s))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Handler returns an HTTP handler serving the metrics as written by
+// [WritePrometheus], to be scraped by Prometheus.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		WritePrometheus(w)
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package datemetrics
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gonih.org/date"
+)
+
+func TestPublish(t *testing.T) {
+	date.Of(2024, 5, 14).Format("Jan 2, 2006 (datemetrics)")
+	// Publish registers the variable globally, which panics when the test
+	// is run more than once, so test the variable it publishes instead.
+	var got map[string]date.CacheStats
+	if err := json.Unmarshal([]byte(expvarFunc().String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := got["layout"]; !ok || s.Misses == 0 || s.Len == 0 {
+		t.Errorf("expvar = %v, want layout cache with misses and entries", got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	date.Of(2024, 5, 14).Format("Jan 2, 2006 (datemetrics)")
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+	for _, want := range []string{
+		"# TYPE godate_layout_cache_hits_total counter\n",
+		"# TYPE godate_layout_cache_entries gauge\n",
+		"\ngodate_layout_cache_misses_total ",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Handler() served\n%s\nwant it to contain %q", body, want)
+		}
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Handler() served Content-Type %q, want text/plain", ct)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package datetest provides utilities for testing code using package date.
+package datetest
+
+import (
+	"sync"
+	"time"
+
+	"gonih.org/date"
+)
+
+// MustDate parses s in [date.RFC3339] format. It panics if s can not be
+// parsed. It is intended for test tables and variable initialization.
+func MustDate(s string) date.Date {
+	d, err := date.Parse(date.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Clock is a [date.Clock] which only advances when told to.
+//
+// Like the system clock, it distinguishes between the wall clock, which can
+// be set arbitrarily, and elapsed time, which only moves forward. Timers
+// created by After fire once enough time has elapsed.
+//
+// A Clock is safe for concurrent use.
+type Clock struct {
+	mu      sync.Mutex
+	cond    sync.Cond
+	loc     *time.Location
+	now     time.Time
+	elapsed time.Duration
+	timers  []timer
+}
+
+type timer struct {
+	deadline time.Duration
+	ch       chan time.Time
+}
+
+var _ date.Clock = (*Clock)(nil)
+
+// NewClock returns a Clock frozen at the start of d in loc.
+func NewClock(d date.Date, loc *time.Location) *Clock {
+	return NewClockAt(d.Time(0, 0, 0, 0, loc))
+}
+
+// NewClockAt returns a Clock frozen at t. Its location is the location of t.
+func NewClockAt(t time.Time) *Clock {
+	c := &Clock{
+		loc: t.Location(),
+		now: t,
+	}
+	c.cond.L = &c.mu
+	return c
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Today returns the current date of the clock, in its location.
+func (c *Clock) Today() date.Date {
+	return date.Of(c.Now().Date())
+}
+
+// After returns a channel, which receives the current time once d has elapsed
+// on c.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.timers = append(c.timers, timer{c.elapsed + d, ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// Advance lets d elapse, moving the wall clock forward by d and firing all
+// expired timers. It panics if d is negative.
+func (c *Clock) Advance(d time.Duration) {
+	if d < 0 {
+		panic("datetest: negative duration passed to Advance")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.elapsed += d
+	c.fireLocked()
+}
+
+// AdvanceDays moves the clock forward by n calendar days, keeping the wall
+// clock time, if possible. The elapsed time is the actual time between the
+// old and new wall clock, so it might differ from n times 24 hours, due to
+// daylight savings time transitions. It panics if n is negative.
+func (c *Clock) AdvanceDays(n int) {
+	if n < 0 {
+		panic("datetest: negative number of days passed to AdvanceDays")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	year, month, day := c.now.Date()
+	hour, min, sec := c.now.Clock()
+	t := time.Date(year, month, day+n, hour, min, sec, c.now.Nanosecond(), c.loc)
+	if d := t.Sub(c.now); d > 0 {
+		c.elapsed += d
+	}
+	c.now = t
+	c.fireLocked()
+}
+
+// Set sets the wall clock to the start of d, without letting any time elapse.
+// This simulates an adjustment of the system clock.
+func (c *Clock) Set(d date.Date) {
+	c.SetTime(d.Time(0, 0, 0, 0, c.loc))
+}
+
+// SetTime sets the wall clock to t, without letting any time elapse. This
+// simulates an adjustment of the system clock.
+func (c *Clock) SetTime(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t.In(c.loc)
+}
+
+// BlockUntil blocks until at least n timers created by After are waiting to
+// fire. It can be used to synchronize with goroutines using the clock.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *Clock) fireLocked() {
+	timers := c.timers[:0]
+	for _, t := range c.timers {
+		if t.deadline > c.elapsed {
+			timers = append(timers, t)
+			continue
+		}
+		t.ch <- c.now
+	}
+	clear(c.timers[len(timers):])
+	c.timers = timers
+}
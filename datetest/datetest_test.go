@@ -0,0 +1,80 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package datetest
+
+import (
+	"testing"
+	"time"
+
+	"gonih.org/date"
+)
+
+func TestMustDate(t *testing.T) {
+	if got, want := MustDate("2024-05-14"), date.Of(2024, 5, 14); got != want {
+		t.Errorf("MustDate(%q) = %v, want %v", "2024-05-14", got, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustDate(%q) did not panic", "2024-13-14")
+		}
+	}()
+	MustDate("2024-13-14")
+}
+
+func TestClock(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	c := NewClock(MustDate("2024-03-30"), loc)
+	if got, want := c.Now(), time.Date(2024, 3, 30, 0, 0, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("Now() = %v, want %v", got, want)
+	}
+	ch := c.After(24 * time.Hour)
+	// DST starts on 2024-03-31, so that day only has 23 hours.
+	c.AdvanceDays(2)
+	if got, want := c.Today(), MustDate("2024-04-01"); got != want {
+		t.Fatalf("Today() = %v, want %v", got, want)
+	}
+	select {
+	case <-ch:
+	default:
+		t.Fatal("timer did not fire after AdvanceDays(2)")
+	}
+
+	ch = c.After(time.Hour)
+	c.Set(MustDate("2024-01-01"))
+	if got, want := c.Today(), MustDate("2024-01-01"); got != want {
+		t.Fatalf("Today() = %v, want %v", got, want)
+	}
+	select {
+	case <-ch:
+		t.Fatal("timer fired after Set")
+	default:
+	}
+	c.Advance(time.Hour)
+	select {
+	case got := <-ch:
+		if want := time.Date(2024, 1, 1, 1, 0, 0, 0, loc); !got.Equal(want) {
+			t.Fatalf("timer sent %v, want %v", got, want)
+		}
+	default:
+		t.Fatal("timer did not fire after Advance")
+	}
+}
+
+func TestClockWatcher(t *testing.T) {
+	c := NewClockAt(time.Date(2024, 5, 14, 12, 0, 0, 0, time.UTC))
+	w := date.NewWatcher(c, time.UTC)
+	defer w.Stop()
+	for i := 1; i <= 3; i++ {
+		c.BlockUntil(1)
+		c.AdvanceDays(1)
+		if got, want := <-w.C, date.Of(2024, 5, 14+i); got != want {
+			t.Fatalf("Watcher sent %v, want %v", got, want)
+		}
+	}
+}
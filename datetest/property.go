@@ -0,0 +1,204 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package datetest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"iter"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"gonih.org/date"
+)
+
+// maxShrinks limits the number of shrinking steps done by Check, in case a
+// shrinker does not converge.
+const maxShrinks = 1000
+
+// Check tests the property prop for n values generated by gen. If prop
+// returns an error for a value, the value is shrunk by repeatedly replacing
+// it with the first value yielded by shrink for which prop fails as well. The
+// final value is reported using t.Errorf. shrink may be nil.
+//
+// The values are generated from a source seeded with the name of t, so every
+// run of a test checks the same values.
+//
+// For example, to check that formatting and parsing are inverse for all dates
+// of the 21st century:
+//
+//	datetest.Check(t, 1000,
+//		datetest.Dates(date.Range{date.Of(2000, 1, 1), date.Of(2100, 1, 1)}),
+//		datetest.ShrinkDate,
+//		datetest.RoundTrip(date.RFC3339),
+//	)
+func Check[T any](t testing.TB, n int, gen func(*rand.Rand) T, shrink func(T) iter.Seq[T], prop func(T) error) {
+	t.Helper()
+	h := fnv.New64a()
+	h.Write([]byte(t.Name()))
+	r := rand.New(rand.NewPCG(h.Sum64(), 0))
+	for range n {
+		v := gen(r)
+		err := prop(v)
+		if err == nil {
+			continue
+		}
+		if shrink != nil {
+			v, err = shrinkFailure(v, err, shrink, prop)
+		}
+		t.Errorf("property fails for %v: %v", v, err)
+		return
+	}
+}
+
+// shrinkFailure shrinks v, for which prop fails with err, as documented for
+// Check.
+func shrinkFailure[T any](v T, err error, shrink func(T) iter.Seq[T], prop func(T) error) (T, error) {
+	for range maxShrinks {
+		shrunk := false
+		for c := range shrink(v) {
+			if e := prop(c); e != nil {
+				v, err, shrunk = c, e, true
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return v, err
+}
+
+// Dates returns a generator of dates chosen uniformly from rng. It panics,
+// if rng is empty.
+func Dates(rng date.Range) func(*rand.Rand) date.Date {
+	if rng.IsEmpty() {
+		panic(fmt.Errorf("datetest: empty range %v", rng))
+	}
+	return func(r *rand.Rand) date.Date {
+		return date.RandomIn(r, rng)
+	}
+}
+
+// Ranges returns a generator of non-empty ranges contained in within. It
+// panics, if within is empty.
+func Ranges(within date.Range) func(*rand.Rand) date.Range {
+	gen := Dates(within)
+	return func(r *rand.Rand) date.Range {
+		a, b := gen(r), gen(r)
+		return date.ClosedRange(min(a, b), max(a, b))
+	}
+}
+
+// Periods returns a generator of periods with up to maxYears years, in either
+// direction. The months and days are less than a year and a month,
+// respectively, and have the same sign as the years, if those are not zero.
+func Periods(maxYears int) func(*rand.Rand) date.Period {
+	return func(r *rand.Rand) date.Period {
+		p := date.Period{
+			Years:  r.IntN(maxYears + 1),
+			Months: r.IntN(12),
+			Days:   r.IntN(31),
+		}
+		if r.IntN(2) == 0 {
+			p = p.Neg()
+		}
+		return p
+	}
+}
+
+// ShrinkDate yields simpler versions of d: the first day of its year and month
+// and dates closer to the zero Date.
+func ShrinkDate(d date.Date) iter.Seq[date.Date] {
+	return func(yield func(date.Date) bool) {
+		year, month, day := d.Date()
+		if month != time.January || day != 1 {
+			if !yield(date.Of(year, time.January, 1)) {
+				return
+			}
+		}
+		if day != 1 {
+			if !yield(date.Of(year, month, 1)) {
+				return
+			}
+		}
+		if d == 0 {
+			return
+		}
+		if d/2 != 0 && !yield(d/2) {
+			return
+		}
+		if d > 0 {
+			yield(d - 1)
+		} else {
+			yield(d + 1)
+		}
+	}
+}
+
+// ShrinkRange yields simpler versions of r: shorter ranges contained in r and
+// ranges of the same length, starting at a simpler date.
+func ShrinkRange(r date.Range) iter.Seq[date.Range] {
+	return func(yield func(date.Range) bool) {
+		n := r.Days()
+		if n > 1 {
+			if !yield(date.Range{Start: r.Start, End: r.Start + 1}) {
+				return
+			}
+			if n > 2 && !yield(date.Range{Start: r.Start, End: r.Start + date.Date(n/2)}) {
+				return
+			}
+			if !yield(date.Range{Start: r.End - date.Date(n/2), End: r.End}) {
+				return
+			}
+		}
+		for d := range ShrinkDate(r.Start) {
+			if !yield(date.Range{Start: d, End: d + date.Date(n)}) {
+				return
+			}
+		}
+	}
+}
+
+// RoundTrip returns a property checking that formatting a date with layout and
+// parsing the result returns the same date. The layout must contain a
+// four-digit year, a month and a day and the dates must be in the years
+// 0000…9999.
+func RoundTrip(layout string) func(date.Date) error {
+	return func(d date.Date) error {
+		s := d.Format(layout)
+		got, err := date.Parse(layout, s)
+		if err != nil {
+			return err
+		}
+		if got != d {
+			return fmt.Errorf("Parse(%q, %q) = %v, want %v", layout, s, got, d)
+		}
+		return nil
+	}
+}
+
+// OfInverse checks that date.Of is the inverse of (date.Date).Date for d.
+func OfInverse(d date.Date) error {
+	year, month, day := d.Date()
+	if got := date.Of(year, month, day); got != d {
+		return fmt.Errorf("Of(%d, %d, %d) = %v, want %v", year, month, day, got, d)
+	}
+	return nil
+}
+
+// MatchesTime checks that the fields of d agree with those of the
+// corresponding [time.Time].
+func MatchesTime(d date.Date) error {
+	t := d.Time(0, 0, 0, 0, time.UTC)
+	f := d.Fields()
+	want := date.Fields{Year: t.Year(), Month: t.Month(), Day: t.Day(), YearDay: t.YearDay(), Weekday: t.Weekday()}
+	if f != want {
+		return fmt.Errorf("Fields() = %+v, want %+v", f, want)
+	}
+	return nil
+}
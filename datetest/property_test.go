@@ -0,0 +1,80 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package datetest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gonih.org/date"
+)
+
+// recorder is a testing.TB recording failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	errs []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func TestCheckInvariants(t *testing.T) {
+	all := date.Range{Start: date.Of(0, 1, 1), End: date.Of(10000, 1, 1)}
+	Check(t, 1000, Dates(all), ShrinkDate, RoundTrip(date.RFC3339))
+	Check(t, 1000, Dates(all), ShrinkDate, RoundTrip("Monday, January 2nd, 2006"))
+	Check(t, 1000, Dates(date.Range{Start: -1e7, End: 1e7}), ShrinkDate, OfInverse)
+	Check(t, 1000, Dates(all), ShrinkDate, MatchesTime)
+	Check(t, 1000, Ranges(all), ShrinkRange, func(r date.Range) error {
+		if r.IsEmpty() || !all.Contains(r.Start) || !all.Contains(r.Last()) {
+			return errors.New("invalid range")
+		}
+		return nil
+	})
+	Check(t, 1000, Periods(10), nil, func(p date.Period) error {
+		if p.Years > 10 || p.Years < -10 || p.Months > 11 || p.Months < -11 || p.Days > 30 || p.Days < -30 {
+			return errors.New("period out of range")
+		}
+		if p.Years*p.Months < 0 || p.Years*p.Days < 0 || p.Months*p.Days < 0 {
+			return errors.New("mixed signs")
+		}
+		return nil
+	})
+}
+
+func TestCheckShrinks(t *testing.T) {
+	r := &recorder{TB: t}
+	after := date.Of(2000, 3, 14)
+	Check(r, 100, Dates(date.YearRange(2024)), ShrinkDate, func(d date.Date) error {
+		if d >= after {
+			return errors.New("too late")
+		}
+		return nil
+	})
+	if len(r.errs) != 1 || !strings.HasPrefix(r.errs[0], "property fails for 2000-03-14:") {
+		t.Errorf("Check reported %q, want failure for %v", r.errs, after)
+	}
+
+	r = &recorder{TB: t}
+	Check(r, 100, Ranges(date.YearRange(2024)), ShrinkRange, func(rng date.Range) error {
+		if rng.Days() > 1 && rng.Start.Weekday() == time.Saturday {
+			return errors.New("starts on Saturday")
+		}
+		return nil
+	})
+	if len(r.errs) != 1 || !strings.Contains(r.errs[0], "starts on Saturday") {
+		t.Fatalf("Check reported %q, want one failure", r.errs)
+	}
+
+	r = &recorder{TB: t}
+	Check(r, 100, Dates(date.YearRange(2024)), ShrinkDate, OfInverse)
+	if len(r.errs) != 0 {
+		t.Errorf("Check reported %q, want no failure", r.errs)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"strconv"
+	"time"
+)
+
+// A DayCountBasis is a convention for counting the days between two dates as a
+// fraction of a year, as used for pro-rating interest or charges.
+type DayCountBasis int
+
+// Day count conventions supported by [YearFractionBetween].
+const (
+	// ActualActual counts the actual number of days, divided by the number
+	// of days in their year. A period spanning several years is split at
+	// the start of each year, as in the ISDA variant of Actual/Actual.
+	ActualActual DayCountBasis = iota
+
+	// Actual365Fixed counts the actual number of days, divided by 365.
+	Actual365Fixed
+
+	// Actual360 counts the actual number of days, divided by 360.
+	Actual360
+
+	// Thirty360 counts every month as 30 days and divides by 360, as in the
+	// US bond basis. The 31st of a month is counted as the 30th, if it is
+	// the first date or if the first date is the 30th or 31st.
+	Thirty360
+
+	// Thirty360E is like Thirty360, but the 31st of a month is always
+	// counted as the 30th, as in the Eurobond basis.
+	Thirty360E
+)
+
+var dayCountBasisNames = [...]string{
+	ActualActual:   "Actual/Actual",
+	Actual365Fixed: "Actual/365 Fixed",
+	Actual360:      "Actual/360",
+	Thirty360:      "30/360",
+	Thirty360E:     "30E/360",
+}
+
+// String returns the common name of b, like "Actual/360".
+func (b DayCountBasis) String() string {
+	if b < 0 || int(b) >= len(dayCountBasisNames) {
+		return "DayCountBasis(" + strconv.Itoa(int(b)) + ")"
+	}
+	return dayCountBasisNames[b]
+}
+
+// YearFraction returns the fraction of its year elapsed at the start of d.
+// It is 0 on January 1st and 364/365 or, in leap years, 365/366 on December
+// 31st.
+func (d Date) YearFraction() float64 {
+	f := d.Fields()
+	return float64(f.YearDay-1) / float64(daysInYear(f.Year))
+}
+
+// YearFractionBetween returns the number of years from a to b, counted
+// according to basis. If b is before a, the result is negative. It panics, if
+// basis is not one of the defined constants.
+func YearFractionBetween(a, b Date, basis DayCountBasis) float64 {
+	if b < a {
+		return -YearFractionBetween(b, a, basis)
+	}
+	switch basis {
+	case ActualActual:
+		ya, yb := a.Year(), b.Year()
+		if ya == yb {
+			return float64(b-a) / float64(daysInYear(ya))
+		}
+		head := Of(ya+1, time.January, 1) - a
+		tail := b - Of(yb, time.January, 1)
+		return float64(head)/float64(daysInYear(ya)) + float64(yb-ya-1) + float64(tail)/float64(daysInYear(yb))
+	case Actual365Fixed:
+		return float64(b-a) / 365
+	case Actual360:
+		return float64(b-a) / 360
+	case Thirty360, Thirty360E:
+		y1, m1, d1 := a.Date()
+		y2, m2, d2 := b.Date()
+		if d1 == 31 {
+			d1 = 30
+		}
+		if d2 == 31 && (basis == Thirty360E || d1 == 30) {
+			d2 = 30
+		}
+		return float64(360*(y2-y1)+30*int(m2-m1)+d2-d1) / 360
+	}
+	panic("invalid DayCountBasis " + basis.String())
+}
+
+// daysInYear returns the number of days in the given year.
+func daysInYear(year int) int {
+	if isLeap(year) {
+		return 366
+	}
+	return 365
+}
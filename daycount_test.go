@@ -0,0 +1,71 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"math"
+	"testing"
+)
+
+func TestYearFraction(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d    Date
+		want float64
+	}{
+		{Of(2024, 1, 1), 0},
+		{Of(2024, 1, 2), 1.0 / 366},
+		{Of(2024, 7, 2), 183.0 / 366},
+		{Of(2024, 12, 31), 365.0 / 366},
+		{Of(2023, 12, 31), 364.0 / 365},
+		{Of(2023, 3, 1), 59.0 / 365},
+	}
+	for _, tc := range tcs {
+		if got := tc.d.YearFraction(); got != tc.want {
+			t.Errorf("%v.YearFraction() = %v, want %v", tc.d, got, tc.want)
+		}
+	}
+}
+
+func TestYearFractionBetween(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		a, b  Date
+		basis DayCountBasis
+		want  float64
+	}{
+		{Of(2024, 1, 1), Of(2025, 1, 1), ActualActual, 1},
+		{Of(2023, 1, 1), Of(2023, 7, 2), ActualActual, 182.0 / 365},
+		{Of(2023, 12, 15), Of(2024, 1, 15), ActualActual, 17.0/365 + 14.0/366},
+		{Of(2023, 12, 15), Of(2026, 1, 15), ActualActual, 17.0/365 + 2 + 14.0/365},
+		{Of(2024, 1, 15), Of(2023, 12, 15), ActualActual, -(17.0/365 + 14.0/366)},
+		{Of(2024, 1, 1), Of(2025, 1, 1), Actual365Fixed, 366.0 / 365},
+		{Of(2024, 1, 1), Of(2024, 3, 1), Actual360, 60.0 / 360},
+		{Of(2024, 1, 31), Of(2024, 3, 31), Thirty360, 60.0 / 360},
+		{Of(2024, 2, 28), Of(2024, 3, 31), Thirty360, 33.0 / 360},
+		{Of(2024, 2, 28), Of(2024, 3, 31), Thirty360E, 32.0 / 360},
+		{Of(2024, 1, 15), Of(2025, 1, 15), Thirty360, 1},
+		{Of(2024, 5, 22), Of(2024, 5, 22), Thirty360E, 0},
+	}
+	for _, tc := range tcs {
+		if got := YearFractionBetween(tc.a, tc.b, tc.basis); math.Abs(got-tc.want) > 1e-12 {
+			t.Errorf("YearFractionBetween(%v, %v, %v) = %v, want %v", tc.a, tc.b, tc.basis, got, tc.want)
+		}
+	}
+}
+
+func TestDayCountBasisString(t *testing.T) {
+	t.Parallel()
+	for b, want := range map[DayCountBasis]string{
+		ActualActual: "Actual/Actual",
+		Thirty360E:   "30E/360",
+		-1:           "DayCountBasis(-1)",
+	} {
+		if got := b.String(); got != want {
+			t.Errorf("DayCountBasis(%d).String() = %q, want %q", int(b), got, want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// A LayoutElement is a custom element of layouts, registered with
+// [RegisterLayoutElement]. It can be used to add domain-specific elements to
+// layouts, like a fiscal quarter.
+type LayoutElement struct {
+	// Token is the text representing the element in a layout, like "Q".
+	Token string
+
+	// Format appends the element for d to b and returns the extended buffer.
+	Format func(b []byte, d Date) []byte
+
+	// Parse parses the element at the start of value and returns the rest
+	// of value. It can modify f to set the fields it parsed. f contains the
+	// fields parsed so far, with fields which have not been parsed being
+	// zero. The Weekday of f is ignored.
+	//
+	// If Parse is nil, layouts containing the element can not be parsed.
+	Parse func(value string, f *Fields) (rest string, err error)
+}
+
+// elements is the registry of layout elements.
+type elements struct {
+	byToken map[string]*LayoutElement
+	tokens  []string // sorted by decreasing length
+}
+
+var (
+	// registry is replaced on every registration, so reading it does not
+	// need any locking.
+	registry   atomic.Pointer[elements]
+	registryMu sync.Mutex // serializes registrations
+)
+
+// RegisterLayoutElement registers e, so that e.Token can be used in layouts.
+// It panics, if the token is empty, contains an element of the reference
+// date or is a prefix of one, including the extended elements, if Format is
+// nil or if the token is already registered. It also panics, if the token
+// occurs in [RFC3339] or [ISOBasic]: those layouts are formatted and parsed
+// by a fast path, which does not know about registered elements, so they
+// always keep their standard meaning.
+//
+// Registered tokens are matched in layouts before the elements of the
+// reference date, longer tokens first. RegisterLayoutElement should be called
+// during initialization. Already created [Formatter] values are not affected.
+//
+// For example, to format the quarter of a date, like "2024-Q2":
+//
+//	date.RegisterLayoutElement(date.LayoutElement{
+//		Token: "Q",
+//		Format: func(b []byte, d date.Date) []byte {
+//			return strconv.AppendInt(append(b, 'Q'), int64(d.Month()+2)/3, 10)
+//		},
+//	})
+//	s := d.Format("2006-Q")
+func RegisterLayoutElement(e LayoutElement) {
+	if e.Token == "" {
+		panic("date: empty layout element token")
+	}
+	for s := e.Token; s != ""; {
+		var op fmtOp
+		if _, op, s = nextOp(s, modeExtended); op != opLiteral && op != opCustom {
+			panic("date: layout element token " + e.Token + " contains reference element " + op.String())
+		}
+	}
+	for op := opLongMonth; op < opCustom; op++ {
+		if strings.HasPrefix(op.String(), e.Token) {
+			panic("date: layout element token " + e.Token + " is a prefix of reference element " + op.String())
+		}
+	}
+	if strings.Contains(RFC3339, e.Token) || strings.Contains(ISOBasic, e.Token) {
+		panic("date: layout element token " + e.Token + " occurs in a layout with a fast path")
+	}
+	if e.Format == nil {
+		panic("date: layout element " + e.Token + " has no Format function")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	old := registry.Load()
+	n := &elements{byToken: make(map[string]*LayoutElement)}
+	if old != nil {
+		if _, ok := old.byToken[e.Token]; ok {
+			panic("date: layout element " + e.Token + " already registered")
+		}
+		maps.Copy(n.byToken, old.byToken)
+	}
+	n.byToken[e.Token] = &e
+	n.tokens = slices.SortedFunc(maps.Keys(n.byToken), func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), strings.Compare(a, b))
+	})
+	registry.Store(n)
+	// Layouts compiled before might contain the new token.
+	memo.Flush()
+	memoExtended.Flush()
+	memoOptions.Flush()
+}
+
+// matchElement returns the token of the registered layout element at the
+// start of layout, if any.
+func matchElement(layout string) (token string, ok bool) {
+	r := registry.Load()
+	if r == nil {
+		return "", false
+	}
+	for _, t := range r.tokens {
+		if strings.HasPrefix(layout, t) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// lookupElement returns the registered layout element with the given token.
+func lookupElement(token string) *LayoutElement {
+	return registry.Load().byToken[token]
+}
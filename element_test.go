@@ -0,0 +1,121 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The tokens registered in this file use guillemets, so they do not appear in
+// the layouts used by other tests.
+
+func init() {
+	RegisterLayoutElement(LayoutElement{
+		Token: "«Q»",
+		Format: func(b []byte, d Date) []byte {
+			return strconv.AppendInt(append(b, 'Q'), int64(d.Month()+2)/3, 10)
+		},
+		Parse: func(value string, f *Fields) (string, error) {
+			if len(value) < 2 || value[0] != 'Q' || value[1] < '1' || value[1] > '4' {
+				return value, errors.New("invalid quarter")
+			}
+			if f.Month == 0 {
+				f.Month = time.Month(3*(value[1]-'1') + 1)
+			}
+			return value[2:], nil
+		},
+	})
+	RegisterLayoutElement(LayoutElement{
+		Token: "«Q»x",
+		Format: func(b []byte, d Date) []byte {
+			return append(b, "long"...)
+		},
+	})
+}
+
+func TestLayoutElement(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 14)
+	formats := []struct {
+		layout string
+		want   string
+	}{
+		{"2006-«Q»", "2024-Q2"},
+		{"«Q» 2006, Jan 2", "Q2 2024, May 14"},
+		{"«Q»x", "long"},
+		{"«Q»«Q»", "Q2Q2"},
+	}
+	for _, tc := range formats {
+		if got := d.Format(tc.layout); got != tc.want {
+			t.Errorf("%v.Format(%q) = %q, want %q", d, tc.layout, got, tc.want)
+		}
+		if got := NewFormatter(tc.layout).Format(d); got != tc.want {
+			t.Errorf("NewFormatter(%q).Format(%v) = %q, want %q", tc.layout, d, got, tc.want)
+		}
+	}
+
+	parses := []struct {
+		layout string
+		value  string
+		want   Date
+	}{
+		{"2006-«Q»", "2024-Q3", Of(2024, 7, 1)},
+		{"«Q» 2006, Jan 2", "Q2 2024, May 14", Of(2024, 5, 14)},
+		{"2006-01-02 «Q»", "2024-05-14 Q1", Of(2024, 5, 14)},
+	}
+	for _, tc := range parses {
+		got, err := Parse(tc.layout, tc.value)
+		if err != nil || got != tc.want {
+			t.Errorf("Parse(%q, %q) = %v, %v, want %v, <nil>", tc.layout, tc.value, got, err, tc.want)
+		}
+	}
+
+	errs := []struct {
+		layout string
+		value  string
+		want   string
+	}{
+		{"2006-«Q»", "2024-Q5", "invalid quarter"},
+		{"2006-«Q»", "2024-Q1x", "extra text"},
+		{"«Q»x", "long", "can not be parsed"},
+	}
+	for _, tc := range errs {
+		_, err := Parse(tc.layout, tc.value)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Parse(%q, %q) = _, %v, want error containing %q", tc.layout, tc.value, err, tc.want)
+		}
+	}
+}
+
+func TestRegisterLayoutElementPanics(t *testing.T) {
+	t.Parallel()
+	format := func(b []byte, d Date) []byte { return b }
+	tcs := []LayoutElement{
+		{Token: "", Format: format},
+		{Token: "«P»"},
+		{Token: "«Q»", Format: format},
+		{Token: "«2006»", Format: format},
+		{Token: "Ja", Format: format},
+		{Token: "_", Format: format},
+		{Token: "Mon", Format: format},
+		{Token: "-", Format: format},
+		{Token: "6", Format: format},
+	}
+	for _, e := range tcs {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterLayoutElement(%q) did not panic", e.Token)
+				}
+			}()
+			RegisterLayoutElement(e)
+		}()
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// UnixEpoch is the Date of the Unix epoch, 1970-01-01.
+const UnixEpoch Date = 719162
+
+// FromUnixDays returns the Date n days after the Unix epoch, 1970-01-01.
+func FromUnixDays(n int64) Date {
+	return UnixEpoch + Date(n)
+}
+
+// UnixDays returns the number of days from the Unix epoch, 1970-01-01, to d.
+// This is the representation of dates used by Avro, Parquet and Arrow.
+func (d Date) UnixDays() int64 {
+	return int64(d - UnixEpoch)
+}
+
+// secondsPerDay is the number of seconds in a day of Unix time, which ignores
+// leap seconds.
+const secondsPerDay = 24 * 60 * 60
+
+// FromUnix returns the date in UTC of the Unix time sec, the number of seconds
+// since 1970-01-01 00:00:00 UTC. For the date in another location, use
+// FromTime(time.Unix(sec, 0).In(loc)).
+func FromUnix(sec int64) Date {
+	days := sec / secondsPerDay
+	if sec%secondsPerDay < 0 {
+		days--
+	}
+	return FromUnixDays(days)
+}
+
+// FromTime returns the date of t in its location. It is equivalent to
+// Of(t.Date()).
+func FromTime(t time.Time) Date {
+	return Of(t.Date())
+}
+
+// Unix returns the Unix time of midnight at the start of d in loc, as computed
+// by [Date.Time]. For UTC, it is the same as d.UnixDays() times the number of
+// seconds in a day.
+func (d Date) Unix(loc *time.Location) int64 {
+	return d.Time(0, 0, 0, 0, loc).Unix()
+}
+
+// EpochDays is a Date, which is represented in JSON as the number of days
+// since the Unix epoch, 1970-01-01. Convert a Date to EpochDays to use that
+// representation:
+//
+//	type Event struct {
+//		Day date.EpochDays `json:"day"`
+//	}
+//	b, err := json.Marshal(Event{date.EpochDays(d)})
+type EpochDays Date
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e EpochDays) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, Date(e).UnixDays(), 10), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts an
+// integer JSON number of days in the range of [MinDate]…[MaxDate]. As is the
+// convention, null is a no-op.
+func (e *EpochDays) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	n, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return errors.New("epoch days must be an integer")
+	}
+	if n < MinDate.UnixDays() || n > MaxDate.UnixDays() {
+		return errors.New("epoch days out of range")
+	}
+	*e = EpochDays(FromUnixDays(n))
+	return nil
+}
+
+// String returns the date formatted as ISO 8601.
+func (e EpochDays) String() string {
+	return Date(e).String()
+}
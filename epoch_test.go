@@ -0,0 +1,103 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestUnixEpoch(t *testing.T) {
+	if got, want := UnixEpoch, Of(1970, 1, 1); got != want {
+		t.Errorf("UnixEpoch = %v, want %v", got, want)
+	}
+	if got, want := Of(2024, 5, 14).UnixDays(), int64(19857); got != want {
+		t.Errorf("UnixDays() = %d, want %d", got, want)
+	}
+	if got, want := FromUnixDays(-1), Of(1969, 12, 31); got != want {
+		t.Errorf("FromUnixDays(-1) = %v, want %v", got, want)
+	}
+}
+
+func TestUnix(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		sec  int64
+		want Date
+	}{
+		{0, UnixEpoch},
+		{86399, UnixEpoch},
+		{86400, UnixEpoch + 1},
+		{-1, UnixEpoch - 1},
+		{-86400, UnixEpoch - 1},
+		{-86401, UnixEpoch - 2},
+		{1672528154, Of(2022, 12, 31)},
+	}
+	for _, tc := range tcs {
+		if got := FromUnix(tc.sec); got != tc.want {
+			t.Errorf("FromUnix(%d) = %v, want %v", tc.sec, got, tc.want)
+		}
+		if got, want := FromUnix(tc.sec), FromTime(time.Unix(tc.sec, 0).UTC()); got != want {
+			t.Errorf("FromUnix(%d) = %v, FromTime(time.Unix(%[1]d, 0).UTC()) = %v", tc.sec, got, want)
+		}
+	}
+
+	berlin := time.FixedZone("CEST", 2*60*60)
+	if got, want := FromTime(time.Date(2024, 5, 14, 23, 30, 0, 0, time.UTC).In(berlin)), Of(2024, 5, 15); got != want {
+		t.Errorf("FromTime = %v, want %v", got, want)
+	}
+	d := Of(2024, 5, 14)
+	if got, want := d.Unix(time.UTC), d.UnixDays()*86400; got != want {
+		t.Errorf("%v.Unix(UTC) = %d, want %d", d, got, want)
+	}
+	if got, want := d.Unix(berlin), d.UnixDays()*86400-2*60*60; got != want {
+		t.Errorf("%v.Unix(%v) = %d, want %d", d, berlin, got, want)
+	}
+}
+
+func TestEpochDaysJSON(t *testing.T) {
+	t.Parallel()
+	type S struct {
+		D EpochDays `json:"d"`
+	}
+	b, err := json.Marshal(S{EpochDays(Of(2024, 5, 14))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"d":19857}`; got != want {
+		t.Errorf("json.Marshal = %s, want %s", got, want)
+	}
+
+	tcs := []struct {
+		in   string
+		want EpochDays
+		err  bool
+	}{
+		{`{"d":19857}`, EpochDays(Of(2024, 5, 14)), false},
+		{`{"d":-719162}`, 0, false},
+		{`{"d":null}`, 42, false},
+		{`{"d":106751991167327}`, EpochDays(MaxDate), false},
+		{`{"d":-106751991073094}`, EpochDays(MinDate), false},
+		{`{"d":106751991167328}`, 0, true},
+		{`{"d":-106751991073095}`, 0, true},
+		{`{"d":9223372036854775807}`, 0, true},
+		{`{"d":1.5}`, 0, true},
+		{`{"d":1e3}`, 0, true},
+		{`{"d":"19857"}`, 0, true},
+	}
+	for _, tc := range tcs {
+		s := S{42}
+		err := json.Unmarshal([]byte(tc.in), &s)
+		if (err != nil) != tc.err {
+			t.Errorf("json.Unmarshal(%s) = %v, want error: %v", tc.in, err, tc.err)
+			continue
+		}
+		if err == nil && s.D != tc.want {
+			t.Errorf("json.Unmarshal(%s) = %v, want %v", tc.in, s.D, tc.want)
+		}
+	}
+}
@@ -10,6 +10,9 @@ import (
 	"time"
 
 	"gonih.org/date"
+	"gonih.org/date/holidays/us"
+	"gonih.org/date/locale/de"
+	"gonih.org/date/locale/fr"
 )
 
 // ExampleOf demonstrates some useful patterns when using Of.
@@ -40,9 +43,35 @@ func ExampleOf() {
 	// 2022-12-31
 }
 
-// ExampleDiffDates demonstrates how to check if two dates differ by a given
+// ExampleFromTime demonstrates getting the Date of a time.Time.
+func ExampleFromTime() {
+	t := time.Date(2024, 1, 10, 13, 24, 42, 0, time.UTC)
+	fmt.Println(date.FromTime(t))
+
+	// The date depends on the location of t:
+	fmt.Println(date.FromTime(t.In(time.FixedZone("UTC+12", 12*3600))))
+
+	// Output:
+	// 2024-01-10
+	// 2024-01-11
+}
+
+// ExampleFromUnix demonstrates getting the Date of a unix timestamp.
+func ExampleFromUnix() {
+	// FromUnix returns the date in UTC:
+	fmt.Println(date.FromUnix(1672528154))
+
+	// For the date in another location, use FromTime:
+	fmt.Println(date.FromTime(time.Unix(1672528154, 0).In(time.FixedZone("UTC+2", 2*3600))))
+
+	// Output:
+	// 2022-12-31
+	// 2023-01-01
+}
+
+// Example_diffDates demonstrates how to check if two dates differ by a given
 // amount.
-func ExampleDiffDates() {
+func Example_diffDates() {
 	// When comparing by number of days, we can just check their difference:
 	if d1, d2 := date.Of(2024, 3, 5), date.Of(2024, 2, 5); d2-d1 < 31 {
 		fmt.Printf("%v and %v are less than 31 days apart.\n", d1, d2)
@@ -74,6 +103,94 @@ func ExampleDiffDates() {
 	// 2024-02-05 and 2025-02-05 are at most a year apart.
 }
 
+// ExampleHumanizeDiff demonstrates how to display the difference between two
+// dates.
+func ExampleHumanizeDiff() {
+	d1, d2 := date.Of(2024, 5, 14), date.Of(2025, 7, 19)
+	fmt.Println(date.Between(d1, d2))
+	fmt.Println(date.HumanizeDiff(d1, d2))
+
+	// Use a Humanizer to select different units or limit the precision:
+	h := date.Humanizer{Units: []date.Unit{date.Weeks, date.Days}}
+	fmt.Println(h.Diff(d1, d2))
+	h = date.Humanizer{Precision: 2}
+	fmt.Println(h.Diff(d1, d2))
+
+	// Output:
+	// P1Y2M5D
+	// 1 year, 2 months, 5 days
+	// 61 weeks, 4 days
+	// 1 year, 2 months
+}
+
+// ExampleDate_With demonstrates how to combine adjusters to express scheduling
+// rules.
+func ExampleDate_With() {
+	d := date.Of(2024, 5, 14)
+
+	// The second Tuesday of next month.
+	fmt.Println(d.With(date.FirstDayOfNextMonth(), date.NthInMonth(2, time.Tuesday)))
+
+	// The last business day of the month, ignoring holidays.
+	fmt.Println(d.With(date.LastDayOfMonth(), date.AdjusterFunc(func(d date.Date) date.Date {
+		for date.Weekend.Contains(d.Weekday()) {
+			d--
+		}
+		return d
+	})))
+
+	// Output:
+	// 2024-06-11
+	// 2024-05-31
+}
+
+// ExampleBusinessCalendar demonstrates how to compute settlement dates.
+func ExampleBusinessCalendar() {
+	cal := date.NewBusinessCalendar(date.Weekend, us.Federal).
+		CloseOn(date.Of(2024, 12, 24)) // office party
+
+	// Two business days after December 20th, skipping the weekend, the
+	// office party and Christmas Day.
+	fmt.Println(cal.AddBusinessDays(date.Of(2024, 12, 20), 2))
+
+	// 2027-12-31 is the observed New Year's Day 2028.
+	fmt.Println(cal.IsBusinessDay(date.Of(2027, 12, 31)))
+
+	// Output:
+	// 2024-12-26
+	// false
+}
+
+func ExampleFrom() {
+	cal := date.NewBusinessCalendar(date.Weekend, us.Federal)
+	d := date.Of(2024, 11, 20)
+
+	// The last Friday of next month.
+	fmt.Println(date.From(d).AddMonths(1).LastDayOfMonth().PreviousOrSame(time.Friday).Date())
+
+	// The first business day of next year.
+	fmt.Println(date.From(d).AddYears(1).FirstDayOfYear().AddDays(-1).NextBusinessDay(cal).Date())
+
+	// Output:
+	// 2024-12-27
+	// 2025-01-02
+}
+
+// Example_regionalLayouts demonstrates that regional layouts are ambiguous.
+func Example_regionalLayouts() {
+	const value = "05/06/2024"
+	us, _ := date.Parse(date.US, value)
+	uk, _ := date.Parse(date.UKSlash, value)
+	fmt.Println(us.Format("January 2"), "or", uk.Format("January 2"))
+
+	// Formatting in an unambiguous layout avoids the problem.
+	fmt.Println(us.Format(date.RFC3339), "or", uk.Format(date.RFC3339))
+
+	// Output:
+	// May 6 or June 5
+	// 2024-05-06 or 2024-06-05
+}
+
 // ExampleParse demonstrates the usage of Parse.
 func ExampleParse() {
 	// Parse date according to RFC3339.
@@ -104,3 +221,55 @@ func ExampleParse() {
 	// 0001-01-01 parsing date "2023-02-29": day out of range
 	// 2024-02-25 <nil> Sunday
 }
+
+// ExampleDate_FormatIn demonstrates formatting and parsing dates in other
+// languages.
+func ExampleDate_FormatIn() {
+	d := date.Of(2024, 3, 14)
+	fmt.Println(d.FormatIn("Monday, 2. January 2006", de.Locale))
+	fmt.Println(d.FormatIn("Mon 2 Jan 2006", fr.Locale))
+
+	// Names are matched case-insensitively.
+	fmt.Println(date.ParseIn("2 January 2006", "14 MÄRZ 2024", de.Locale))
+
+	// Output:
+	// Donnerstag, 14. März 2024
+	// jeu. 14 mars 2024
+	// 2024-03-14 <nil>
+}
+
+// ExampleLookupLocale demonstrates choosing a locale by the language of a
+// user. The locales of the imported subpackages of gonih.org/date/locale are
+// registered.
+func ExampleLookupLocale() {
+	d := date.Of(2024, 3, 14)
+	for _, tag := range []string{"de-AT", "fr_CA", "en-GB", "ja"} {
+		loc, ok := date.LookupLocale(tag)
+		if !ok {
+			loc, _ = date.LookupLocale("en")
+		}
+		fmt.Println(tag, d.FormatIn("January 2006", loc))
+	}
+
+	// Output:
+	// de-AT März 2024
+	// fr_CA mars 2024
+	// en-GB March 2024
+	// ja March 2024
+}
+
+// ExampleOfISOWeek demonstrates converting between dates and ISO 8601 week
+// dates, as used by planning systems.
+func ExampleOfISOWeek() {
+	d := date.OfISOWeek(2024, 5, time.Wednesday)
+	fmt.Println(d, date.FormatISOWeek(d))
+
+	// The ISO year of a date can differ from its calendar year.
+	fmt.Println(date.ParseISOWeek("2025-W01-1"))
+	fmt.Println(date.FormatISOWeekBasic(date.Of(2027, 1, 1)))
+
+	// Output:
+	// 2024-01-31 2024-W05-3
+	// 2024-12-30 <nil>
+	// 2026W535
+}
@@ -0,0 +1,35 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "gonih.org/date/internal/cache"
+
+// memoize layout strings compiled by parseLayoutExtended.
+var memoExtended = cache.Cache[string, []inst]{MaxSize: defaultLayoutCacheSize, Size: layoutSize}
+
+// parseLayoutExtended is like parseLayout, but also recognizes the extended
+// elements, like "2nd".
+func parseLayoutExtended(layout string) []inst {
+	return compileLayout(layout, modeExtended)
+}
+
+// formatExtended returns d formatted according to layout, recognizing the
+// extended elements.
+func (d Date) formatExtended(layout string) string {
+	var buf [64]byte
+	return string(d.appendProg(buf[:0], memoExtended.Get(layout, parseLayoutExtended), &english, KeepCase))
+}
+
+// SetExtended sets whether f recognizes the extended elements of layouts, like
+// "2nd", and returns f. They are documented for [Layout]. Otherwise, they are
+// literals, as for package time. For example, for dates like "May 14th":
+//
+//	f := NewFormatter("January 2nd").SetExtended(true)
+func (f *Formatter) SetExtended(on bool) *Formatter {
+	f.extended = on
+	f.compile()
+	return f
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"iter"
+	"time"
+)
+
+// A Predicate reports whether a date matches some pattern, like "a Friday
+// the 13th". Predicates can be combined using [AllOf], [AnyOf] and [Not].
+type Predicate func(Date) bool
+
+// Find returns an iterator over all dates in r for which pred returns true,
+// in ascending order. It does not allocate per date, so it can be used to
+// scan long ranges.
+func Find(r Range, pred Predicate) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for d := r.Start; d < r.End; d++ {
+			if pred(d) && !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// AllOf returns a Predicate matching the dates matched by all of ps. If ps is
+// empty, it matches all dates.
+func AllOf(ps ...Predicate) Predicate {
+	return func(d Date) bool {
+		for _, p := range ps {
+			if !p(d) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AnyOf returns a Predicate matching the dates matched by any of ps. If ps is
+// empty, it matches no dates.
+func AnyOf(ps ...Predicate) Predicate {
+	return func(d Date) bool {
+		for _, p := range ps {
+			if p(d) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a Predicate matching the dates not matched by p.
+func Not(p Predicate) Predicate {
+	return func(d Date) bool {
+		return !p(d)
+	}
+}
+
+// OnWeekdays returns a Predicate matching the dates falling on a weekday in
+// s.
+func OnWeekdays(s WeekdaySet) Predicate {
+	return func(d Date) bool {
+		return s.Contains(d.Weekday())
+	}
+}
+
+// OnDay returns a Predicate matching the given day of the month. If day is
+// negative, it counts from the end of the month, so OnDay(-1) matches the
+// last day of each month.
+func OnDay(day int) Predicate {
+	return func(d Date) bool {
+		if day < 0 {
+			year, month, _ := d.Date()
+			return d == Of(year, month+1, day+1)
+		}
+		return d.Day() == day
+	}
+}
+
+// FridayThe13th reports whether d is a Friday and the 13th of its month.
+func FridayThe13th(d Date) bool {
+	return d.Day() == 13 && d.Weekday() == time.Friday
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+	c := NewBusinessCalendar(Weekend).CloseOn(Of(2024, 5, 31))
+	tcs := []struct {
+		name string
+		r    Range
+		pred Predicate
+		want []Date
+	}{
+		{"FridayThe13th", YearRange(2024), FridayThe13th, []Date{Of(2024, 9, 13), Of(2024, 12, 13)}},
+		{"FridayThe13th", YearRange(2026), FridayThe13th, []Date{Of(2026, 2, 13), Of(2026, 3, 13), Of(2026, 11, 13)}},
+		{"OnDay(-1)", ClosedRange(Of(2024, 1, 15), Of(2024, 3, 31)), OnDay(-1), []Date{Of(2024, 1, 31), Of(2024, 2, 29), Of(2024, 3, 31)}},
+		{"OnDay(-2)", MonthRange(2023, time.February), OnDay(-2), []Date{Of(2023, 2, 27)}},
+		{"OnDay(31)", ClosedRange(Of(2024, 1, 1), Of(2024, 4, 30)), OnDay(31), []Date{Of(2024, 1, 31), Of(2024, 3, 31)}},
+		{
+			"IsLastBusinessDayOfMonth",
+			ClosedRange(Of(2024, 3, 1), Of(2024, 6, 30)),
+			c.IsLastBusinessDayOfMonth,
+			[]Date{Of(2024, 3, 29), Of(2024, 4, 30), Of(2024, 5, 30), Of(2024, 6, 28)},
+		},
+		{
+			"IsFirstBusinessDayOfMonth",
+			ClosedRange(Of(2024, 5, 2), Of(2024, 9, 30)),
+			c.IsFirstBusinessDayOfMonth,
+			[]Date{Of(2024, 6, 3), Of(2024, 7, 1), Of(2024, 8, 1), Of(2024, 9, 2)},
+		},
+		{
+			"AllOf(OnWeekdays(Weekend), Not(OnDay(1)), Not(OnDay(30)))",
+			MonthRange(2024, time.June),
+			AllOf(OnWeekdays(Weekend), Not(OnDay(1)), Not(OnDay(30))),
+			[]Date{Of(2024, 6, 2), Of(2024, 6, 8), Of(2024, 6, 9), Of(2024, 6, 15), Of(2024, 6, 16), Of(2024, 6, 22), Of(2024, 6, 23), Of(2024, 6, 29)},
+		},
+		{"AnyOf(OnDay(1), OnDay(15))", ClosedRange(Of(2024, 1, 1), Of(2024, 2, 14)), AnyOf(OnDay(1), OnDay(15)), []Date{Of(2024, 1, 1), Of(2024, 1, 15), Of(2024, 2, 1)}},
+		{"AnyOf()", YearRange(2024), AnyOf(), nil},
+		{"AllOf()", ClosedRange(Of(2024, 1, 1), Of(2024, 1, 2)), AllOf(), []Date{Of(2024, 1, 1), Of(2024, 1, 2)}},
+	}
+	for _, tc := range tcs {
+		if got := slices.Collect(Find(tc.r, tc.pred)); !slices.Equal(got, tc.want) {
+			t.Errorf("Find(%v, %s) = %v, want %v", tc.r, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFindAllocs(t *testing.T) {
+	pred := AllOf(OnWeekdays(Workdays), OnDay(13))
+	r := ClosedRange(Of(2000, 1, 1), Of(2099, 12, 31))
+	n := testing.AllocsPerRun(10, func() {
+		for range Find(r, pred) {
+		}
+	})
+	if n > 2 {
+		t.Errorf("Find allocated %v times, want at most 2", n)
+	}
+}
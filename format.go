@@ -6,11 +6,17 @@
 package date
 
 import (
+	"cmp"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
+	"unsafe"
 
 	"gonih.org/date/internal/cache"
 )
@@ -32,62 +38,59 @@ import (
 //	Day of the week: "Mon" "Monday"
 //	Day of the month: "2" "_2", "02"
 //	Day of the year: "__2" "002"
+//
+// Further elements can be added with [RegisterLayoutElement].
+//
+// A [Parser] with Extended set and a [Formatter] with [Formatter.SetExtended]
+// also recognize the following elements, which are not supported by package
+// time:
+//
+//	Year: "12006"
+//	Month: "J"
+//	Day of the week: "Mo" "M" "Mon#"
+//	Day of the month: "2nd"
+//	Skipped text: "…"
+//
+// The element "2nd" formats the day of the month with an English ordinal
+// suffix, like "1st" or "22nd". When parsing, the suffix is optional. The
+// element "Mo" formats the day of the week with two letters, like "Tu", as
+// used in calendar headers. The elements "J" and "M" format the narrow names
+// of the month and day of the week, which are usually their first letter,
+// like "M" for May and "T" for Tuesday. To avoid misinterpreting literals,
+// they are only recognized if they are not adjacent to a letter. As narrow
+// names are ambiguous, layouts containing them can not be parsed. The element
+// "12006" is the year in the Holocene calendar, also called the Human Era,
+// which is the Gregorian year plus 10000, like "12024" for 2024. It has at
+// least five digits. The element "Mon#" is the ISO 8601 number of the day of
+// the week, from 1 for Monday to 7 for Sunday, like %u of strftime.
+//
+// The element "…" (U+2026, an ellipsis) skips text when parsing, up to the
+// first occurrence of the literal text following it in the layout, or to the
+// end of the value, if it ends the layout. This allows parsing dates followed
+// or surrounded by other text, like "2006-01-02…" for
+// "2024-05-14T08:30:00+02:00 something". It must not be followed directly by
+// another element. When formatting, it is omitted.
+//
+// The regional layouts are ambiguous among each other: "05/06/2024" is May
+// 6th in [US] layout, but June 5th in [UKSlash] layout. Prefer [RFC3339] for
+// data exchange.
 const (
-	Layout  = "01/02 '06" // The reference date, in numerical order
-	RFC822  = "02 Jan 06"
-	RFC1123 = "02 Jan 2006"
-	RFC3339 = "2006-01-02"
-)
+	Layout   = "01/02 '06" // The reference date, in numerical order
+	RFC822   = "02 Jan 06"
+	RFC1123  = "02 Jan 2006"
+	RFC3339  = "2006-01-02"
+	ISOBasic = "20060102" // ISO 8601 basic format
 
-var longDayNames = []string{
-	"Sunday",
-	"Monday",
-	"Tuesday",
-	"Wednesday",
-	"Thursday",
-	"Friday",
-	"Saturday",
-}
-
-var shortDayNames = []string{
-	"Sun",
-	"Mon",
-	"Tue",
-	"Wed",
-	"Thu",
-	"Fri",
-	"Sat",
-}
-
-var shortMonthNames = []string{
-	"Jan",
-	"Feb",
-	"Mar",
-	"Apr",
-	"May",
-	"Jun",
-	"Jul",
-	"Aug",
-	"Sep",
-	"Oct",
-	"Nov",
-	"Dec",
-}
-
-var longMonthNames = []string{
-	"January",
-	"February",
-	"March",
-	"April",
-	"May",
-	"June",
-	"July",
-	"August",
-	"September",
-	"October",
-	"November",
-	"December",
-}
+	// Regional layouts.
+	US             = "01/02/2006" // Month first, as used in the United States
+	UKSlash        = "02/01/2006" // Day first, as used in the United Kingdom
+	EuropeanDotted = "02.01.2006" // Day first, as used in Germany and others
+	Compact        = ISOBasic
+
+	// Long human-readable layouts.
+	LongDate    = "January 2, 2006"
+	LongOrdinal = "January 2nd, 2006" // Needs extended elements, parses dates with or without ordinal suffix
+)
 
 // inst is a single component of a layout string, either a literal string, or a
 // formatting operator.
@@ -98,7 +101,7 @@ type inst struct {
 
 // String implements fmt.Stringer, for debugging
 func (i inst) String() string {
-	if i.op == opLiteral {
+	if i.op == opLiteral || i.op == opCustom {
 		return i.lit
 	}
 	return i.op.String()
@@ -125,8 +128,28 @@ const (
 	opUnderLongYear // package time treats this as "_"+opLongYear, but it is simpler to just handle it with an extra opcode
 	opUnderDay
 	opUnderYearDay
+	opOrdinalDay // matched as a suffix of opDay, to keep the parsing preference
+	opMinWeekDay // after opLongWeekDay and opWeekDay, which it is a prefix of
+	opNarrowMonth
+	opNarrowWeekDay
+	opHoloceneYear // matched as opNumMonth followed by opLongYear
+	opISOWeekDay   // matched as opWeekDay followed by "#"
+	opSkip
+	opCustom // a registered LayoutElement, with its token in inst.lit
 
 	opInvalid
+
+	// Only used for parsing, replacing the operators above to implement
+	// the options of a Parser. See Parser.compile.
+	opFuzzyLongMonth // opLongMonth with FuzzyMonths
+	opFuzzyMonth     // opMonth with FuzzyMonths
+	opRejectedYear   // opYear with RejectTwoDigitYears
+
+	// Replacing opLongMonth and opMonth in layouts without a day of the
+	// month, which use the standalone forms of month names. See
+	// markStandalone.
+	opStandaloneLongMonth
+	opStandaloneMonth
 )
 
 // String implements fmt.Stringer. Except for opLiteral, it returns the layout
@@ -135,9 +158,11 @@ func (op fmtOp) String() string {
 	switch op {
 	case opLiteral:
 		return "<literal>"
-	case opLongMonth:
+	case opCustom:
+		return "<custom>"
+	case opLongMonth, opFuzzyLongMonth, opStandaloneLongMonth:
 		return "January"
-	case opMonth:
+	case opMonth, opFuzzyMonth, opStandaloneMonth:
 		return "Jan"
 	case opLongWeekDay:
 		return "Monday"
@@ -149,7 +174,7 @@ func (op fmtOp) String() string {
 		return "01"
 	case opZeroDay:
 		return "02"
-	case opYear:
+	case opYear, opRejectedYear:
 		return "06"
 	case opNumMonth:
 		return "1"
@@ -163,41 +188,138 @@ func (op fmtOp) String() string {
 		return "_2"
 	case opUnderYearDay:
 		return "__2"
+	case opOrdinalDay:
+		return "2nd"
+	case opMinWeekDay:
+		return "Mo"
+	case opNarrowMonth:
+		return "J"
+	case opNarrowWeekDay:
+		return "M"
+	case opHoloceneYear:
+		return "12006"
+	case opISOWeekDay:
+		return "Mon#"
+	case opSkip:
+		return "…"
 	}
 	panic("invalid fmtOp")
 }
 
+// isolated returns whether op must not be adjacent to any letter.
+func (op fmtOp) isolated() bool {
+	return op == opNarrowMonth || op == opNarrowWeekDay
+}
+
 // endsWord returns whether op must be a full word, that is must not be
 // followed by a lower-case letter.
 func (op fmtOp) endsWord() bool {
-	return op == opMonth || op == opWeekDay
+	return op == opMonth || op == opWeekDay || op == opMinWeekDay
 }
 
 // memoize compiled layout strings.
-var memo cache.Cache[string, []inst]
+var memo = cache.Cache[string, []inst]{MaxSize: defaultLayoutCacheSize, Size: layoutSize}
+
+// defaultLayoutCacheSize is the default limit for the memory used by each
+// cache of compiled layouts, in bytes.
+const defaultLayoutCacheSize = 256 << 10
+
+// layoutSize estimates the memory used by caching prog as the compiled layout,
+// in bytes. The literals of prog share memory with layout.
+func layoutSize(layout string, prog []inst) int64 {
+	const overhead = 128 // map and queue entry
+	return int64(overhead + len(layout) + cap(prog)*int(unsafe.Sizeof(inst{})))
+}
+
+// SetLayoutCacheSize limits the memory used to cache compiled layouts to about
+// n bytes. Layouts are compiled on first use for formatting or parsing and
+// cached, so using them again is fast. If layouts are constructed dynamically,
+// for example from user input, a smaller limit bounds the memory used. If n is
+// zero, layouts are not cached. If n is negative, the default of 256 KiB is
+// restored. Layouts used with [Parser.Extended], [Parser.TimeCompat] or options
+// changing how elements are parsed, like [Parser.FuzzyMonths], are cached
+// separately, with the same limit.
+func SetLayoutCacheSize(n int) {
+	size := int64(n)
+	switch {
+	case n == 0:
+		size = -1
+	case n < 0:
+		size = defaultLayoutCacheSize
+	}
+	memo.SetMaxSize(size)
+	memoExtended.SetMaxSize(size)
+	memoCompat.SetMaxSize(size)
+	memoOptions.SetMaxSize(size)
+}
 
 // parseLayout parses layout into a set of instructions to parse or format
 // according to it.
 func parseLayout(layout string) []inst {
+	return compileLayout(layout, modeDefault)
+}
+
+// A layoutMode determines which elements are recognized in a layout.
+type layoutMode int
+
+const (
+	modeDefault  layoutMode = iota // the elements of package time and registered elements
+	modeExtended                   // additionally the extended elements, like "2nd"
+	modeCompat                     // only the elements of package time
+)
+
+// compileLayout implements parseLayout, parseLayoutExtended and
+// parseLayoutCompat.
+func compileLayout(layout string, mode layoutMode) []inst {
 	var prog []inst
 	for len(layout) > 0 {
-		prefix, op, suffix := nextOp(layout)
+		prefix, op, suffix := nextOp(layout, mode)
 		if prefix != "" {
 			prog = append(prog, inst{lit: prefix})
 		}
-		if op != opLiteral {
+		switch op {
+		case opLiteral:
+		case opCustom:
+			prog = append(prog, inst{op: op, lit: layout[len(prefix) : len(layout)-len(suffix)]})
+		default:
 			prog = append(prog, inst{op: op})
 		}
 		layout = suffix
 	}
+	markStandalone(prog)
 	return prog
 }
 
+// markStandalone replaces the month names in prog by their standalone forms,
+// if prog contains no day of the month.
+func markStandalone(prog []inst) {
+	if hasDay(prog) {
+		return
+	}
+	for j, i := range prog {
+		switch i.op {
+		case opLongMonth:
+			prog[j].op = opStandaloneLongMonth
+		case opMonth:
+			prog[j].op = opStandaloneMonth
+		}
+	}
+}
+
 // nextOp decomposes layout into the next operator, a literal prefix and the
-// rest of the layout.
-func nextOp(layout string) (prefix string, op fmtOp, suffix string) {
+// rest of the layout, recognizing the operators of mode.
+func nextOp(layout string, mode layoutMode) (prefix string, op fmtOp, suffix string) {
+	last, ext := opOrdinalDay, mode == modeExtended
+	if ext {
+		last = opCustom
+	}
 	for i := 0; i < len(layout); i++ {
-		for op := opLongMonth; op < opInvalid; op++ {
+		if mode != modeCompat {
+			if tok, ok := matchElement(layout[i:]); ok {
+				return layout[:i], opCustom, layout[i+len(tok):]
+			}
+		}
+		for op := opLongMonth; op < last; op++ {
 			suffix, ok := strings.CutPrefix(layout[i:], op.String())
 			if !ok {
 				continue
@@ -205,12 +327,51 @@ func nextOp(layout string) (prefix string, op fmtOp, suffix string) {
 			if op.endsWord() && startsWithLowerCase(suffix) {
 				continue
 			}
+			if op.isolated() && (startsWithLetter(suffix) || endsWithLetter(layout[:i])) {
+				continue
+			}
+			if rest, ok := strings.CutPrefix(suffix, "nd"); ok && op == opDay && ext {
+				return layout[:i], opOrdinalDay, rest
+			}
+			if rest, ok := strings.CutPrefix(suffix, "2006"); ok && op == opNumMonth && ext {
+				return layout[:i], opHoloceneYear, rest
+			}
+			if rest, ok := strings.CutPrefix(suffix, "#"); ok && op == opWeekDay && ext {
+				return layout[:i], opISOWeekDay, rest
+			}
 			return layout[:i], op, suffix
 		}
 	}
 	return layout, opLiteral, ""
 }
 
+// hasDay reports whether prog contains a day of the month.
+func hasDay(prog []inst) bool {
+	for _, i := range prog {
+		switch i.op {
+		case opDay, opZeroDay, opUnderDay, opOrdinalDay:
+			return true
+		}
+	}
+	return false
+}
+
+// startsWithLetter reports whether s starts with a letter. Any non-ASCII
+// character is considered a letter.
+func startsWithLetter(s string) bool {
+	return len(s) > 0 && isLetter(s[0])
+}
+
+// endsWithLetter reports whether s ends with a letter. Any non-ASCII
+// character is considered a letter.
+func endsWithLetter(s string) bool {
+	return len(s) > 0 && isLetter(s[len(s)-1])
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c|0x20 && c|0x20 <= 'z' || c >= 0x80
+}
+
 // startsWithLowerCase reports whether the string has a lower-case letter at
 // the beginning. Its purpose is to prevent matching strings like "Month" when
 // looking for "Mon".
@@ -223,24 +384,54 @@ func startsWithLowerCase(s string) bool {
 // the constant called Layout to see how to represent the layout format.
 func (d Date) Format(layout string) string {
 	const bufSize = 64
-	var b []byte
 	max := len(layout) + 10
 	if max < bufSize {
 		var buf [64]byte
-		b = buf[:0]
-	} else {
-		b = make([]byte, 0, max)
+		return string(d.AppendFormat(buf[:0], layout))
+	}
+	bp := bufPool.Get().(*[]byte)
+	b := d.AppendFormat((*bp)[:0], layout)
+	s := string(b)
+	if cap(b) <= maxPooledBuf {
+		*bp = b
+		bufPool.Put(bp)
 	}
-	return string(d.AppendFormat(b, layout))
+	return s
+}
+
+// maxPooledBuf is the maximum capacity of buffers returned to bufPool, so a
+// single long layout does not pin a large buffer.
+const maxPooledBuf = 4 << 10
+
+// bufPool holds buffers used by Format for layouts too long for a buffer on
+// the stack.
+var bufPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 256)
+		return &b
+	},
 }
 
 // AppendFormat is like Format but appends the textual representation to b and
 // returns the extended buffer.
 func (d Date) AppendFormat(b []byte, layout string) []byte {
-	year, month, day, yday := absDate(d.abs(), true)
-	yday++
+	if b, ok := d.appendFast(b, layout); ok {
+		return b
+	}
+	return d.appendFormat(b, layout)
+}
 
-	prog := memo.Get(layout, parseLayout)
+// appendFormat is the general implementation of AppendFormat, interpreting
+// the compiled layout.
+func (d Date) appendFormat(b []byte, layout string) []byte {
+	return d.appendProg(b, memo.Get(layout, parseLayout), &english, KeepCase)
+}
+
+// appendProg appends d formatted according to the compiled layout prog to b,
+// using the names of loc in case nc.
+func (d Date) appendProg(b []byte, prog []inst, loc *Locale, nc NameCase) []byte {
+	f := d.Fields()
+	year, month, day, yday := f.Year, f.Month, f.Day, f.YearDay
 
 	for _, i := range prog {
 		switch i.op {
@@ -274,10 +465,24 @@ func (d Date) AppendFormat(b []byte, layout string) []byte {
 				b = append(b, '0')
 			}
 			b = strconv.AppendInt(b, int64(y), 10)
+		case opHoloceneYear:
+			y := year + holoceneOffset
+			if y < 0 {
+				b = append(b, '-')
+				y = -y
+			}
+			for n := 10000; n > 1 && y < n; n /= 10 {
+				b = append(b, '0')
+			}
+			b = strconv.AppendInt(b, int64(y), 10)
 		case opMonth:
-			b = append(b, month.String()[:3]...)
+			b = appendName(b, loc.ShortMonths[month-1], nc)
 		case opLongMonth:
-			b = append(b, month.String()...)
+			b = appendName(b, loc.Months[month-1], nc)
+		case opStandaloneMonth:
+			b = appendName(b, loc.standaloneMonths(true)[month-1], nc)
+		case opStandaloneLongMonth:
+			b = appendName(b, loc.standaloneMonths(false)[month-1], nc)
 		case opNumMonth:
 			b = strconv.AppendInt(b, int64(month), 10)
 		case opZeroMonth:
@@ -286,9 +491,17 @@ func (d Date) AppendFormat(b []byte, layout string) []byte {
 			}
 			b = strconv.AppendInt(b, int64(month), 10)
 		case opWeekDay:
-			b = append(b, d.Weekday().String()[:3]...)
+			b = appendName(b, loc.ShortWeekdays[f.Weekday], nc)
 		case opLongWeekDay:
-			b = append(b, d.Weekday().String()...)
+			b = appendName(b, loc.Weekdays[f.Weekday], nc)
+		case opMinWeekDay:
+			b = appendName(b, loc.minWeekdays()[f.Weekday], nc)
+		case opNarrowMonth:
+			b = appendName(b, loc.narrowMonths()[month-1], nc)
+		case opNarrowWeekDay:
+			b = appendName(b, loc.narrowWeekdays()[f.Weekday], nc)
+		case opISOWeekDay:
+			b = append(b, byte('0'+isoWeekday(f.Weekday)))
 		case opDay:
 			b = strconv.AppendInt(b, int64(day), 10)
 		case opUnderDay:
@@ -301,6 +514,9 @@ func (d Date) AppendFormat(b []byte, layout string) []byte {
 				b = append(b, '0')
 			}
 			b = strconv.AppendInt(b, int64(day), 10)
+		case opOrdinalDay:
+			b = strconv.AppendInt(b, int64(day), 10)
+			b = append(b, ordinalSuffix(day)...)
 		case opUnderYearDay:
 			if yday < 100 {
 				b = append(b, ' ')
@@ -317,6 +533,12 @@ func (d Date) AppendFormat(b []byte, layout string) []byte {
 				}
 			}
 			b = strconv.AppendInt(b, int64(yday), 10)
+		case opSkip:
+		case opCustom:
+			// Appending to a separate buffer keeps b from escaping, so
+			// layouts without custom elements can be formatted without
+			// allocating.
+			b = append(b, lookupElement(i.lit).Format(nil, d)...)
 		default:
 			panic(errors.New("invalid inst " + i.String()))
 		}
@@ -334,9 +556,238 @@ func (d Date) AppendFormat(b []byte, layout string) []byte {
 // is checked for syntax but is otherwise ignored.
 //
 // For layouts specifying the two-digit year 06, a value NN >= 69 will be
-// treated as 19NN and a value NN < 69 will be treated as 20NN.
+// treated as 19NN and a value NN < 69 will be treated as 20NN. Use a [Parser]
+// to check the day of the week or to change the handling of two-digit years.
 func Parse(layout, value string) (Date, error) {
-	p := newParser(value)
+	if d, ok := parseFast(layout, value); ok {
+		return d, nil
+	}
+	// Fall back to the general case, for error reporting.
+	return parse(layout, value, FoldSpaces)
+}
+
+// ParseTruncate parses value as a date in [RFC3339] format, like
+// "2024-05-14", or as a timestamp in [time.RFC3339] format, like
+// "2024-05-14T22:30:00Z". A timestamp is converted to loc and truncated to its
+// date there. This is useful for data sources sending timestamps, where a date
+// is meant. If value is neither, the error wraps both [ErrSyntax] and the
+// error returned by [time.Parse].
+func ParseTruncate(value string, loc *time.Location) (Date, error) {
+	if len(value) <= len(RFC3339) {
+		return Parse(RFC3339, value)
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, &ParseError{
+			Value:   value,
+			Message: "neither a date nor an RFC 3339 timestamp: " + err.Error(),
+			Err:     fmt.Errorf("%w: %w", ErrSyntax, err),
+		}
+	}
+	return Of(t.In(loc).Date()), nil
+}
+
+// ParseTimestampIn parses value as a timestamp, using a layout as understood by
+// [time.Parse], and returns its date in loc. A timestamp without a time zone
+// is interpreted in loc, as by [time.ParseInLocation]. This answers questions
+// like "on what day did this event happen, for the user?":
+//
+//	d, err := date.ParseTimestampIn(time.RFC1123, "Tue, 14 May 2024 23:30:00 UTC", berlin)
+//
+// If value can not be parsed, the error is returned by package time.
+func ParseTimestampIn(layout, value string, loc *time.Location) (Date, error) {
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return 0, err
+	}
+	return Of(t.In(loc).Date()), nil
+}
+
+// A Parser parses dates, like [Parse], with configurable handling of white
+// space and validation. The zero value of a Parser behaves exactly like Parse.
+//
+// For data ingestion, where ambiguous or inconsistent values should be
+// rejected instead of guessed, a strict Parser can be used:
+//
+//	strict := date.Parser{
+//		Spaces:              date.ExactSpaces,
+//		CheckWeekday:        true,
+//		RejectTwoDigitYears: true,
+//		Within:              date.Range{date.Of(1900, 1, 1), date.Of(2100, 1, 1)},
+//	}
+type Parser struct {
+	// TrimSpace causes leading and trailing white space in the value to be
+	// ignored. This is useful for data with padded fields.
+	TrimSpace bool
+
+	// Spaces determines how spaces in literals of the layout match the
+	// value.
+	Spaces SpaceMode
+
+	// Locale provides the names of months and weekdays. If it is nil,
+	// English names are used.
+	Locale *Locale
+
+	// FuzzyMonths causes names of months to be matched tolerating minor
+	// misspellings and unusual abbreviations, like "Febuary" or "Sept".
+	// This is useful for data from error-prone sources, like OCR.
+	//
+	// A word which is not exactly the name of a month required by the
+	// layout is compared to all names of months of the locale, ignoring case
+	// and diacritics: "Maerz" matches "März". A word of at least three
+	// letters matches the names it is a prefix of. Otherwise, it matches the
+	// names which are at most one edit away or, for words with more than
+	// seven letters, two edits. An edit is inserting, deleting or replacing
+	// a letter or swapping two adjacent letters. Shorter words must match
+	// exactly. If the closest names belong to different months, the value is
+	// rejected.
+	FuzzyMonths bool
+
+	// CheckWeekday causes values to be rejected, if the day of the week in
+	// the value does not match the date. Otherwise, the day of the week is
+	// checked for syntax but ignored. [Parser.ParseLenient] reports a
+	// mismatch as a warning instead.
+	CheckWeekday bool
+
+	// RejectTwoDigitYears causes values of layouts containing the two-digit
+	// year 06 to be rejected, as their century has to be guessed.
+	RejectTwoDigitYears bool
+
+	// PivotYear determines the century of two-digit years. A two-digit year
+	// NN is the year ending in NN in the range PivotYear…PivotYear+99. If
+	// PivotYear is zero, 1969 is used, as documented for Parse.
+	PivotYear int
+
+	// Within restricts the parsed dates. If it is not empty, dates outside
+	// of it are rejected.
+	Within Range
+
+	// Extended causes the extended elements of layouts, like "2nd", to be
+	// recognized, as documented for [Layout]. Otherwise, they are literals,
+	// as for package time.
+	Extended bool
+
+	// TimeCompat causes Parse to accept and reject exactly the same values
+	// as [time.Parse] and to return the same dates, for layouts without
+	// elements of the time of day or time zone. The other fields are
+	// ignored, including Extended. Only the elements supported by package
+	// time are recognized, so registered layout elements are literals.
+	//
+	// This is meant for code migrating from [time.Time], which has to
+	// preserve the behavior of time.Parse. Without TimeCompat, Parse
+	// currently behaves the same for these layouts, but edge cases like
+	// the folding of spaces, "__2" or the century of two-digit years might
+	// change in the future.
+	TimeCompat bool
+}
+
+// A SpaceMode determines how spaces in literals of a layout match the value
+// when parsing.
+type SpaceMode int
+
+const (
+	// FoldSpaces matches a run of spaces in the layout against a run of one
+	// or more spaces in the value. This is the behavior of Parse.
+	FoldSpaces SpaceMode = iota
+
+	// ExactSpaces requires every space in the layout to match exactly one
+	// space in the value.
+	ExactSpaces
+
+	// LooseSpaces matches a run of spaces in the layout against a run of
+	// zero or more ASCII white space characters in the value, like tabs or
+	// spaces.
+	LooseSpaces
+)
+
+// Parse parses a formatted string and returns the date value it represents.
+// See [Parse] for details.
+func (ps Parser) Parse(layout, value string) (Date, error) {
+	if ps.TimeCompat {
+		return parseTimeCompat(layout, value)
+	}
+	if ps.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+	if d, ok := parseFast(layout, value); ok {
+		if err := ps.checkWithin(d, value); err != nil {
+			return 0, err
+		}
+		return d, nil
+	}
+	return parseProg(ps.compile(layout), layout, value, ps, nil)
+}
+
+// compile returns the compiled layout, recognizing the elements enabled by
+// the options of ps. Options changing how elements are parsed are compiled into
+// the instructions, so parsing does not have to check them for every element.
+func (ps Parser) compile(layout string) []inst {
+	if ps.FuzzyMonths || ps.RejectTwoDigitYears {
+		return memoOptions.Get(parseOptions{layout, ps.Extended, ps.FuzzyMonths, ps.RejectTwoDigitYears}, compileOptions)
+	}
+	if ps.Extended {
+		return memoExtended.Get(layout, parseLayoutExtended)
+	}
+	return memo.Get(layout, parseLayout)
+}
+
+// parseOptions is a layout and the options of a Parser compiled into it.
+type parseOptions struct {
+	layout                                     string
+	extended, fuzzyMonths, rejectTwoDigitYears bool
+}
+
+// memoize layouts compiled by compileOptions.
+var memoOptions = cache.Cache[parseOptions, []inst]{
+	MaxSize: defaultLayoutCacheSize,
+	Size: func(k parseOptions, prog []inst) int64 {
+		return layoutSize(k.layout, prog)
+	},
+}
+
+// compileOptions compiles k.layout, replacing the instructions affected by
+// the options in k.
+func compileOptions(k parseOptions) []inst {
+	prog := slices.Clone(Parser{Extended: k.extended}.compile(k.layout))
+	for j, i := range prog {
+		switch {
+		case (i.op == opLongMonth || i.op == opStandaloneLongMonth) && k.fuzzyMonths:
+			prog[j].op = opFuzzyLongMonth
+		case (i.op == opMonth || i.op == opStandaloneMonth) && k.fuzzyMonths:
+			prog[j].op = opFuzzyMonth
+		case i.op == opYear && k.rejectTwoDigitYears:
+			prog[j].op = opRejectedYear
+		}
+	}
+	return prog
+}
+
+// checkWithin returns an error, if d is not in ps.Within.
+func (ps Parser) checkWithin(d Date, value string) error {
+	if ps.Within.IsEmpty() || ps.Within.Contains(d) {
+		return nil
+	}
+	return &ParseError{Value: strings.Clone(value), Message: "date outside of " + ps.Within.String(), Err: ErrRange}
+}
+
+// parse is the general implementation of Parse, interpreting the compiled
+// layout.
+func parse(layout, value string, spaces SpaceMode) (Date, error) {
+	return parseProg(memo.Get(layout, parseLayout), layout, value, Parser{Spaces: spaces}, nil)
+}
+
+// parseProg parses value using prog, which must be the layout compiled by
+// ps.compile, and the other options of ps, except TrimSpace. If warn is not
+// nil, problems which can be worked around are appended to it instead of
+// failing, as documented for [Parser.ParseLenient].
+func parseProg(prog []inst, layout, value string, ps Parser, warn *[]ParseWarning) (Date, error) {
+	p := newParser(value, ps.Spaces)
+	loc := ps.Locale
+	if loc == nil {
+		loc = &english
+	}
+	// Unix time starts Dec 31 1969 in some time zones
+	pivot := cmp.Or(ps.PivotYear, 1969)
 	var (
 		// kept around for error reporting
 		alayout, avalue = layout, value
@@ -344,64 +795,140 @@ func Parse(layout, value string) (Date, error) {
 		month           int = -1
 		day             int = -1
 		yday            int = -1
-	)
+		wday            int = -1
 
-	prog := memo.Get(layout, parseLayout)
+		// kept around for warnings
+		wdayText, dayText string
+
+		// offsets of fields into value, for error reporting
+		dayOff, ydayOff, wdayOff int
+	)
 
 	// Execute the parsing instructions
-	for _, i := range prog {
+	for k, i := range prog {
 		p.setInst(i)
+		off := len(avalue) - len(p.value)
 		switch i.op {
 		case opLiteral:
 			p.accept(i.lit)
 		case opYear:
 			year = p.atoi(2)
-			if year >= 69 { // Unix time starts Dec 31 1969 in some time zones
-				year += 1900
-			} else {
-				year += 2000
-			}
+			_, n := norm(0, year-pivot, 100)
+			year = pivot + n
+		case opRejectedYear:
+			return 0, p.err(alayout, avalue, ErrLayout, "two-digit year not allowed")
 		case opUnderLongYear:
 			p.accept("_")
 			fallthrough
 		case opLongYear:
 			p.peekDigit()
 			year = p.atoi(4)
-		case opMonth:
-			month = p.lookup(shortMonthNames) + 1
-		case opLongMonth:
-			month = p.lookup(longMonthNames) + 1
+		case opHoloceneYear:
+			p.peekDigit()
+			year = p.atoi(5) - holoceneOffset
+		case opMonth, opStandaloneMonth:
+			month = p.lookup(loc.ShortMonths[:], loc.ShortStandaloneMonths[:]) + 1
+		case opFuzzyMonth:
+			month = p.lookupMonthFuzzy(loc, loc.ShortMonths[:], loc.ShortStandaloneMonths[:]) + 1
+		case opLongMonth, opStandaloneLongMonth:
+			month = p.lookup(loc.Months[:], loc.StandaloneMonths[:]) + 1
+		case opFuzzyLongMonth:
+			month = p.lookupMonthFuzzy(loc, loc.Months[:], loc.StandaloneMonths[:]) + 1
 		case opNumMonth, opZeroMonth:
 			month = p.num(i.op == opZeroMonth)
 			if month <= 0 || 12 < month {
-				return 0, p.err(alayout, avalue, "month out of range")
+				return 0, p.fieldErr(alayout, avalue, ErrRange, "month out of range", off, month)
 			}
 		case opWeekDay:
-			// ignore weekday, except for parsing
-			p.lookup(shortDayNames)
+			// ignore weekday, except for parsing and warnings
+			wday, wdayText, wdayOff = p.lookup(loc.ShortWeekdays[:]), p.consumed(), off
 		case opLongWeekDay:
-			// ignore weekday, except for parsing
-			p.lookup(longDayNames)
+			// ignore weekday, except for parsing and warnings
+			wday, wdayText, wdayOff = p.lookup(loc.Weekdays[:]), p.consumed(), off
+		case opMinWeekDay:
+			// ignore weekday, except for parsing and warnings
+			mins := loc.minWeekdays()
+			wday, wdayText, wdayOff = p.lookup(mins[:]), p.consumed(), off
+		case opNarrowMonth, opNarrowWeekDay:
+			return 0, p.err(alayout, avalue, ErrLayout, "narrow names are ambiguous and can not be parsed")
+		case opISOWeekDay:
+			// ignore weekday, except for parsing and warnings
+			n := p.getnumN(1, true)
+			if p.hasErr {
+				break
+			}
+			if n < 1 || 7 < n {
+				return 0, p.fieldErr(alayout, avalue, ErrRange, "day of the week out of range", off, n)
+			}
+			wday, wdayText, wdayOff = n%7, p.consumed(), off
 		case opUnderDay:
 			p.skipByte(' ')
 			fallthrough
 		case opDay, opZeroDay:
-			day = p.num(i.op == opZeroDay)
+			dayOff = len(avalue) - len(p.value)
+			day, dayText = p.num(i.op == opZeroDay), p.consumed()
+		case opOrdinalDay:
+			day, dayText, dayOff = p.num(false), p.consumed(), off
+			if suf := ordinalSuffix(day); len(p.value) >= 2 && match(p.value[:2], suf) {
+				p.value = p.value[2:]
+			}
 		case opUnderYearDay:
 			p.skipByte(' ')
 			p.skipByte(' ')
 			fallthrough
 		case opZeroYearDay:
+			ydayOff = len(avalue) - len(p.value)
 			yday = p.num3(i.op == opZeroYearDay)
+		case opSkip:
+			if k+1 == len(prog) {
+				p.value = ""
+				break
+			}
+			next := prog[k+1]
+			if next.op != opLiteral {
+				return 0, p.err(alayout, avalue, ErrLayout, "… must be followed by text")
+			}
+			if j := strings.Index(p.value, next.lit); j >= 0 {
+				p.value = p.value[j:]
+			} else {
+				p.parseFailed()
+			}
+		case opCustom:
+			e := lookupElement(i.lit)
+			if e.Parse == nil {
+				return 0, p.err(alayout, avalue, ErrLayout, "layout element "+i.lit+" can not be parsed")
+			}
+			f := Fields{Year: year, Month: time.Month(max(month, 0)), Day: max(day, 0), YearDay: max(yday, 0)}
+			// Cloning keeps value from escaping, as in parser.err.
+			rest, err := e.Parse(strings.Clone(p.value), &f)
+			if err != nil {
+				return 0, p.err(alayout, avalue, err, err.Error())
+			}
+			p.value = rest
+			year, month, day, yday = f.Year, int(f.Month), f.Day, f.YearDay
+			if month == 0 {
+				month = -1
+			} else if month < 0 || 12 < month {
+				return 0, p.fieldErr(alayout, avalue, ErrRange, "month out of range", off, month)
+			}
+			if day == 0 {
+				day = -1
+			}
+			if yday == 0 {
+				yday = -1
+			}
 		default:
 			panic(errors.New("invalid inst " + i.String()))
 		}
 		if p.hasErr {
-			return 0, p.err(alayout, avalue, "")
+			return 0, p.err(alayout, avalue, ErrSyntax, "")
 		}
 	}
 	if len(p.value) > 0 {
-		return 0, p.err(alayout, avalue, "extra text: "+strconv.Quote(p.value))
+		if warn == nil {
+			return 0, p.fieldErr(alayout, avalue, ErrExtraText, "extra text: "+strconv.Quote(p.value), len(avalue)-len(p.value), 0)
+		}
+		*warn = append(*warn, ParseWarning{ExtraText, strings.Clone(p.value)})
 	}
 	p.finish()
 
@@ -410,6 +937,7 @@ func Parse(layout, value string) (Date, error) {
 		var (
 			d int
 			m int
+			n = yday // yday is adjusted for leap years
 		)
 		if isLeap(year) {
 			if yday == 31+29 {
@@ -420,7 +948,7 @@ func Parse(layout, value string) (Date, error) {
 			}
 		}
 		if yday < 1 || yday > 365 {
-			return 0, p.err(alayout, avalue, "day-of-year out of range")
+			return 0, p.fieldErr(alayout, avalue, ErrRange, "day-of-year out of range", ydayOff, n)
 		}
 		if m == 0 {
 			m = (yday-1)/31 + 1
@@ -432,11 +960,11 @@ func Parse(layout, value string) (Date, error) {
 		// If month, day already seen, yday's m, d must match.
 		// Otherwise, set them from m, d.
 		if month >= 0 && month != m {
-			return 0, p.err(alayout, avalue, "day-of-year does not match month")
+			return 0, p.fieldErr(alayout, avalue, ErrMismatch, "day-of-year does not match month", ydayOff, n)
 		}
 		month = m
 		if day >= 0 && day != d {
-			return 0, p.err(alayout, avalue, "day-of-year does not match day")
+			return 0, p.fieldErr(alayout, avalue, ErrMismatch, "day-of-year does not match day", ydayOff, n)
 		}
 		day = d
 	} else {
@@ -448,10 +976,141 @@ func Parse(layout, value string) (Date, error) {
 		}
 	}
 	// Validate the day of the month.
-	if day < 1 || day > daysIn(time.Month(month), year) {
-		return 0, p.err(alayout, avalue, "day out of range")
+	if n := daysIn(time.Month(month), year); day < 1 || day > n {
+		if warn == nil || yday >= 0 {
+			return 0, p.fieldErr(alayout, avalue, ErrRange, "day out of range", dayOff, day)
+		}
+		*warn = append(*warn, ParseWarning{DayClamped, strings.Clone(dayText)})
+		day = min(max(day, 1), n)
+	}
+	d := Of(year, time.Month(month), day)
+	if wday >= 0 && (warn != nil || ps.CheckWeekday) && d.Weekday() != time.Weekday(wday) {
+		if warn != nil {
+			*warn = append(*warn, ParseWarning{WeekdayMismatch, strings.Clone(wdayText)})
+		} else {
+			return 0, p.fieldErr(alayout, avalue, ErrMismatch, "day of the week does not match date", wdayOff, wday)
+		}
+	}
+	if err := ps.checkWithin(d, avalue); err != nil {
+		return 0, err
+	}
+	return d, nil
+}
+
+// holoceneOffset is the number of years the Holocene calendar is ahead of the
+// Gregorian calendar.
+const holoceneOffset = 10000
+
+// ordinalSuffix returns the English ordinal suffix for the day of the month
+// day, like "st" for 1 or "th" for 11.
+func ordinalSuffix(day int) string {
+	if day/10 == 1 {
+		return "th"
+	}
+	switch day % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
 	}
-	return Of(year, time.Month(month), day), nil
+	return "th"
+}
+
+// appendFast appends d using one of the layouts with a fast path, [ISOBasic]
+// and [RFC3339]. It reports false, if layout has no fast path or the year of d
+// does not have exactly four digits, in which case the general implementation
+// has to be used.
+func (d Date) appendFast(b []byte, layout string) ([]byte, bool) {
+	var sep byte
+	switch layout {
+	case ISOBasic:
+	case RFC3339:
+		sep = '-'
+	default:
+		return b, false
+	}
+	year, month, day, _ := absDate(d.abs(), true)
+	if year < 0 || year > 9999 {
+		return b, false
+	}
+	return appendISO(b, year, month, day, sep), true
+}
+
+// appendISO appends the date in ISOBasic layout to b, if sep is 0, or in
+// RFC3339 layout with the given separator. year must be in the range 0…9999.
+func appendISO(b []byte, year int, month time.Month, day int, sep byte) []byte {
+	b = append(b,
+		byte('0'+year/1000),
+		byte('0'+year/100%10),
+		byte('0'+year/10%10),
+		byte('0'+year%10),
+	)
+	if sep == 0 {
+		return append(b, byte('0'+month/10), byte('0'+month%10), byte('0'+day/10), byte('0'+day%10))
+	}
+	return append(b, sep, byte('0'+month/10), byte('0'+month%10), sep, byte('0'+day/10), byte('0'+day%10))
+}
+
+// parseFast parses s using one of the layouts with a fast path, [ISOBasic]
+// and [RFC3339]. It reports false, if layout has no fast path or s is not
+// valid, in which case the general parser has to be used.
+func parseFast[S ~string | ~[]byte](layout string, s S) (Date, bool) {
+	switch layout {
+	case ISOBasic:
+		return parseISO(s, 0)
+	case RFC3339:
+		return parseISO(s, '-')
+	}
+	return 0, false
+}
+
+// parseISO parses s in ISOBasic layout, if sep is 0, or in RFC3339 layout
+// with the given separator. It reports whether s is valid.
+func parseISO[S ~string | ~[]byte](s S, sep byte) (Date, bool) {
+	var buf [8]byte
+	switch {
+	case sep == 0 && len(s) == 8:
+		copy(buf[:], s)
+	case sep != 0 && len(s) == 10 && s[4] == sep && s[7] == sep:
+		copy(buf[:4], s[:4])
+		copy(buf[4:6], s[5:7])
+		copy(buf[6:], s[8:])
+	default:
+		return 0, false
+	}
+	year, month, day, ok := swarDigits(binary.LittleEndian.Uint64(buf[:]))
+	if !ok || month < time.January || month > time.December || day < 1 || day > daysIn(month, year) {
+		return 0, false
+	}
+	return Of(year, month, day), true
+}
+
+// swarDigits interprets the eight bytes of v, in little-endian order, as the
+// ASCII digits YYYYMMDD. It reports whether all bytes are digits.
+//
+// All bytes are validated and converted at once, by treating v as a vector of
+// bytes ("SIMD within a register").
+func swarDigits(v uint64) (year int, month time.Month, day int, ok bool) {
+	const (
+		ones  = 0x0101010101010101
+		high  = 0xf0 * ones
+		zeros = '0' * ones
+	)
+	// A byte c is a digit, if the high nibble of both c and c+6 is 3. If c+6
+	// carries into the next byte, the high nibble of c is not 3.
+	if v&high|(v+6*ones)&high>>4 != 0x33*ones {
+		return 0, 0, 0, false
+	}
+	v -= zeros
+	// Combine pairs of digits into 16-bit lanes, the first digit being the
+	// least significant byte.
+	v = (v*10 + v>>8) & 0x00ff00ff00ff00ff
+	year = int(v&0xffff)*100 + int(v>>16&0xffff)
+	month = time.Month(v >> 32 & 0xffff)
+	day = int(v >> 48)
+	return year, month, day, true
 }
 
 // match reports whether s1 and s2 match ignoring case.
@@ -472,6 +1131,10 @@ func match(s1, s2 string) bool {
 	return true
 }
 
+func isASCIISpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
 func isDigit(s string, i int) bool {
 	if len(s) <= i {
 		return false
@@ -485,11 +1148,13 @@ type parser struct {
 	value  string
 	valEl  string
 	errMsg string
+	spaces SpaceMode
 }
 
-func newParser(value string) *parser {
+func newParser(value string, spaces SpaceMode) *parser {
 	return &parser{
-		value: value,
+		value:  value,
+		spaces: spaces,
 	}
 }
 
@@ -506,6 +1171,12 @@ func (p *parser) finish() {
 	p.valEl = ""
 }
 
+// consumed returns the part of the value consumed by the current
+// instruction so far.
+func (p *parser) consumed() string {
+	return p.valEl[:len(p.valEl)-len(p.value)]
+}
+
 // parseFailed signals that the parse has failed at the current instruction.
 func (p *parser) parseFailed() {
 	p.hasErr = true
@@ -518,7 +1189,10 @@ func (p *parser) invalidDate(msg string) {
 	p.errMsg = msg
 }
 
-func (p *parser) err(layout, value, msg string) error {
+// err returns a ParseError for the current instruction. kind classifies the
+// error, as documented for ParseError.Err. If msg is empty, the error reports
+// the layout and value elements instead.
+func (p *parser) err(layout, value string, kind error, msg string) *ParseError {
 	// We call strings.Clone in this function to prevent Parse from allocating
 	// in the happy path. As parts of the input appear in the error message,
 	// the compiler has to mark the value argument to Parse as potentially
@@ -537,15 +1211,27 @@ func (p *parser) err(layout, value, msg string) error {
 			Value:      v,
 			LayoutElem: le,
 			ValueElem:  ve,
+			Offset:     len(value) - len(p.valEl),
+			Err:        kind,
 		}
 	}
 	return &ParseError{
 		Layout:  layout,
 		Value:   v,
 		Message: msg,
+		Offset:  len(value) - len(p.valEl),
+		Err:     kind,
 	}
 }
 
+// fieldErr is like err, but for a field with the value n at offset off into
+// value.
+func (p *parser) fieldErr(layout, value string, kind error, msg string, off, n int) *ParseError {
+	e := p.err(layout, value, kind, msg)
+	e.Offset, e.Number = off, n
+	return e
+}
+
 // skipByte skips the given byte, if the input starts with it.
 func (p *parser) skipByte(b byte) {
 	if len(p.value) > 0 && p.value[0] == b {
@@ -560,10 +1246,17 @@ func (p *parser) trimByte(b byte) {
 	}
 }
 
-// accept a literal string, treating runs of space characters as equivalent.
+// accept a literal string, matching spaces according to p.spaces.
 func (p *parser) accept(lit string) {
 	for len(lit) > 0 {
-		if lit[0] == ' ' {
+		if lit[0] == ' ' && p.spaces == LooseSpaces {
+			for len(p.value) > 0 && isASCIISpace(p.value[0]) {
+				p.value = p.value[1:]
+			}
+			lit = strings.TrimLeft(lit, " ")
+			continue
+		}
+		if lit[0] == ' ' && p.spaces == FoldSpaces {
 			if p.value != "" && p.value[0] != ' ' {
 				p.parseFailed()
 				return
@@ -628,18 +1321,82 @@ func (p *parser) peekDigit() {
 	}
 }
 
-// lookup a value from a table and accept a case-insensitive match.
-func (p *parser) lookup(table []string) int {
-	for i, v := range table {
-		if len(p.value) >= len(v) && match(p.value[0:len(v)], v) {
-			p.value = p.value[len(v):]
-			return i
+// lookup a value from tables, which are indexed the same way, and accept a
+// case-insensitive match. If multiple values match, the longest one is used.
+func (p *parser) lookup(tables ...[]string) int {
+	idx, n := p.longestMatch(tables...)
+	if idx < 0 {
+		p.parseFailed()
+		return 0
+	}
+	p.value = p.value[n:]
+	return idx
+}
+
+// longestMatch returns the index and length of the longest entry of tables
+// which p.value starts with. If there is none, idx is -1.
+func (p *parser) longestMatch(tables ...[]string) (idx, n int) {
+	idx = -1
+	if p.value == "" {
+		return idx, n
+	}
+	c := p.value[0]
+	for _, table := range tables {
+		for i, v := range table {
+			if len(v) <= n || len(p.value) < len(v) {
+				continue
+			}
+			// Skip entries with a different first letter, without calling
+			// foldMatch.
+			if c != v[0] && c|v[0] < utf8.RuneSelf && c|('a'-'A') != v[0]|('a'-'A') {
+				continue
+			}
+			if foldMatch(p.value[:len(v)], v) {
+				idx, n = i, len(v)
+			}
 		}
 	}
-	p.parseFailed()
-	return 0
+	return idx, n
 }
 
+// foldMatch reports whether s1 and s2 match under Unicode case folding. It is
+// assumed s1 and s2 are the same length.
+func foldMatch(s1, s2 string) bool {
+	for i := 0; i < len(s1); i++ {
+		c1, c2 := s1[i], s2[i]
+		if c1 == c2 {
+			continue
+		}
+		if c1|c2 >= utf8.RuneSelf {
+			// Only compare Unicode case folding if necessary, as it is slow.
+			return strings.EqualFold(s1, s2)
+		}
+		c1 |= 'a' - 'A'
+		c2 |= 'a' - 'A'
+		if c1 != c2 || c1 < 'a' || c1 > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Errors classifying a [ParseError], which can be checked with [errors.Is].
+var (
+	// ErrSyntax means the value does not match an element of the layout.
+	ErrSyntax = errors.New("value does not match layout")
+	// ErrRange means a field of the value, like the month, is out of range
+	// or the date is outside of [Parser.Within].
+	ErrRange = errors.New("value out of range")
+	// ErrExtraText means the value contains text after the layout has been
+	// matched.
+	ErrExtraText = errors.New("extra text after date")
+	// ErrMismatch means fields of the value contradict each other, like a
+	// day of the week not matching the date.
+	ErrMismatch = errors.New("fields do not match")
+	// ErrLayout means the layout can not be used for parsing.
+	ErrLayout = errors.New("layout can not be parsed")
+)
+
 // ParseError describes a problem parsing a date string.
 type ParseError struct {
 	Layout     string
@@ -647,6 +1404,21 @@ type ParseError struct {
 	LayoutElem string
 	ValueElem  string
 	Message    string
+
+	// Offset is the byte offset into Value at which the problem was
+	// detected, that is the start of ValueElem, of the field failing
+	// validation or of the extra text.
+	Offset int
+	// Number is the value of the field failing validation, if Err is
+	// ErrRange or ErrMismatch and the field is an element of the layout.
+	// It is zero otherwise.
+	Number int
+	// Err classifies the problem. It is one of ErrSyntax, ErrRange,
+	// ErrExtraText, ErrMismatch and ErrLayout or, if a custom layout element
+	// fails, the error returned by its Parse function. For timestamps
+	// rejected by ParseTruncate, it wraps ErrSyntax and the error returned
+	// by package time.
+	Err error
 }
 
 // Error returns the string representation of a ParseError.
@@ -656,3 +1428,8 @@ func (e *ParseError) Error() string {
 	}
 	return fmt.Sprintf("parsing date %q: %s", e.Value, e.Message)
 }
+
+// Unwrap returns e.Err.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -6,6 +6,8 @@
 package date
 
 import (
+	"encoding/binary"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -18,6 +20,12 @@ var layouts = []string{
 	RFC822,
 	RFC1123,
 	RFC3339,
+	ISOBasic,
+	US,
+	UKSlash,
+	EuropeanDotted,
+	LongDate,
+	LongOrdinal,
 }
 
 // FuzzParseLayout generates layouts to check that [parseLayout] does not
@@ -47,6 +55,7 @@ func FuzzParseLayout(f *testing.F) {
 	}
 	f.Fuzz(func(t *testing.T, s string) {
 		parseLayout(s)
+		parseLayoutExtended(s)
 	})
 }
 
@@ -293,6 +302,304 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParser(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		p      Parser
+		layout string
+		value  string
+		want   Date
+		ok     bool
+	}{
+		{Parser{}, RFC3339, " 2024-05-14", 0, false},
+		{Parser{}, RFC3339, "2024-05-14  ", 0, false},
+		{Parser{TrimSpace: true}, RFC3339, " 2024-05-14\t ", Of(2024, 5, 14), true},
+		{Parser{TrimSpace: true}, ISOBasic, "20240514   ", Of(2024, 5, 14), true},
+		{Parser{TrimSpace: true}, ISOBasic, "2024 0514", 0, false},
+		{Parser{}, RFC1123, "14   May 2024", Of(2024, 5, 14), true},
+		{Parser{}, RFC1123, "14May 2024", 0, false},
+		{Parser{}, RFC1123, "14\tMay 2024", 0, false},
+		{Parser{Spaces: ExactSpaces}, RFC1123, "14 May 2024", Of(2024, 5, 14), true},
+		{Parser{Spaces: ExactSpaces}, RFC1123, "14  May 2024", 0, false},
+		{Parser{Spaces: ExactSpaces}, "02  Jan", "14  May", Of(0, 5, 14), true},
+		{Parser{Spaces: ExactSpaces}, "02  Jan", "14 May", 0, false},
+		{Parser{Spaces: LooseSpaces}, RFC1123, "14\t May  2024", Of(2024, 5, 14), true},
+		{Parser{Spaces: LooseSpaces}, RFC1123, "14May2024", Of(2024, 5, 14), true},
+		{Parser{Spaces: LooseSpaces}, RFC1123, "14-May 2024", 0, false},
+		{Parser{}, "Mon 2006-01-02", "Wed 2024-05-14", Of(2024, 5, 14), true},
+		{Parser{CheckWeekday: true}, "Mon 2006-01-02", "Tue 2024-05-14", Of(2024, 5, 14), true},
+		{Parser{CheckWeekday: true}, "Mon 2006-01-02", "Wed 2024-05-14", 0, false},
+		{Parser{CheckWeekday: true}, "2006-01-02", "2024-05-14", Of(2024, 5, 14), true},
+		{Parser{}, "02.01.06", "14.05.68", Of(2068, 5, 14), true},
+		{Parser{}, "02.01.06", "14.05.69", Of(1969, 5, 14), true},
+		{Parser{PivotYear: 1950}, "02.01.06", "14.05.49", Of(2049, 5, 14), true},
+		{Parser{PivotYear: 1950}, "02.01.06", "14.05.50", Of(1950, 5, 14), true},
+		{Parser{RejectTwoDigitYears: true}, "02.01.06", "14.05.24", 0, false},
+		{Parser{RejectTwoDigitYears: true}, "02.01.2006", "14.05.2024", Of(2024, 5, 14), true},
+		{Parser{Within: YearRange(2024)}, RFC3339, "2024-05-14", Of(2024, 5, 14), true},
+		{Parser{Within: YearRange(2024)}, RFC3339, "2025-05-14", 0, false},
+		{Parser{Within: YearRange(2024)}, RFC1123, "14 May 2023", 0, false},
+	}
+	for _, tc := range tcs {
+		got, err := tc.p.Parse(tc.layout, tc.value)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("%+v.Parse(%q, %q) = %v, %v, want %v, %v", tc.p, tc.layout, tc.value, got, err, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestParseErrorDetails(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		layout string
+		value  string
+		kind   error
+		offset int
+		number int
+	}{
+		{RFC3339, "2024-13-01", ErrRange, 5, 13},
+		{"Jan 2, 2006", "Feb 30, 2024", ErrRange, 4, 30},
+		{"2006-002", "2023-366", ErrRange, 5, 366},
+		{RFC3339, "2024/05/14", ErrSyntax, 4, 0},
+		{"Jan 2 2006", "May 14 24", ErrSyntax, 7, 0},
+		{RFC3339, "2024-05-14 and more", ErrExtraText, 10, 0},
+		{"2006-01-02 002", "2024-05-14 100", ErrMismatch, 11, 100},
+	}
+	for _, tc := range tcs {
+		_, err := Parse(tc.layout, tc.value)
+		if !errors.Is(err, tc.kind) {
+			t.Errorf("Parse(%q, %q) = _, %v, want %v", tc.layout, tc.value, err, tc.kind)
+			continue
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Offset != tc.offset || pe.Number != tc.number {
+			t.Errorf("Parse(%q, %q) = _, %#v, want Offset %d and Number %d", tc.layout, tc.value, pe, tc.offset, tc.number)
+		}
+	}
+
+	ps := Parser{CheckWeekday: true}
+	if _, err := ps.Parse("Mon 2006-01-02", "Mon 2024-05-14"); !errors.Is(err, ErrMismatch) {
+		t.Errorf("%+v.Parse(%q, %q) = _, %v, want %v", ps, "Mon 2006-01-02", "Mon 2024-05-14", err, ErrMismatch)
+	}
+
+	// Other parsing functions classify their errors as well.
+	others := []struct {
+		call string
+		err  error
+		kind error
+	}{
+		{`ParseYearQuarter("2024-Q5")`, second(ParseYearQuarter("2024-Q5")), ErrRange},
+		{`ParseYearQuarter("Q3/2024")`, second(ParseYearQuarter("Q3/2024")), ErrSyntax},
+		{`ParseISOWeek("2024-W54-1")`, second(ParseISOWeek("2024-W54-1")), ErrRange},
+		{`ParseISOWeek("2024W21")`, second(ParseISOWeek("2024W21")), ErrSyntax},
+		{`ParseISOWeekBasic("2024-W21-3")`, second(ParseISOWeekBasic("2024-W21-3")), ErrSyntax},
+		{`ParsePeriod("P1H")`, second(ParsePeriod("P1H")), ErrSyntax},
+		{`ParsePeriod("P99999999999999999999D")`, second(ParsePeriod("P99999999999999999999D")), ErrRange},
+		{`parseInterval("2024-05-01")`, second(parseInterval("2024-05-01")), ErrSyntax},
+		{`ParseVCard("--13")`, second(ParseVCard("--13")), ErrRange},
+		{`ParseVCard("2024-05-")`, second(ParseVCard("2024-05-")), ErrSyntax},
+		{`ParseAny("yesterday")`, second(ParseAny("yesterday")), ErrSyntax},
+		{`ParseTruncate("2024-05-14 22:30", time.UTC)`, second(ParseTruncate("2024-05-14 22:30", time.UTC)), ErrSyntax},
+	}
+	for _, tc := range others {
+		if !errors.Is(tc.err, tc.kind) {
+			t.Errorf("%s = _, %v, want %v", tc.call, tc.err, tc.kind)
+		}
+	}
+}
+
+// second returns its second argument.
+func second[T any](_ T, err error) error {
+	return err
+}
+
+func TestParseTruncate(t *testing.T) {
+	t.Parallel()
+	berlin := time.FixedZone("CEST", 2*60*60)
+	tcs := []struct {
+		value string
+		loc   *time.Location
+		want  Date
+		ok    bool
+	}{
+		{"2024-05-14", time.UTC, Of(2024, 5, 14), true},
+		{"2024-05-14", berlin, Of(2024, 5, 14), true},
+		{"2024-05-14T22:30:00Z", time.UTC, Of(2024, 5, 14), true},
+		{"2024-05-14T22:30:00Z", berlin, Of(2024, 5, 15), true},
+		{"2024-05-14T00:30:00.123+02:00", time.UTC, Of(2024, 5, 13), true},
+		{"2024-05-14T00:30:00.123+02:00", berlin, Of(2024, 5, 14), true},
+		{"2024-05-14T22:30:00", time.UTC, 0, false},
+		{"2024-05-14 22:30:00Z", time.UTC, 0, false},
+		{"2024-5-14", time.UTC, 0, false},
+		{"", time.UTC, 0, false},
+	}
+	for _, tc := range tcs {
+		got, err := ParseTruncate(tc.value, tc.loc)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("ParseTruncate(%q, %v) = %v, %v, want %v, %v", tc.value, tc.loc, got, err, tc.want, tc.ok)
+		}
+	}
+	var te *time.ParseError
+	if _, err := ParseTruncate("2024-05-14T25:30:00Z", time.UTC); !errors.As(err, &te) || !errors.Is(err, ErrSyntax) {
+		t.Errorf("ParseTruncate(%q, UTC) = _, %v, want error wrapping *time.ParseError and ErrSyntax", "2024-05-14T25:30:00Z", err)
+	}
+}
+
+func TestParseTimestampIn(t *testing.T) {
+	t.Parallel()
+	berlin := time.FixedZone("CEST", 2*60*60)
+	tcs := []struct {
+		layout string
+		value  string
+		loc    *time.Location
+		want   Date
+		ok     bool
+	}{
+		{time.RFC3339, "2024-05-14T22:30:00Z", time.UTC, Of(2024, 5, 14), true},
+		{time.RFC3339, "2024-05-14T22:30:00Z", berlin, Of(2024, 5, 15), true},
+		{time.RFC3339, "2024-05-14T00:30:00+02:00", time.UTC, Of(2024, 5, 13), true},
+		{time.DateTime, "2024-05-14 23:30:00", berlin, Of(2024, 5, 14), true},
+		{time.RFC1123, "Tue, 14 May 2024 23:30:00 UTC", berlin, Of(2024, 5, 15), true},
+		{time.Kitchen, "11:30PM", berlin, Of(0, 1, 1), true},
+		{time.RFC3339, "2024-05-14", time.UTC, 0, false},
+	}
+	for _, tc := range tcs {
+		got, err := ParseTimestampIn(tc.layout, tc.value, tc.loc)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("ParseTimestampIn(%q, %q, %v) = %v, %v, want %v, %v", tc.layout, tc.value, tc.loc, got, err, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestOrdinalDay(t *testing.T) {
+	t.Parallel()
+	for day, want := range map[int]string{
+		1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 10: "10th", 11: "11th", 12: "12th",
+		13: "13th", 14: "14th", 21: "21st", 22: "22nd", 23: "23rd", 30: "30th", 31: "31st",
+	} {
+		d := Of(2024, 1, day)
+		if got := NewFormatter("2nd").SetExtended(true).Format(d); got != want {
+			t.Errorf("NewFormatter(%q).SetExtended(true).Format(%v) = %q, want %q", "2nd", d, got, want)
+		}
+	}
+	if got, want := NewFormatter(LongOrdinal).SetExtended(true).Format(Of(2024, 5, 14)), "May 14th, 2024"; got != want {
+		t.Errorf("NewFormatter(LongOrdinal).SetExtended(true).Format() = %q, want %q", got, want)
+	}
+	// Without extended elements, "nd" is a literal, as for package time.
+	if got, want := Of(2024, 5, 14).Format(LongOrdinal), "May 14nd, 2024"; got != want {
+		t.Errorf("Format(LongOrdinal) = %q, want %q", got, want)
+	}
+	if got, want := Of(2024, 5, 14).Format(LongDate), "May 14, 2024"; got != want {
+		t.Errorf("Format(LongDate) = %q, want %q", got, want)
+	}
+	tcs := []struct {
+		layout string
+		value  string
+		want   Date
+		ok     bool
+	}{
+		{LongDate, "May 14, 2024", Of(2024, 5, 14), true},
+		{LongDate, "may 2, 2024", Of(2024, 5, 2), true},
+		{LongDate, "May 14th, 2024", 0, false},
+		{LongOrdinal, "May 14th, 2024", Of(2024, 5, 14), true},
+		{LongOrdinal, "May 14TH, 2024", Of(2024, 5, 14), true},
+		{LongOrdinal, "May 14, 2024", Of(2024, 5, 14), true},
+		{LongOrdinal, "March 1st, 2024", Of(2024, 3, 1), true},
+		{LongOrdinal, "March 22nd, 2024", Of(2024, 3, 22), true},
+		{LongOrdinal, "March 23rd, 2024", Of(2024, 3, 23), true},
+		{LongOrdinal, "March 11th, 2024", Of(2024, 3, 11), true},
+		{LongOrdinal, "March 11st, 2024", 0, false},
+		{LongOrdinal, "March 2th, 2024", 0, false},
+		{LongOrdinal, "February 30th, 2024", 0, false},
+		{"2nd", "2", Of(0, 1, 2), true},
+		{"2ndx", "2ndx", Of(0, 1, 2), true},
+	}
+	for _, tc := range tcs {
+		got, err := Parser{Extended: true}.Parse(tc.layout, tc.value)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("Parser{Extended: true}.Parse(%q, %q) = %v, %v, want %v, %v", tc.layout, tc.value, got, err, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestSkip(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		layout string
+		value  string
+		want   Date
+		ok     bool
+	}{
+		{"2006-01-02…", "2024-05-14T08:30:00+02:00 something", Of(2024, 5, 14), true},
+		{"2006-01-02…", "2024-05-14", Of(2024, 5, 14), true},
+		{"…[2006-01-02]…", "INFO [2024-05-14] started", Of(2024, 5, 14), true},
+		{"…: Jan 2, 2006", "Due date: May 14, 2024", Of(2024, 5, 14), true},
+		{"… 2 …, 2006", "Tuesday 14 of May, 2024", Of(2024, 1, 14), true},
+		{"…[2006-01-02]", "INFO 2024-05-14", 0, false},
+		{"…2006-01-02", "on 2024-05-14", 0, false},
+		{"2006-01-02…x", "2024-05-14 y", 0, false},
+	}
+	for _, tc := range tcs {
+		got, err := Parser{Extended: true}.Parse(tc.layout, tc.value)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("Parser{Extended: true}.Parse(%q, %q) = %v, %v, want %v, %v", tc.layout, tc.value, got, err, tc.want, tc.ok)
+		}
+	}
+	if got, want := NewFormatter("…[2006-01-02]…").SetExtended(true).Format(Of(2024, 5, 14)), "[2024-05-14]"; got != want {
+		t.Errorf("NewFormatter(%q).SetExtended(true).Format() = %q, want %q", "…[2006-01-02]…", got, want)
+	}
+	if got, err := (Parser{TimeCompat: true}).Parse("2006-01-02…", "2024-05-14…"); err != nil || got != Of(2024, 5, 14) {
+		t.Errorf("Parser{TimeCompat: true}.Parse(%q, %q) = %v, %v, want %v, <nil>", "2006-01-02…", "2024-05-14…", got, err, Of(2024, 5, 14))
+	}
+}
+
+func TestHoloceneYear(t *testing.T) {
+	t.Parallel()
+	formats := []struct {
+		d      Date
+		layout string
+		want   string
+	}{
+		{Of(2024, 5, 22), "12006-01-02", "12024-05-22"},
+		{Of(2024, 5, 22), "2 January 12006 HE (2006 CE)", "22 May 12024 HE (2024 CE)"},
+		{Of(-9999, 1, 1), "12006", "00001"},
+		{Of(-10000, 1, 1), "12006", "00000"},
+		{Of(-10001, 1, 1), "12006", "-00001"},
+		{Of(-12345, 1, 1), "12006", "-02345"},
+		{Of(90000, 1, 1), "12006", "100000"},
+	}
+	for _, tc := range formats {
+		f := NewFormatter(tc.layout).SetExtended(true)
+		if got := f.Format(tc.d); got != tc.want {
+			t.Errorf("NewFormatter(%q).SetExtended(true).Format(%v) = %q, want %q", tc.layout, tc.d, got, tc.want)
+		}
+		if got := f.Func()(tc.d); got != tc.want {
+			t.Errorf("NewFormatter(%q).SetExtended(true).Func()(%v) = %q, want %q", tc.layout, tc.d, got, tc.want)
+		}
+	}
+
+	parses := []struct {
+		layout string
+		value  string
+		want   Date
+		ok     bool
+	}{
+		{"12006-01-02", "12024-05-22", Of(2024, 5, 22), true},
+		{"12006", "00000", Of(-10000, 1, 1), true},
+		{"12006", "99999", Of(89999, 1, 1), true},
+		{"12006", "2024", 0, false},
+		{"12006", "+2024", 0, false},
+		{"12006", "-2024", 0, false},
+		{"1/2006", "5/2024", Of(2024, 5, 1), true},
+	}
+	for _, tc := range parses {
+		got, err := Parser{Extended: true}.Parse(tc.layout, tc.value)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("Parser{Extended: true}.Parse(%q, %q) = %v, %v, want %v, %v", tc.layout, tc.value, got, err, tc.want, tc.ok)
+		}
+	}
+}
+
 // TestParseZeroAllocs checks that calling Parse does not escape its argument
 // and does not allocate, in the happy path.
 func TestParseZeroAllocs(t *testing.T) {
@@ -306,6 +613,35 @@ func TestParseZeroAllocs(t *testing.T) {
 	}
 }
 
+// longLayout is a layout too long for the buffer Format allocates on the
+// stack.
+const longLayout = "Monday, January 2, 2006 (day 002 of the year), Mon Jan 2 06, 2006-01-02"
+
+func TestFormatAllocs(t *testing.T) {
+	d := Of(2024, 5, 14)
+	for _, layout := range []string{RFC1123, longLayout} {
+		d.Format(layout) // compile the layout and fill the pool
+		// Only the returned string is allocated.
+		if got := testing.AllocsPerRun(1000, func() { d.Format(layout) }); got != 1 {
+			t.Errorf("Format(%q) allocates %v times, want 1", layout, got)
+		}
+	}
+}
+
+// BenchmarkFormat benchmarks Format for layouts fitting into the buffer on the
+// stack and for longer layouts, using pooled buffers.
+func BenchmarkFormat(b *testing.B) {
+	d := Of(2024, 5, 14)
+	for _, tc := range []struct{ name, layout string }{{"Short", RFC1123}, {"Long", longLayout}} {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = (d + Date(i%1000)).Format(tc.layout)
+			}
+		})
+	}
+}
+
 // BenchmarkParseHappy benchmarks (and counts allocations) of Parse in the
 // happy path.
 func BenchmarkParseHappy(b *testing.B) {
@@ -315,6 +651,216 @@ func BenchmarkParseHappy(b *testing.B) {
 	}
 }
 
+// FuzzISOBasic compares the fast path for ISOBasic to package time.
+func FuzzISOBasic(f *testing.F) {
+	f.Add("20240514", int(Of(2024, 5, 14)))
+	f.Add("20230229", int(Of(-2023, 2, 28)))
+	f.Add("+0240514", int(Of(12024, 5, 14)))
+	f.Fuzz(func(t *testing.T, value string, date int) {
+		d, errD := Parse(ISOBasic, value)
+		T, errT := time.Parse(ISOBasic, value)
+		if (errD == nil) != (errT == nil) {
+			t.Fatalf("Parse(ISOBasic, %q) returned different error from time.Parse: got %v, want %v", value, errD, errT)
+		}
+		if td := Of(T.Date()); errD == nil && d != td {
+			t.Fatalf("Parse(ISOBasic, %q) returned different date than time.Parse: got %#v, want %#v", value, d, td)
+		}
+		if date < 0 || date > 1e7 {
+			return
+		}
+		d = Date(date)
+		got, want := d.Format(ISOBasic), d.Time(8, 0, 0, 0, time.UTC).Format(ISOBasic)
+		if got != want {
+			t.Fatalf("%#v.Format(ISOBasic) = %q, want %q", d, got, want)
+		}
+	})
+}
+
+// FuzzParseFast checks that the fast paths of Parse agree with the general
+// implementation.
+func FuzzParseFast(f *testing.F) {
+	f.Add("2024-05-14")
+	f.Add("2024-02-30")
+	f.Add("20240514")
+	f.Add("2024-5-14")
+	f.Fuzz(func(t *testing.T, value string) {
+		for _, layout := range []string{ISOBasic, RFC3339} {
+			d, ok := parseFast(layout, value)
+			if !ok {
+				continue
+			}
+			want, err := parse(layout, value, FoldSpaces)
+			if err != nil || d != want {
+				t.Fatalf("parseFast(%q, %q) = %v, true, but parse returns %v, %v", layout, value, d, want, err)
+			}
+			if db, _ := parseFast(layout, []byte(value)); db != d {
+				t.Fatalf("parseFast(%q, []byte(%q)) = %v, want %v", layout, value, db, d)
+			}
+		}
+	})
+}
+
+// TestISOBasicZeroAllocs checks that parsing and appending in ISOBasic layout
+// does not allocate.
+func TestISOBasicZeroAllocs(t *testing.T) {
+	b := make([]byte, 0, 8)
+	d := Of(2024, 5, 14)
+	if got := testing.AllocsPerRun(1000, func() { b = d.AppendFormat(b[:0], ISOBasic) }); got != 0 {
+		t.Errorf("AppendFormat(_, ISOBasic) allocates %v times, want 0", got)
+	}
+	if got := testing.AllocsPerRun(1000, func() { Parse(ISOBasic, string(b)) }); got != 0 {
+		t.Errorf("Parse(ISOBasic, _) allocates %v times, want 0", got)
+	}
+}
+
+// BenchmarkISOBasic benchmarks the fast path for ISOBasic against the general
+// implementation.
+func BenchmarkISOBasic(b *testing.B) {
+	const value = "20240514"
+	d := Of(2024, 5, 14)
+	b.Run("Parse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Parse(ISOBasic, value)
+		}
+	})
+	b.Run("ParseInterpreted", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			parse(ISOBasic, value, FoldSpaces)
+		}
+	})
+	b.Run("AppendFormat", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 8)
+		for i := 0; i < b.N; i++ {
+			buf = d.AppendFormat(buf[:0], ISOBasic)
+		}
+	})
+	b.Run("AppendFormatInterpreted", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 8)
+		for i := 0; i < b.N; i++ {
+			buf = d.appendFormat(buf[:0], ISOBasic)
+		}
+	})
+}
+
+// FuzzSWARDigits compares swarDigits to parsing the digits one by one.
+func FuzzSWARDigits(f *testing.F) {
+	f.Add([]byte("20240514"))
+	f.Add([]byte("2024051/"))
+	f.Add([]byte(":0240514"))
+	f.Add([]byte("\xff9999999"))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		if len(b) < 8 {
+			return
+		}
+		var v [8]int
+		want := true
+		for i, c := range b[:8] {
+			if c < '0' || c > '9' {
+				want = false
+			}
+			v[i] = int(c - '0')
+		}
+		year, month, day, ok := swarDigits(binary.LittleEndian.Uint64(b))
+		if ok != want {
+			t.Fatalf("swarDigits(%q) = _, _, _, %v, want %v", b[:8], ok, want)
+		}
+		if !ok {
+			return
+		}
+		wy, wm, wd := v[0]*1000+v[1]*100+v[2]*10+v[3], time.Month(v[4]*10+v[5]), v[6]*10+v[7]
+		if year != wy || month != wm || day != wd {
+			t.Fatalf("swarDigits(%q) = %d, %d, %d, want %d, %d, %d", b[:8], year, month, day, wy, wm, wd)
+		}
+	})
+}
+
+// FuzzAppendFast checks that the fast paths of AppendFormat agree with the
+// general implementation.
+func FuzzAppendFast(f *testing.F) {
+	f.Add(int64(Of(2024, 5, 14)))
+	f.Add(int64(Of(0, 1, 1)))
+	f.Add(int64(Of(9999, 12, 31)))
+	f.Add(int64(Of(10000, 1, 1)))
+	f.Add(int64(-1))
+	f.Fuzz(func(t *testing.T, v int64) {
+		d := Date(v)
+		if !d.IsValid() {
+			return
+		}
+		for _, layout := range []string{ISOBasic, RFC3339} {
+			got, ok := d.appendFast(nil, layout)
+			if !ok {
+				continue
+			}
+			if want := d.appendFormat(nil, layout); string(got) != string(want) {
+				t.Fatalf("%#v.appendFast(nil, %q) = %q, want %q", d, layout, got, want)
+			}
+		}
+	})
+}
+
+// TestRFC3339ZeroAllocs checks that parsing and appending in RFC3339 layout,
+// including through the encoding interfaces, does not allocate.
+func TestRFC3339ZeroAllocs(t *testing.T) {
+	b := make([]byte, 0, 10)
+	d := Of(2024, 5, 14)
+	if got := testing.AllocsPerRun(1000, func() { b = d.AppendFormat(b[:0], RFC3339) }); got != 0 {
+		t.Errorf("AppendFormat(_, RFC3339) allocates %v times, want 0", got)
+	}
+	if got := testing.AllocsPerRun(1000, func() { Parse(RFC3339, string(b)) }); got != 0 {
+		t.Errorf("Parse(RFC3339, _) allocates %v times, want 0", got)
+	}
+	if got := testing.AllocsPerRun(1000, func() { b, _ = d.AppendText(b[:0]) }); got != 0 {
+		t.Errorf("AppendText allocates %v times, want 0", got)
+	}
+	var v Date
+	if got := testing.AllocsPerRun(1000, func() { v.UnmarshalText(b) }); got != 0 || v != d {
+		t.Errorf("UnmarshalText allocates %v times and returns %v, want 0 and %v", got, v, d)
+	}
+	// Only the returned string is allocated.
+	var str string
+	if got := testing.AllocsPerRun(1000, func() { str = d.String() }); got != 1 || str != "2024-05-14" {
+		t.Errorf("String allocates %v times and returns %q, want 1 and %q", got, str, "2024-05-14")
+	}
+}
+
+// BenchmarkRFC3339 benchmarks the fast path for RFC3339 against the general
+// implementation.
+func BenchmarkRFC3339(b *testing.B) {
+	const value = "2024-05-14"
+	d := Of(2024, 5, 14)
+	b.Run("Parse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Parse(RFC3339, value)
+		}
+	})
+	b.Run("ParseInterpreted", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			parse(RFC3339, value, FoldSpaces)
+		}
+	})
+	b.Run("AppendFormat", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 10)
+		for i := 0; i < b.N; i++ {
+			buf = d.AppendFormat(buf[:0], RFC3339)
+		}
+	})
+	b.Run("AppendFormatInterpreted", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 10)
+		for i := 0; i < b.N; i++ {
+			buf = d.appendFormat(buf[:0], RFC3339)
+		}
+	})
+}
+
 func parseHappy() {
 	const layout = "Monday, 2006-01-02 002"
 	const value = "Thursday, 2023-11-02 306"
@@ -0,0 +1,112 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "time"
+
+// Formatted is a Date, which is marshaled as text using a custom layout,
+// instead of ISO 8601. As the layout is part of the value, it must be set
+// before unmarshaling:
+//
+//	type Invoice struct {
+//		Due date.Formatted `json:"due"`
+//	}
+//	inv := Invoice{Due: date.Formatted{Layout: date.EuropeanDotted}}
+//	err := json.Unmarshal(b, &inv)
+type Formatted struct {
+	Date Date
+
+	// Layout is the layout used for marshaling and unmarshaling. If it is
+	// empty, [RFC3339] is used.
+	Layout string
+}
+
+// String returns the date formatted using f.Layout.
+func (f Formatted) String() string {
+	return f.Date.Format(f.layout())
+}
+
+// IsZero reports whether f.Date is the zero Date, regardless of the layout.
+func (f Formatted) IsZero() bool {
+	return f.Date.IsZero()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The date is
+// formatted using f.Layout.
+func (f Formatted) MarshalText() ([]byte, error) {
+	return f.Date.AppendFormat(nil, f.layout()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The date is
+// parsed using f.Layout, which is left unchanged.
+func (f *Formatted) UnmarshalText(b []byte) error {
+	v, err := Parse(f.layout(), string(b))
+	if err == nil {
+		f.Date = v
+	}
+	return err
+}
+
+func (f Formatted) layout() string {
+	if f.Layout == "" {
+		return RFC3339
+	}
+	return f.Layout
+}
+
+// Truncated is a Date, which is unmarshaled from either a date in ISO 8601
+// format or an RFC 3339 timestamp, which is truncated to its date in
+// Location, as by [ParseTruncate]. It is marshaled as a date in ISO 8601
+// format. As the location is part of the value, it must be set before
+// unmarshaling:
+//
+//	type Event struct {
+//		Day date.Truncated `json:"day"`
+//	}
+//	ev := Event{Day: date.Truncated{Location: berlin}}
+//	err := json.Unmarshal(b, &ev)
+//
+// With encoding/json/v2, [JSONTruncateTimestamps] can be used instead.
+type Truncated struct {
+	Date Date
+
+	// Location is the location timestamps are converted to before
+	// truncating. If it is nil, UTC is used.
+	Location *time.Location
+}
+
+// String returns the date formatted as ISO 8601.
+func (t Truncated) String() string {
+	return t.Date.String()
+}
+
+// IsZero reports whether t.Date is the zero Date, regardless of the location.
+func (t Truncated) IsZero() bool {
+	return t.Date.IsZero()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The date is
+// formatted in ISO 8601 format.
+func (t Truncated) MarshalText() ([]byte, error) {
+	return t.Date.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The text is
+// parsed as by [ParseTruncate], using t.Location, which is left unchanged.
+func (t *Truncated) UnmarshalText(b []byte) error {
+	v, err := ParseTruncate(string(b), t.location())
+	if err == nil {
+		t.Date = v
+	}
+	return err
+}
+
+func (t Truncated) location() *time.Location {
+	if t.Location == nil {
+		return time.UTC
+	}
+	return t.Location
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFormatted(t *testing.T) {
+	t.Parallel()
+	type invoice struct {
+		Issued Formatted `json:"issued"`
+		Due    Formatted `json:"due"`
+	}
+	in := invoice{
+		Issued: Formatted{Date: Of(2024, 5, 14)},
+		Due:    Formatted{Date: Of(2024, 6, 13), Layout: EuropeanDotted},
+	}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"issued":"2024-05-14","due":"13.06.2024"}`; got != want {
+		t.Errorf("json.Marshal(%v) = %s, want %s", in, got, want)
+	}
+
+	out := invoice{Due: Formatted{Layout: EuropeanDotted}}
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", b, out, in)
+	}
+	if got, want := out.Due.String(), "13.06.2024"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	out = invoice{Due: Formatted{Layout: US}}
+	if err := json.Unmarshal(b, &out); err == nil {
+		t.Errorf("json.Unmarshal(%s) with layout %q succeeded", b, US)
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	t.Parallel()
+	berlin := time.FixedZone("CEST", 2*60*60)
+	type event struct {
+		Day Truncated `json:"day"`
+	}
+	tcs := []struct {
+		in   string
+		loc  *time.Location
+		want Date
+		err  bool
+	}{
+		{`{"day":"2024-05-14"}`, berlin, Of(2024, 5, 14), false},
+		{`{"day":"2024-05-14T22:30:00Z"}`, nil, Of(2024, 5, 14), false},
+		{`{"day":"2024-05-14T22:30:00Z"}`, berlin, Of(2024, 5, 15), false},
+		{`{"day":""}`, berlin, 0, false},
+		{`{"day":null}`, berlin, Of(2000, 1, 1), false},
+		{`{"day":"2024-05-14T22:30:00"}`, berlin, 0, true},
+		{`{"day":20240514}`, berlin, 0, true},
+	}
+	for _, tc := range tcs {
+		ev := event{Truncated{Of(2000, 1, 1), tc.loc}}
+		err := json.Unmarshal([]byte(tc.in), &ev)
+		if (err != nil) != tc.err {
+			t.Errorf("json.Unmarshal(%s) = %v, want error: %v", tc.in, err, tc.err)
+			continue
+		}
+		if err == nil && (ev.Day.Date != tc.want || ev.Day.Location != tc.loc) {
+			t.Errorf("json.Unmarshal(%s) = %v, want %v", tc.in, ev.Day, tc.want)
+		}
+	}
+
+	b, err := json.Marshal(event{Truncated{Of(2024, 5, 14), berlin}})
+	if got, want := string(b), `{"day":"2024-05-14"}`; err != nil || got != want {
+		t.Errorf("json.Marshal = %s, %v, want %s, <nil>", got, err, want)
+	}
+	var tr Truncated
+	if err := tr.UnmarshalText([]byte("2024-05-14T22:30:00Z")); err != nil || tr.Date != Of(2024, 5, 14) {
+		t.Errorf("UnmarshalText = %v, %v, want %v, <nil>", tr, err, Of(2024, 5, 14))
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A Formatter formats dates according to a fixed layout. It compiles the
+// layout once and reuses an internal buffer, so formatting a large number of
+// dates does not allocate.
+//
+// A Formatter is not safe for concurrent use.
+type Formatter struct {
+	layout   string
+	prog     []inst
+	nameCase NameCase
+	loc      *Locale
+	extended bool
+	compat   bool
+	buf      []byte
+}
+
+// NewFormatter returns a Formatter for the given layout. See the documentation
+// for the constant called Layout to see how to represent the layout format.
+func NewFormatter(layout string) *Formatter {
+	return &Formatter{
+		layout: layout,
+		prog:   parseLayout(layout),
+	}
+}
+
+// compile compiles the layout of f, recognizing the elements enabled by the
+// options of f.
+func (f *Formatter) compile() {
+	switch {
+	case f.compat:
+		f.prog = parseLayoutCompat(f.layout)
+	case f.extended:
+		f.prog = parseLayoutExtended(f.layout)
+	default:
+		f.prog = parseLayout(f.layout)
+	}
+}
+
+// SetNameCase sets the case used for the names of months and weekdays and
+// returns f. For example, for dates like "14-MAY-2024":
+//
+//	f := NewFormatter("02-Jan-2006").SetNameCase(UpperCase)
+func (f *Formatter) SetNameCase(c NameCase) *Formatter {
+	f.nameCase = c
+	return f
+}
+
+// SetLocale sets the locale providing the names of months and weekdays and
+// returns f, like for [Date.FormatIn]. If loc is nil, English names are used.
+func (f *Formatter) SetLocale(loc *Locale) *Formatter {
+	f.loc = loc
+	return f
+}
+
+// names returns the case to use for names.
+func (f *Formatter) names() NameCase {
+	if f.compat {
+		return KeepCase
+	}
+	return f.nameCase
+}
+
+// locale returns the locale to use for names.
+func (f *Formatter) locale() *Locale {
+	if f.compat || f.loc == nil {
+		return &english
+	}
+	return f.loc
+}
+
+// Layout returns the layout of f.
+func (f *Formatter) Layout() string {
+	return f.layout
+}
+
+// AppendDate appends the textual representation of d to b and returns the
+// extended buffer. Unless options are set, it is equivalent to
+// d.AppendFormat(b, f.Layout()).
+func (f *Formatter) AppendDate(b []byte, d Date) []byte {
+	if b, ok := d.appendFast(b, f.layout); ok {
+		return b
+	}
+	return d.appendProg(b, f.prog, f.locale(), f.names())
+}
+
+// WriteDate writes the textual representation of d to w. It returns the
+// number of bytes written and any error encountered.
+func (f *Formatter) WriteDate(w io.Writer, d Date) (int, error) {
+	f.buf = f.AppendDate(f.buf[:0], d)
+	return w.Write(f.buf)
+}
+
+// Format returns the textual representation of d. Unless options are set, it
+// is equivalent to d.Format(f.Layout()).
+func (f *Formatter) Format(d Date) string {
+	f.buf = f.AppendDate(f.buf[:0], d)
+	return string(f.buf)
+}
+
+// Func returns a function formatting dates according to the layout of f, like
+// f.Format. The returned function is safe for concurrent use.
+//
+// If the layout consists only of literals and the elements "2006", "01" and
+// "02", each at most once, like [RFC3339] or "02.01.2006", the returned
+// function is specialized for it: it copies the literals and writes the digits
+// to fixed positions, instead of interpreting the layout for every date, and
+// only allocates the returned string.
+func (f *Formatter) Func() func(Date) string {
+	prog, loc, nc := f.prog, f.locale(), f.names()
+	general := func(d Date) string {
+		var buf [64]byte
+		return string(d.appendProg(buf[:0], prog, loc, nc))
+	}
+	switch f.layout {
+	case RFC3339, ISOBasic:
+		sep := byte('-')
+		if f.layout == ISOBasic {
+			sep = 0
+		}
+		return func(d Date) string {
+			year, month, day, _ := absDate(d.abs(), true)
+			if year < 0 || year > 9999 {
+				return general(d)
+			}
+			var buf [10]byte
+			return string(appendISO(buf[:0], year, month, day, sep))
+		}
+	}
+	tmpl, yp, mp, dp, ok := compileFixed(prog)
+	if !ok {
+		return general
+	}
+	return func(d Date) string {
+		year, month, day, _ := absDate(d.abs(), true)
+		if year < 0 || year > 9999 {
+			return general(d)
+		}
+		var buf [64]byte
+		b := append(buf[:0], tmpl...)
+		b[yp], b[yp+1], b[yp+2], b[yp+3] = byte('0'+year/1000), byte('0'+year/100%10), byte('0'+year/10%10), byte('0'+year%10)
+		b[mp], b[mp+1] = byte('0'+month/10), byte('0'+month%10)
+		b[dp], b[dp+1] = byte('0'+day/10), byte('0'+day%10)
+		return string(b)
+	}
+}
+
+// compileFixed compiles prog into a template containing its literals and the
+// positions of the elements "2006", "01" and "02". It reports false, if prog
+// contains other elements, does not contain each of them exactly once or the
+// result would not fit into 64 bytes.
+func compileFixed(prog []inst) (tmpl []byte, yp, mp, dp int, ok bool) {
+	yp, mp, dp = -1, -1, -1
+	for _, i := range prog {
+		var pos *int
+		switch i.op {
+		case opLiteral:
+			tmpl = append(tmpl, i.lit...)
+			continue
+		case opLongYear:
+			pos = &yp
+		case opZeroMonth:
+			pos = &mp
+		case opZeroDay:
+			pos = &dp
+		default:
+			return nil, 0, 0, 0, false
+		}
+		if *pos >= 0 {
+			return nil, 0, 0, 0, false
+		}
+		*pos = len(tmpl)
+		tmpl = append(tmpl, i.op.String()...)
+	}
+	return tmpl, yp, mp, dp, yp >= 0 && mp >= 0 && dp >= 0 && len(tmpl) <= 64
+}
+
+// A NameCase determines the case of the names of months and weekdays, when
+// formatting with a [Formatter].
+type NameCase int
+
+const (
+	// KeepCase uses names as they are, like "May" or "mai".
+	KeepCase NameCase = iota
+
+	// UpperCase converts names to upper case, like "MAY" or "MAI".
+	UpperCase
+
+	// LowerCase converts names to lower case, like "may" or "mai".
+	LowerCase
+
+	// TitleCase converts the first letter of names to upper case and the
+	// others to lower case, like "May" or "Mai".
+	TitleCase
+)
+
+// appendName appends s in case c to b.
+func appendName(b []byte, s string, c NameCase) []byte {
+	if c == KeepCase {
+		return append(b, s...)
+	}
+	for i, r := range s {
+		switch {
+		case c == UpperCase || c == TitleCase && i == 0:
+			r = unicode.ToUpper(r)
+		default:
+			r = unicode.ToLower(r)
+		}
+		b = utf8.AppendRune(b, r)
+	}
+	return b
+}
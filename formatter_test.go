@@ -0,0 +1,142 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestFormatter(t *testing.T) {
+	t.Parallel()
+	ds := []Date{
+		Of(2024, 5, 14),
+		Of(1, 1, 1),
+		Of(-12, 12, 31),
+		Of(12345, 6, 7),
+	}
+	for _, layout := range layouts {
+		f := NewFormatter(layout)
+		if got := f.Layout(); got != layout {
+			t.Errorf("NewFormatter(%q).Layout() = %q", layout, got)
+		}
+		var buf bytes.Buffer
+		var want []byte
+		for _, d := range ds {
+			if got, want := f.Format(d), d.Format(layout); got != want {
+				t.Errorf("NewFormatter(%q).Format(%v) = %q, want %q", layout, d, got, want)
+			}
+			if got, want := string(f.AppendDate([]byte("x"), d)), "x"+d.Format(layout); got != want {
+				t.Errorf("NewFormatter(%q).AppendDate(%q, %v) = %q, want %q", layout, "x", d, got, want)
+			}
+			n, err := f.WriteDate(&buf, d)
+			if err != nil || n != len(d.Format(layout)) {
+				t.Errorf("NewFormatter(%q).WriteDate(…, %v) = %d, %v, want %d, <nil>", layout, d, n, err, len(d.Format(layout)))
+			}
+			want = d.AppendFormat(want, layout)
+		}
+		if got := buf.String(); got != string(want) {
+			t.Errorf("NewFormatter(%q) wrote %q, want %q", layout, got, want)
+		}
+	}
+}
+
+func TestFormatterFunc(t *testing.T) {
+	t.Parallel()
+	ds := []Date{
+		Of(2024, 5, 14),
+		Of(2024, 12, 31),
+		Of(1, 1, 1),
+		Of(-12, 12, 31),
+		Of(12345, 6, 7),
+	}
+	for _, layout := range append(layouts, "02.01.2006", "2006/02/01", "06/002", "2006-__2", "2006-01-02 2006", "x") {
+		fn := NewFormatter(layout).Func()
+		for _, d := range ds {
+			if got, want := fn(d), d.Format(layout); got != want {
+				t.Errorf("NewFormatter(%q).Func()(%v) = %q, want %q", layout, d, got, want)
+			}
+		}
+	}
+}
+
+func TestFormatterZeroAllocs(t *testing.T) {
+	f := NewFormatter("Monday, January 2, 2006")
+	d := Of(2024, 5, 14)
+	f.WriteDate(io.Discard, d) // grow the buffer
+	if n := testing.AllocsPerRun(100, func() { f.WriteDate(io.Discard, d) }); n != 0 {
+		t.Errorf("WriteDate allocates %v times, want 0", n)
+	}
+	for _, layout := range []string{RFC3339, "02.01.2006"} {
+		fn := NewFormatter(layout).Func()
+		if n := testing.AllocsPerRun(100, func() { fn(d) }); n != 1 {
+			t.Errorf("NewFormatter(%q).Func() allocates %v times, want 1", layout, n)
+		}
+	}
+}
+
+func BenchmarkFormatter(b *testing.B) {
+	f := NewFormatter("Monday, January 2, 2006")
+	d := Of(2024, 5, 14)
+	for i := 0; i < b.N; i++ {
+		f.WriteDate(io.Discard, d+Date(i%1000))
+	}
+}
+
+func BenchmarkFormatterFunc(b *testing.B) {
+	d := Of(2024, 5, 14)
+	for _, layout := range []string{RFC3339, "02.01.2006", RFC1123} {
+		b.Run(layout, func(b *testing.B) {
+			b.Run("Format", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_ = (d + Date(i%1000)).Format(layout)
+				}
+			})
+			b.Run("Func", func(b *testing.B) {
+				fn := NewFormatter(layout).Func()
+				for i := 0; i < b.N; i++ {
+					_ = fn(d + Date(i%1000))
+				}
+			})
+		})
+	}
+}
+
+func TestFormatterNameCase(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 14)
+	tcs := []struct {
+		layout string
+		c      NameCase
+		want   string
+	}{
+		{"02-Jan-2006", KeepCase, "14-May-2024"},
+		{"02-Jan-2006", UpperCase, "14-MAY-2024"},
+		{"Monday, 2 January 2006", UpperCase, "TUESDAY, 14 MAY 2024"},
+		{"Monday, 2 January 2006", LowerCase, "tuesday, 14 may 2024"},
+		{"Monday, 2 January 2006", TitleCase, "Tuesday, 14 May 2024"},
+		{"Mon Jan 2006", LowerCase, "tue may 2024"},
+	}
+	for _, tc := range tcs {
+		f := NewFormatter(tc.layout).SetNameCase(tc.c)
+		if got := f.Format(d); got != tc.want {
+			t.Errorf("NewFormatter(%q).SetNameCase(%v).Format(%v) = %q, want %q", tc.layout, tc.c, d, got, tc.want)
+		}
+		if got := f.Func()(d); got != tc.want {
+			t.Errorf("NewFormatter(%q).SetNameCase(%v).Func()(%v) = %q, want %q", tc.layout, tc.c, d, got, tc.want)
+		}
+	}
+	// Case conversion applies to non-ASCII names as well.
+	b := appendName(nil, "äpril", TitleCase)
+	if got, want := string(b), "Äpril"; got != want {
+		t.Errorf("appendName(nil, %q, TitleCase) = %q, want %q", "äpril", got, want)
+	}
+	b = appendName(nil, "ÉTÉ", LowerCase)
+	if got, want := string(b), "été"; got != want {
+		t.Errorf("appendName(nil, %q, LowerCase) = %q, want %q", "ÉTÉ", got, want)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// lookupMonthFuzzy is like lookup, for the names of months in tables. If the
+// word at the start of p.value is not fully matched by any name in tables, it
+// is resolved to the closest name of a month in loc, as documented for
+// [Parser.FuzzyMonths].
+func (p *parser) lookupMonthFuzzy(loc *Locale, tables ...[]string) int {
+	word := p.value
+	for i := 0; i < len(word); i++ {
+		if !isLetter(word[i]) {
+			word = word[:i]
+			break
+		}
+	}
+	if idx, n := p.longestMatch(tables...); idx >= 0 && n >= len(word) {
+		p.value = p.value[n:]
+		return idx
+	}
+	// Cloning keeps value from escaping, as in parser.err.
+	idx := closestMonth(loc, strings.Clone(word))
+	if idx < 0 {
+		p.parseFailed()
+		return 0
+	}
+	p.value = p.value[len(word):]
+	return idx
+}
+
+// closestMonth returns the index of the month with the name closest to word,
+// or -1, if there is no sufficiently close name or the closest names belong to
+// different months.
+func closestMonth(loc *Locale, word string) int {
+	w := normalizeName(word)
+	n := utf8.RuneCountInString(w)
+	if n == 0 {
+		return -1
+	}
+	maxDist := 0
+	switch {
+	case n > 7:
+		maxDist = 2
+	case n > 3:
+		maxDist = 1
+	}
+	best, bestDist, ambiguous := -1, maxDist+1, false
+	for _, names := range [...]*[12]string{&loc.Months, &loc.ShortMonths, &loc.StandaloneMonths, &loc.ShortStandaloneMonths} {
+		for i, name := range names {
+			if name == "" {
+				continue
+			}
+			name = normalizeName(name)
+			d := 0
+			if n < 3 || !strings.HasPrefix(name, w) {
+				d = editDistance(w, name)
+			}
+			switch {
+			case d < bestDist:
+				best, bestDist, ambiguous = i, d, false
+			case d == bestDist && i != best:
+				ambiguous = true
+			}
+		}
+	}
+	if ambiguous {
+		return -1
+	}
+	return best
+}
+
+// nameReplacer replaces diacritics by their common ASCII transliteration and
+// removes abbreviation dots.
+var nameReplacer = strings.NewReplacer(
+	"ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss",
+	"à", "a", "â", "a", "á", "a",
+	"ç", "c",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "í", "i",
+	"ô", "o", "ó", "o",
+	"û", "u", "ù", "u", "ú", "u",
+	".", "",
+)
+
+// normalizeName returns the lower-case form of a name, with diacritics
+// transliterated.
+func normalizeName(s string) string {
+	return nameReplacer.Replace(strings.ToLower(s))
+}
+
+// editDistance returns the edit distance between a and b, that is the minimal
+// number of inserted, deleted or substituted runes and transpositions of
+// adjacent runes to turn a into b. Transposed runes can not be edited further.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// pprev, prev and cur are the rows i-1, i and i+1 of the distance
+	// matrix, which has one column more than rb has runes.
+	pprev, prev, cur := make([]int, len(rb)+1), make([]int, len(rb)+1), make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := range ra {
+		cur[0] = i + 1
+		for j := range rb {
+			cost := 1
+			if ra[i] == rb[j] {
+				cost = 0
+			}
+			cur[j+1] = min(prev[j+1]+1, cur[j]+1, prev[j]+cost)
+			if i > 0 && j > 0 && ra[i] == rb[j-1] && ra[i-1] == rb[j] {
+				cur[j+1] = min(cur[j+1], pprev[j-1]+1)
+			}
+		}
+		pprev, prev, cur = prev, cur, pprev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "testing"
+
+var germanMonths = &Locale{
+	Months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+	ShortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+}
+
+func TestFuzzyMonths(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		layout string
+		value  string
+		loc    *Locale
+		want   Date
+		err    bool
+	}{
+		{"January 2, 2006", "May 14, 2024", nil, Of(2024, 5, 14), false},
+		{"January 2, 2006", "Febuary 14, 2024", nil, Of(2024, 2, 14), false},
+		{"January 2, 2006", "febrary 14, 2024", nil, Of(2024, 2, 14), false},
+		{"January 2, 2006", "Sept 14, 2024", nil, Of(2024, 9, 14), false},
+		{"Jan 2, 2006", "Sept 14, 2024", nil, Of(2024, 9, 14), false},
+		{"Jan 2, 2006", "Spetember 14, 2024", nil, Of(2024, 9, 14), false},
+		{"January 2, 2006", "Jnue 14, 2024", nil, Of(2024, 6, 14), false},
+		{"January 2, 2006", "Setpember 14, 2024", nil, Of(2024, 9, 14), false},
+		{"January 2, 2006", "Decmeber 14, 2024", nil, Of(2024, 12, 14), false},
+		{"2. January 2006", "14. Maerz 2024", germanMonths, Of(2024, 3, 14), false},
+		{"2. January 2006", "14. Marz 2024", germanMonths, Of(2024, 3, 14), false},
+		{"2. Jan 2006", "14. Sept. 2024", germanMonths, Of(2024, 9, 14), false},
+		{"2. Jan 2006", "14. Okt 2024", germanMonths, Of(2024, 10, 14), false},
+		// June and July are one edit away from "Juny".
+		{"January 2, 2006", "Juny 14, 2024", nil, 0, true},
+		// Words of three letters must match exactly.
+		{"Jan 2, 2006", "Jnu 14, 2024", nil, 0, true},
+		// Ju is a prefix of June and July.
+		{"January 2, 2006", "Ju 14, 2024", nil, 0, true},
+		{"January 2, 2006", "Fbruar 14, 2024", nil, 0, true},
+		{"January 2, 2006", "Mai 14, 2024", nil, 0, true},
+		{"January 2, 2006", "14, 2024", nil, 0, true},
+	}
+	for _, tc := range tcs {
+		ps := Parser{Locale: tc.loc, FuzzyMonths: true}
+		got, err := ps.Parse(tc.layout, tc.value)
+		if (err != nil) != tc.err || got != tc.want {
+			t.Errorf("Parse(%q, %q) = %v, %v, want %v, %v", tc.layout, tc.value, got, err, tc.want, tc.err)
+		}
+	}
+	if _, err := (Parser{}).Parse("January 2, 2006", "Febuary 14, 2024"); err == nil {
+		t.Error(`Parse("January 2, 2006", "Febuary 14, 2024") without FuzzyMonths succeeded`)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"märz", "marz", 1},
+		{"flaw", "lawn", 2},
+		{"spet", "sept", 1},
+		{"ca", "abc", 3},
+	}
+	for _, tc := range tcs {
+		if got := editDistance(tc.a, tc.b); got != tc.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+		if got := editDistance(tc.b, tc.a); got != tc.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tc.b, tc.a, got, tc.want)
+		}
+	}
+}
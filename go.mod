@@ -1,5 +1,5 @@
 module gonih.org/date
 
-go 1.22.1
+go 1.23.0
 
 require gonih.org v0.0.0-20230802184447-5ac3f742ddac // indirect
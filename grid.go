@@ -0,0 +1,56 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "time"
+
+// MonthGrid returns the dates of the given month, arranged in weeks starting
+// on weekStart, as displayed by a calendar. Every week has seven days. The
+// first and last week are padded with days from the adjacent months.
+//
+// Depending on the length of the month and the day of the week it starts on,
+// the grid has four to six weeks. The month is normalized as for [Of] and
+// weekStart is taken modulo 7, as for [WeekdaysOf].
+func MonthGrid(year int, m time.Month, weekStart time.Weekday) [][]Date {
+	weekStart = (weekStart%7 + 7) % 7
+	first := Of(year, m, 1)
+	end := first.AddDate(0, 1, 0)
+	start := first - Date((first.Weekday()-weekStart+7)%7)
+	days := make([]Date, 0, 6*7)
+	for d := start; d < end || len(days)%7 != 0; d++ {
+		days = append(days, d)
+	}
+	grid := make([][]Date, 0, len(days)/7)
+	for i := 0; i < len(days); i += 7 {
+		grid = append(grid, days[i:i+7:i+7])
+	}
+	return grid
+}
+
+// An ISOWeekSpan is the part of an ISO 8601 week falling into a month, as
+// returned by [MonthISOWeeks].
+type ISOWeekSpan struct {
+	// Year and Week identify the week, as returned by [Date.ISOWeek]. Year
+	// is the ISO year, which can differ from the year of the month.
+	Year, Week int
+
+	// Range contains the days of the week in the month. It covers less than
+	// seven days, if the week starts before or ends after the month.
+	Range Range
+}
+
+// MonthISOWeeks returns the ISO 8601 weeks overlapping the given month, in
+// order. The first and last week are clipped to the month. The month is
+// normalized as for [Of].
+func MonthISOWeeks(year int, m time.Month) []ISOWeekSpan {
+	weeks := MonthRange(year, m).SplitByWeek(time.Monday)
+	out := make([]ISOWeekSpan, len(weeks))
+	for i, r := range weeks {
+		y, w := r.Start.ISOWeek()
+		out[i] = ISOWeekSpan{y, w, r}
+	}
+	return out
+}
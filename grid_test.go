@@ -0,0 +1,104 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMonthGrid(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		year      int
+		month     time.Month
+		weekStart time.Weekday
+		first     Date
+		last      Date
+		weeks     int
+	}{
+		// February 2015 starts on a Sunday and has 28 days.
+		{2015, time.February, time.Sunday, Of(2015, 2, 1), Of(2015, 2, 28), 4},
+		{2015, time.February, time.Monday, Of(2015, 1, 26), Of(2015, 3, 1), 5},
+		{2024, time.May, time.Monday, Of(2024, 4, 29), Of(2024, 6, 2), 5},
+		{2024, time.May, time.Sunday, Of(2024, 4, 28), Of(2024, 6, 1), 5},
+		{2024, time.May, time.Saturday, Of(2024, 4, 27), Of(2024, 5, 31), 5},
+		// September 2024 starts on a Sunday and has 30 days.
+		{2024, time.September, time.Monday, Of(2024, 8, 26), Of(2024, 10, 6), 6},
+		{2024, 13, time.Monday, Of(2024, 12, 30), Of(2025, 2, 2), 5},
+		{2024, time.May, time.Monday + 7, Of(2024, 4, 29), Of(2024, 6, 2), 5},
+		{2024, time.May, -1, Of(2024, 4, 27), Of(2024, 5, 31), 5},
+	}
+	for _, tc := range tcs {
+		grid := MonthGrid(tc.year, tc.month, tc.weekStart)
+		if len(grid) != tc.weeks {
+			t.Errorf("MonthGrid(%d, %v, %v) has %d weeks, want %d", tc.year, tc.month, tc.weekStart, len(grid), tc.weeks)
+			continue
+		}
+		want := tc.first
+		for _, week := range grid {
+			if len(week) != 7 {
+				t.Fatalf("MonthGrid(%d, %v, %v) has week of %d days", tc.year, tc.month, tc.weekStart, len(week))
+			}
+			if wd := week[0].Weekday(); wd != (tc.weekStart%7+7)%7 {
+				t.Errorf("MonthGrid(%d, %v, %v) has week starting on %v", tc.year, tc.month, tc.weekStart, wd)
+			}
+			for _, d := range week {
+				if d != want {
+					t.Fatalf("MonthGrid(%d, %v, %v) contains %v, want %v", tc.year, tc.month, tc.weekStart, d, want)
+				}
+				want++
+			}
+		}
+		if got := grid[len(grid)-1][6]; got != tc.last {
+			t.Errorf("MonthGrid(%d, %v, %v) ends on %v, want %v", tc.year, tc.month, tc.weekStart, got, tc.last)
+		}
+	}
+}
+
+func TestMonthISOWeeks(t *testing.T) {
+	t.Parallel()
+	d := func(year int, m time.Month, day int) Date { return Of(year, m, day) }
+	tcs := []struct {
+		year  int
+		month time.Month
+		want  []ISOWeekSpan
+	}{
+		{2024, time.May, []ISOWeekSpan{
+			{2024, 18, Range{d(2024, 5, 1), d(2024, 5, 6)}},
+			{2024, 19, Range{d(2024, 5, 6), d(2024, 5, 13)}},
+			{2024, 20, Range{d(2024, 5, 13), d(2024, 5, 20)}},
+			{2024, 21, Range{d(2024, 5, 20), d(2024, 5, 27)}},
+			{2024, 22, Range{d(2024, 5, 27), d(2024, 6, 1)}},
+		}},
+		{2021, time.February, []ISOWeekSpan{
+			{2021, 5, Range{d(2021, 2, 1), d(2021, 2, 8)}},
+			{2021, 6, Range{d(2021, 2, 8), d(2021, 2, 15)}},
+			{2021, 7, Range{d(2021, 2, 15), d(2021, 2, 22)}},
+			{2021, 8, Range{d(2021, 2, 22), d(2021, 3, 1)}},
+		}},
+		{2021, time.January, []ISOWeekSpan{
+			{2020, 53, Range{d(2021, 1, 1), d(2021, 1, 4)}},
+			{2021, 1, Range{d(2021, 1, 4), d(2021, 1, 11)}},
+			{2021, 2, Range{d(2021, 1, 11), d(2021, 1, 18)}},
+			{2021, 3, Range{d(2021, 1, 18), d(2021, 1, 25)}},
+			{2021, 4, Range{d(2021, 1, 25), d(2021, 2, 1)}},
+		}},
+		{2024, 13, []ISOWeekSpan{
+			{2025, 1, Range{d(2025, 1, 1), d(2025, 1, 6)}},
+			{2025, 2, Range{d(2025, 1, 6), d(2025, 1, 13)}},
+			{2025, 3, Range{d(2025, 1, 13), d(2025, 1, 20)}},
+			{2025, 4, Range{d(2025, 1, 20), d(2025, 1, 27)}},
+			{2025, 5, Range{d(2025, 1, 27), d(2025, 2, 1)}},
+		}},
+	}
+	for _, tc := range tcs {
+		if got := MonthISOWeeks(tc.year, tc.month); !slices.Equal(got, tc.want) {
+			t.Errorf("MonthISOWeeks(%d, %v) = %v, want %v", tc.year, tc.month, got, tc.want)
+		}
+	}
+}
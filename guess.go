@@ -0,0 +1,168 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+)
+
+// guessParser parses the candidate layouts, which include the extended
+// element "2nd".
+var guessParser = Parser{Extended: true}
+
+// guessCandidates are the layouts considered by GuessLayout, in order of
+// preference.
+var guessCandidates = func() []string {
+	var out []string
+	numeric := [][2]string{
+		{"2006{s}01{s}02", "2006{s}1{s}2"},
+		{"01{s}02{s}2006", "1{s}2{s}2006"},
+		{"02{s}01{s}2006", "2{s}1{s}2006"},
+		{"01{s}02{s}06", "1{s}2{s}06"},
+		{"02{s}01{s}06", "2{s}1{s}06"},
+	}
+	for _, sep := range []string{"-", "/", ".", " "} {
+		for _, l := range numeric {
+			out = append(out,
+				strings.ReplaceAll(l[0], "{s}", sep),
+				strings.ReplaceAll(l[1], "{s}", sep),
+			)
+		}
+	}
+	return append(out,
+		ISOBasic,
+		RFC1123,
+		"2 Jan 2006",
+		"02-Jan-2006",
+		"2-Jan-2006",
+		RFC822,
+		"02-Jan-06",
+		"Jan 2, 2006",
+		"Jan 2 2006",
+		LongDate,
+		LongOrdinal,
+		"2 January 2006",
+		"Mon, 02 Jan 2006",
+		"Mon, Jan 2, 2006",
+		"Monday, January 2, 2006",
+		"Monday, 2 January 2006",
+		"2006-002",
+	)
+}()
+
+// GuessLayout infers the layout of the given sample values. It is intended
+// for importing data in an unknown format, like user-provided spreadsheets.
+//
+// GuessLayout tries a list of common layouts and returns the one parsing the
+// most samples. If multiple layouts parse the same number of samples, it
+// prefers the one formatting the parsed dates back to the samples, so
+// "2006-01-02" is preferred over "2006-1-2" for zero-padded samples.
+//
+// The confidence is the fraction of samples parsed by the layout, divided by
+// the number of equally good layouts interpreting the samples differently.
+// For example, if all samples are of the form "05/06/2024", GuessLayout can
+// not determine whether the day or the month comes first. It then prefers the
+// month first, as in [US], with a confidence of 0.5. A single sample with a
+// day greater than 12 resolves this ambiguity.
+//
+// The returned layout might be [LongOrdinal], which has to be parsed with
+// [Parser.Extended]. Leading and trailing white space in the samples is
+// ignored. GuessLayout returns an error, if there are no samples or no layout
+// parses any of them.
+func GuessLayout(samples []string) (layout string, confidence float64, err error) {
+	if len(samples) == 0 {
+		return "", 0, errors.New("no samples to guess layout from")
+	}
+	type result struct {
+		layout            string
+		parsed, formatted int
+		dates             []Date
+	}
+	var best []result
+	for _, l := range guessCandidates {
+		r := result{layout: l, dates: make([]Date, len(samples))}
+		for i, s := range samples {
+			s = strings.TrimSpace(s)
+			d, err := guessParser.Parse(l, s)
+			if err != nil {
+				r.dates[i] = math.MinInt // never returned by Parse
+				continue
+			}
+			r.dates[i] = d
+			r.parsed++
+			if d.formatExtended(l) == s {
+				r.formatted++
+			}
+		}
+		if r.parsed == 0 {
+			continue
+		}
+		if len(best) == 0 || r.parsed > best[0].parsed || r.parsed == best[0].parsed && r.formatted > best[0].formatted {
+			best = append(best[:0], r)
+		} else if r.parsed == best[0].parsed && r.formatted == best[0].formatted {
+			best = append(best, r)
+		}
+	}
+	if len(best) == 0 {
+		return "", 0, errors.New("no layout matches the samples")
+	}
+	// Count the different interpretations of the samples.
+	var interpretations [][]Date
+outer:
+	for _, r := range best {
+		for _, ds := range interpretations {
+			if slices.Equal(ds, r.dates) {
+				continue outer
+			}
+		}
+		interpretations = append(interpretations, r.dates)
+	}
+	confidence = float64(best[0].parsed) / float64(len(samples)) / float64(len(interpretations))
+	return best[0].layout, confidence, nil
+}
+
+// ParseFirst parses value using the first of the given layouts which matches
+// it, as by [Parse]. The layouts are tried in order, so for values matching
+// multiple layouts, like "05/06/2024", the order of layouts determines the
+// result.
+//
+// If no layout matches, the returned error joins the errors for all layouts.
+func ParseFirst(layouts []string, value string) (Date, error) {
+	var errs []error
+	for _, l := range layouts {
+		d, err := Parse(l, value)
+		if err == nil {
+			return d, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return 0, errors.New("no layouts to parse date with")
+	}
+	return 0, errors.Join(errs...)
+}
+
+// ParseAny parses value using the common layouts considered by [GuessLayout],
+// like "2024-05-14", "14 May 2024", "May 14, 2024" or "05/14/2024". It is
+// intended for single values in an unknown format. For many values in the
+// same format, GuessLayout and [ParseAll] are more reliable.
+//
+// Numeric dates with the year first are parsed as year, month and day. Other
+// numeric dates are parsed with the month first, as in [US], unless that
+// fails, so "05/06/2024" is May 6th and "13/06/2024" is June 13th. Leading and
+// trailing white space is ignored.
+func ParseAny(value string) (Date, error) {
+	s := strings.TrimSpace(value)
+	for _, l := range guessCandidates {
+		if d, err := guessParser.Parse(l, s); err == nil {
+			return d, nil
+		}
+	}
+	return 0, &ParseError{Value: value, Message: "no common layout matches", Err: ErrSyntax}
+}
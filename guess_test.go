@@ -0,0 +1,100 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGuessLayout(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		samples    []string
+		layout     string
+		confidence float64
+	}{
+		{[]string{"2024-05-14", "2023-01-02"}, RFC3339, 1},
+		{[]string{"2024-5-14", "2023-1-2"}, "2006-1-2", 1},
+		{[]string{"05/06/2024", "01/02/2024"}, US, 0.5},
+		{[]string{"05/06/2024", "01/31/2024"}, US, 1},
+		{[]string{"05/06/2024", "31/01/2024"}, UKSlash, 1},
+		{[]string{"01/01/2024"}, US, 1},
+		{[]string{"14.05.2024", " 01.02.2024 "}, EuropeanDotted, 1},
+		{[]string{"20240514"}, ISOBasic, 1},
+		{[]string{"May 14, 2024", "June 1, 2024"}, LongDate, 1},
+		{[]string{"May 14th, 2024", "June 1st, 2024"}, LongOrdinal, 1},
+		{[]string{"14 May 2024", "01 Jun 2024", "garbage"}, RFC1123, 2.0 / 3},
+		{[]string{"Tuesday, May 14, 2024"}, "Monday, January 2, 2006", 1},
+	}
+	for _, tc := range tcs {
+		layout, confidence, err := GuessLayout(tc.samples)
+		if err != nil || layout != tc.layout || confidence != tc.confidence {
+			t.Errorf("GuessLayout(%q) = %q, %v, %v, want %q, %v, <nil>", tc.samples, layout, confidence, err, tc.layout, tc.confidence)
+		}
+	}
+	for _, samples := range [][]string{nil, {"foo", "bar"}} {
+		if layout, _, err := GuessLayout(samples); err == nil {
+			t.Errorf("GuessLayout(%q) = %q, <nil>, want error", samples, layout)
+		}
+	}
+}
+
+func TestParseFirst(t *testing.T) {
+	t.Parallel()
+	layouts := []string{UKSlash, US, RFC1123}
+	tcs := []struct {
+		value string
+		want  Date
+	}{
+		{"05/06/2024", Of(2024, 6, 5)},
+		{"05/13/2024", Of(2024, 5, 13)},
+		{"14 May 2024", Of(2024, 5, 14)},
+	}
+	for _, tc := range tcs {
+		if got, err := ParseFirst(layouts, tc.value); err != nil || got != tc.want {
+			t.Errorf("ParseFirst(%q, %q) = %v, %v, want %v, <nil>", layouts, tc.value, got, err, tc.want)
+		}
+	}
+	_, err := ParseFirst(layouts, "2024-05-14")
+	if err == nil || strings.Count(err.Error(), "\n") != len(layouts)-1 {
+		t.Errorf("ParseFirst(%q, %q) = _, %v, want one error per layout", layouts, "2024-05-14", err)
+	}
+	if _, err := ParseFirst(nil, "2024-05-14"); err == nil {
+		t.Errorf("ParseFirst(nil, %q) = _, <nil>, want error", "2024-05-14")
+	}
+}
+
+func TestParseAny(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		value string
+		want  Date
+	}{
+		{"2024-05-14", Of(2024, 5, 14)},
+		{" 2024/5/14 ", Of(2024, 5, 14)},
+		{"20240514", Of(2024, 5, 14)},
+		{"05/06/2024", Of(2024, 5, 6)},
+		{"13/06/2024", Of(2024, 6, 13)},
+		{"14.05.2024", Of(2024, 5, 14)},
+		{"14 May 2024", Of(2024, 5, 14)},
+		{"14-May-24", Of(2024, 5, 14)},
+		{"May 14, 2024", Of(2024, 5, 14)},
+		{"May 14th, 2024", Of(2024, 5, 14)},
+		{"Tuesday, May 14, 2024", Of(2024, 5, 14)},
+		{"2024-135", Of(2024, 5, 14)},
+	}
+	for _, tc := range tcs {
+		if got, err := ParseAny(tc.value); err != nil || got != tc.want {
+			t.Errorf("ParseAny(%q) = %v, %v, want %v, <nil>", tc.value, got, err, tc.want)
+		}
+	}
+	for _, s := range []string{"", "garbage", "2024-13-01", "32/01/2024"} {
+		if got, err := ParseAny(s); err == nil {
+			t.Errorf("ParseAny(%q) = %v, <nil>, want error", s, got)
+		}
+	}
+}
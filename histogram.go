@@ -0,0 +1,65 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"iter"
+	"sort"
+	"time"
+)
+
+// A Bucket is a period of time in a histogram, together with the number of
+// dates falling into it.
+type Bucket struct {
+	// Start is the first day of the bucket.
+	Start Date
+	Count int
+}
+
+// Histogram counts the dates in ds, bucketed by the given unit. It returns one
+// bucket for every unit overlapping r, in order, including those containing no
+// dates. Dates outside of r are ignored.
+//
+// Buckets are aligned to the calendar: a bucket of Weeks starts on a Monday,
+// as in ISO 8601, a bucket of Months on the first day of the month and so on.
+// So the first and the last bucket might extend beyond r.
+//
+// Histogram panics, if u is not one of the defined units.
+func Histogram(ds iter.Seq[Date], r Range, u Unit) []Bucket {
+	if !u.valid() {
+		panic("Histogram: invalid unit " + u.String())
+	}
+	if r.IsEmpty() {
+		return nil
+	}
+	var out []Bucket
+	for d := u.truncate(r.Start); d < r.End; d = u.add(d, 1) {
+		out = append(out, Bucket{Start: d})
+	}
+	for d := range ds {
+		if !r.Contains(d) {
+			continue
+		}
+		i := sort.Search(len(out), func(i int) bool { return out[i].Start > d }) - 1
+		out[i].Count++
+	}
+	return out
+}
+
+// truncate returns the first day of the unit containing d. Weeks start on
+// Monday.
+func (u Unit) truncate(d Date) Date {
+	switch u {
+	case Days:
+		return d
+	case Weeks:
+		return d - Date((d.Weekday()-time.Monday+7)%7)
+	}
+	year, month, _ := d.Date()
+	m := u.months()
+	month = (month-1)/time.Month(m)*time.Month(m) + 1
+	return Of(year, month, 1)
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+	ds := []Date{
+		Of(2024, 5, 14),
+		Of(2024, 5, 14),
+		Of(2024, 5, 15),
+		Of(2024, 5, 20),
+		Of(2024, 6, 30),
+		Of(2024, 7, 1),
+		Of(2024, 4, 30), // outside
+		Of(2024, 7, 2),  // outside
+	}
+	r := Range{Of(2024, 5, 1), Of(2024, 7, 2)}
+	tcs := []struct {
+		u    Unit
+		r    Range
+		want []Bucket
+	}{
+		{Days, Range{Of(2024, 5, 13), Of(2024, 5, 17)}, []Bucket{
+			{Of(2024, 5, 13), 0},
+			{Of(2024, 5, 14), 2},
+			{Of(2024, 5, 15), 1},
+			{Of(2024, 5, 16), 0},
+		}},
+		{Weeks, Range{Of(2024, 5, 14), Of(2024, 5, 28)}, []Bucket{
+			{Of(2024, 5, 13), 3},
+			{Of(2024, 5, 20), 1},
+			{Of(2024, 5, 27), 0},
+		}},
+		{Months, r, []Bucket{
+			{Of(2024, 5, 1), 4},
+			{Of(2024, 6, 1), 1},
+			{Of(2024, 7, 1), 1},
+		}},
+		{Quarters, r, []Bucket{
+			{Of(2024, 4, 1), 5},
+			{Of(2024, 7, 1), 1},
+		}},
+		{Years, r, []Bucket{
+			{Of(2024, 1, 1), 6},
+		}},
+		{Years, Range{}, nil},
+	}
+	for _, tc := range tcs {
+		got := Histogram(slices.Values(ds), tc.r, tc.u)
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("Histogram(…, %v, %v) = %v, want %v", tc.r, tc.u, got, tc.want)
+		}
+	}
+}
+
+func TestHistogramPanics(t *testing.T) {
+	t.Parallel()
+	for _, u := range []Unit{-1, Years + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Histogram(…, %v) did not panic", u)
+				}
+			}()
+			Histogram(slices.Values([]Date{Of(2024, 5, 14)}), YearRange(2024), u)
+		}()
+	}
+}
+
+func TestUnitTruncate(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 11, 14) // a Thursday
+	tcs := []struct {
+		u    Unit
+		want Date
+	}{
+		{Days, d},
+		{Weeks, Of(2024, 11, 11)},
+		{Months, Of(2024, 11, 1)},
+		{Quarters, Of(2024, 10, 1)},
+		{Years, Of(2024, 1, 1)},
+	}
+	for _, tc := range tcs {
+		if got := tc.u.truncate(d); got != tc.want {
+			t.Errorf("%v.truncate(%v) = %v, want %v", tc.u, d, got, tc.want)
+		}
+	}
+}
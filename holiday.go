@@ -0,0 +1,347 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// A Holiday is an occurrence of a holiday in a specific year.
+type Holiday struct {
+	// Name is the name of the holiday, like "Christmas Day".
+	Name string
+
+	// Date is the nominal date of the holiday.
+	Date Date
+
+	// Observed is the date on which the holiday is observed. It differs
+	// from Date, if the holiday falls on a weekend or another holiday and
+	// the calendar moves it to a substitute day.
+	Observed Date
+}
+
+// A Calendar provides the holidays of some region or organization.
+type Calendar interface {
+	// Holidays returns the holidays with a nominal date in the given year,
+	// sorted by their observed date. The observed date might fall into the
+	// previous or next year.
+	Holidays(year int) []Holiday
+}
+
+// HolidaysIn returns the holidays in cal observed on a date in r, sorted by
+// their observed date.
+func HolidaysIn(cal Calendar, r Range) []Holiday {
+	if r.IsEmpty() {
+		return nil
+	}
+	var out []Holiday
+	for y := r.Start.Year() - 1; y <= r.Last().Year()+1; y++ {
+		for _, h := range cal.Holidays(y) {
+			if r.Contains(h.Observed) {
+				out = append(out, h)
+			}
+		}
+	}
+	slices.SortStableFunc(out, func(a, b Holiday) int {
+		return cmp.Compare(a.Observed, b.Observed)
+	})
+	return out
+}
+
+// HolidayOn returns a holiday in cal observed on d. It reports false, if d is
+// not a holiday.
+func HolidayOn(cal Calendar, d Date) (Holiday, bool) {
+	y := d.Year()
+	// Check the current year first, as it is the most likely one.
+	for _, y := range [...]int{y, y - 1, y + 1} {
+		for _, h := range cal.Holidays(y) {
+			if h.Observed == d {
+				return h, true
+			}
+		}
+	}
+	return Holiday{}, false
+}
+
+// A MergedCalendar is the union of several calendars, like the holidays of
+// all financial centers involved in a transaction.
+type MergedCalendar struct {
+	cals []Calendar
+}
+
+// MergeCalendars returns a Calendar containing the holidays of all cals. Use
+// [MergedCalendar.Sources] to find out which of cals observe a holiday on a
+// given date.
+func MergeCalendars(cals ...Calendar) *MergedCalendar {
+	return &MergedCalendar{slices.Clone(cals)}
+}
+
+// Holidays implements Calendar. A holiday occurring in multiple calendars,
+// with the same name, nominal and observed date, is included only once.
+// Otherwise, holidays observed on the same date are sorted in the order of
+// the calendars passed to MergeCalendars.
+func (m *MergedCalendar) Holidays(year int) []Holiday {
+	var out []Holiday
+	for _, c := range m.cals {
+		for _, h := range c.Holidays(year) {
+			if !slices.Contains(out, h) {
+				out = append(out, h)
+			}
+		}
+	}
+	slices.SortStableFunc(out, func(a, b Holiday) int {
+		return cmp.Compare(a.Observed, b.Observed)
+	})
+	return out
+}
+
+// Sources returns the indices of the calendars passed to MergeCalendars,
+// which observe a holiday on d, in ascending order. It returns nil, if d is
+// not a holiday.
+func (m *MergedCalendar) Sources(d Date) []int {
+	var out []int
+	for i, c := range m.cals {
+		if _, ok := HolidayOn(c, d); ok {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// A HolidayRule describes a recurring holiday.
+type HolidayRule struct {
+	// Name is the name of the holiday.
+	Name string
+
+	// Date returns the nominal date of the holiday in the given year. It
+	// returns false, if there is no such holiday in that year.
+	Date func(year int) (Date, bool)
+
+	// FirstYear and LastYear restrict the years in which the holiday
+	// exists. A zero value means no restriction.
+	FirstYear, LastYear int
+
+	// Observe determines the date on which the holiday is observed. If it is
+	// nil, the holiday is observed on its nominal date.
+	Observe Observance
+}
+
+// FixedHoliday returns a HolidayRule for a holiday on the same day every year,
+// like Christmas Day.
+func FixedHoliday(name string, month time.Month, day int, observe Observance) HolidayRule {
+	return HolidayRule{
+		Name: name,
+		Date: func(year int) (Date, bool) {
+			return Of(year, month, day), true
+		},
+		Observe: observe,
+	}
+}
+
+// WeekdayHoliday returns a HolidayRule for a holiday on the n-th weekday wd of
+// the given month, like Thanksgiving on the fourth Thursday of November. If n
+// is negative, it counts from the end of the month, as in [NthInMonth].
+func WeekdayHoliday(name string, month time.Month, n int, wd time.Weekday, observe Observance) HolidayRule {
+	adj := NthInMonth(n, wd)
+	return HolidayRule{
+		Name: name,
+		Date: func(year int) (Date, bool) {
+			return adj.Adjust(Of(year, month, 1)), true
+		},
+		Observe: observe,
+	}
+}
+
+// EasterHoliday returns a HolidayRule for a holiday offset days from Easter
+// Sunday, like Good Friday (offset -2) or Whit Monday (offset 50).
+func EasterHoliday(name string, offset int, observe Observance) HolidayRule {
+	return HolidayRule{
+		Name: name,
+		Date: func(year int) (Date, bool) {
+			return Easter(year) + Date(offset), true
+		},
+		Observe: observe,
+	}
+}
+
+// OneTimeHoliday returns a HolidayRule for a holiday occurring only once, on
+// d, like a state funeral.
+func OneTimeHoliday(name string, d Date, observe Observance) HolidayRule {
+	return HolidayRule{
+		Name: name,
+		Date: func(year int) (Date, bool) {
+			return d, year == d.Year()
+		},
+		Observe: observe,
+	}
+}
+
+// RuleHoliday returns a HolidayRule for a holiday given by a yearly [Rule],
+// like "Wednesday before November 23". It panics if r is not yearly.
+func RuleHoliday(name string, r *Rule, observe Observance) HolidayRule {
+	if !r.Yearly() {
+		panic("date: rule of holiday " + name + " is not yearly")
+	}
+	return HolidayRule{
+		Name: name,
+		Date: func(year int) (Date, bool) {
+			return r.date(year, time.January)
+		},
+		Observe: observe,
+	}
+}
+
+// Easter returns the date of Easter Sunday in the given year, as computed for
+// the Gregorian calendar by Western churches.
+func Easter(year int) Date {
+	// Anonymous Gregorian algorithm, see
+	// https://en.wikipedia.org/wiki/Date_of_Easter#Anonymous_Gregorian_algorithm
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return Of(year, time.Month(month), day)
+}
+
+// A HolidayCalendar is a Calendar defined by a list of rules. The holidays of
+// each year are computed once and then cached.
+//
+// A HolidayCalendar must not be copied and its fields must not be modified
+// after first use.
+type HolidayCalendar struct {
+	// Rules are the holidays of the calendar.
+	Rules []HolidayRule
+
+	mu    sync.Mutex
+	years map[int][]Holiday
+}
+
+// NewHolidayCalendar returns a HolidayCalendar with the given rules.
+func NewHolidayCalendar(rules ...HolidayRule) *HolidayCalendar {
+	return &HolidayCalendar{Rules: rules}
+}
+
+// Holidays implements Calendar.
+//
+// Observance rules are applied in order of the nominal dates of the holidays.
+// A date is considered taken by another holiday, if it is its nominal date or
+// the date on which an earlier holiday is observed.
+func (c *HolidayCalendar) Holidays(year int) []Holiday {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hs, ok := c.years[year]
+	if !ok {
+		hs = c.compute(year)
+		if c.years == nil {
+			c.years = make(map[int][]Holiday)
+		}
+		c.years[year] = hs
+	}
+	return slices.Clone(hs)
+}
+
+func (c *HolidayCalendar) compute(year int) []Holiday {
+	type entry struct {
+		h       Holiday
+		observe Observance
+	}
+	var es []entry
+	for _, r := range c.Rules {
+		if (r.FirstYear != 0 && year < r.FirstYear) || (r.LastYear != 0 && year > r.LastYear) {
+			continue
+		}
+		if d, ok := r.Date(year); ok {
+			es = append(es, entry{Holiday{r.Name, d, d}, r.Observe})
+		}
+	}
+	slices.SortStableFunc(es, func(a, b entry) int {
+		return cmp.Compare(a.h.Date, b.h.Date)
+	})
+	observed := make(map[Date]bool)
+	for i := range es {
+		e := &es[i]
+		if e.observe != nil {
+			taken := func(d Date) bool {
+				if observed[d] {
+					return true
+				}
+				for j := range es {
+					if j != i && es[j].h.Date == d {
+						return true
+					}
+				}
+				return false
+			}
+			e.h.Observed = e.observe(e.h.Date, taken)
+		}
+		observed[e.h.Observed] = true
+	}
+	hs := make([]Holiday, len(es))
+	for i, e := range es {
+		hs[i] = e.h
+	}
+	slices.SortStableFunc(hs, func(a, b Holiday) int {
+		return cmp.Compare(a.Observed, b.Observed)
+	})
+	return hs
+}
+
+// An Observance determines the date on which a holiday is observed, given its
+// nominal date d. taken reports whether a date is already taken by another
+// holiday of the same calendar.
+type Observance func(d Date, taken func(Date) bool) Date
+
+// ObserveNearestWeekday observes a holiday falling on a Saturday on the
+// preceding Friday and one falling on a Sunday on the following Monday. This
+// is the rule used for US federal holidays.
+func ObserveNearestWeekday(d Date, taken func(Date) bool) Date {
+	switch d.Weekday() {
+	case time.Saturday:
+		return d - 1
+	case time.Sunday:
+		return d + 1
+	}
+	return d
+}
+
+// ObserveNextWeekday observes a holiday falling on a weekend on the next
+// weekday not taken by another holiday. This is the "substitute day" rule used
+// in the United Kingdom: if Christmas Day falls on a Saturday and Boxing Day
+// on a Sunday, they are observed on Monday and Tuesday.
+func ObserveNextWeekday(d Date, taken func(Date) bool) Date {
+	if !Weekend.Contains(d.Weekday()) {
+		return d
+	}
+	d++
+	for Weekend.Contains(d.Weekday()) || taken(d) {
+		d++
+	}
+	return d
+}
+
+// ObserveSundayToNext observes a holiday falling on a Sunday on the next day
+// not taken by another holiday. This is the substitute holiday rule used in
+// Japan.
+func ObserveSundayToNext(d Date, taken func(Date) bool) Date {
+	if d.Weekday() != time.Sunday {
+		return d
+	}
+	d++
+	for taken(d) {
+		d++
+	}
+	return d
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEaster(t *testing.T) {
+	t.Parallel()
+	tcs := []Date{
+		Of(1961, 4, 2),
+		Of(2000, 4, 23),
+		Of(2008, 3, 23),
+		Of(2011, 4, 24),
+		Of(2019, 4, 21),
+		Of(2024, 3, 31),
+		Of(2025, 4, 20),
+		Of(2038, 4, 25),
+	}
+	for _, want := range tcs {
+		if got := Easter(want.Year()); got != want {
+			t.Errorf("Easter(%d) = %v, want %v", want.Year(), got, want)
+		}
+	}
+}
+
+func TestHolidayCalendar(t *testing.T) {
+	t.Parallel()
+	uk := NewHolidayCalendar(
+		FixedHoliday("New Year's Day", time.January, 1, ObserveNextWeekday),
+		EasterHoliday("Good Friday", -2, nil),
+		WeekdayHoliday("Spring bank holiday", time.May, -1, time.Monday, nil),
+		FixedHoliday("Christmas Day", time.December, 25, ObserveNextWeekday),
+		FixedHoliday("Boxing Day", time.December, 26, ObserveNextWeekday),
+	)
+	us := NewHolidayCalendar(
+		FixedHoliday("New Year's Day", time.January, 1, ObserveNearestWeekday),
+		HolidayRule{
+			Name:      "Juneteenth",
+			Date:      func(y int) (Date, bool) { return Of(y, time.June, 19), true },
+			FirstYear: 2021,
+			Observe:   ObserveNearestWeekday,
+		},
+		WeekdayHoliday("Thanksgiving Day", time.November, 4, time.Thursday, nil),
+		OneTimeHoliday("Day of Mourning", Of(2018, 12, 5), nil),
+	)
+	jp := NewHolidayCalendar(
+		FixedHoliday("Constitution Memorial Day", time.May, 3, ObserveSundayToNext),
+		FixedHoliday("Greenery Day", time.May, 4, ObserveSundayToNext),
+		FixedHoliday("Children's Day", time.May, 5, ObserveSundayToNext),
+	)
+	tcs := []struct {
+		name string
+		cal  Calendar
+		year int
+		want []Holiday
+	}{
+		{"UK", uk, 2021, []Holiday{
+			{"New Year's Day", Of(2021, 1, 1), Of(2021, 1, 1)},
+			{"Good Friday", Of(2021, 4, 2), Of(2021, 4, 2)},
+			{"Spring bank holiday", Of(2021, 5, 31), Of(2021, 5, 31)},
+			{"Christmas Day", Of(2021, 12, 25), Of(2021, 12, 27)},
+			{"Boxing Day", Of(2021, 12, 26), Of(2021, 12, 28)},
+		}},
+		{"UK", uk, 2022, []Holiday{
+			{"New Year's Day", Of(2022, 1, 1), Of(2022, 1, 3)},
+			{"Good Friday", Of(2022, 4, 15), Of(2022, 4, 15)},
+			{"Spring bank holiday", Of(2022, 5, 30), Of(2022, 5, 30)},
+			{"Boxing Day", Of(2022, 12, 26), Of(2022, 12, 26)},
+			{"Christmas Day", Of(2022, 12, 25), Of(2022, 12, 27)},
+		}},
+		{"US", us, 2018, []Holiday{
+			{"New Year's Day", Of(2018, 1, 1), Of(2018, 1, 1)},
+			{"Thanksgiving Day", Of(2018, 11, 22), Of(2018, 11, 22)},
+			{"Day of Mourning", Of(2018, 12, 5), Of(2018, 12, 5)},
+		}},
+		{"US", us, 2020, []Holiday{
+			{"New Year's Day", Of(2020, 1, 1), Of(2020, 1, 1)},
+			{"Thanksgiving Day", Of(2020, 11, 26), Of(2020, 11, 26)},
+		}},
+		{"US", us, 2022, []Holiday{
+			{"New Year's Day", Of(2022, 1, 1), Of(2021, 12, 31)},
+			{"Juneteenth", Of(2022, 6, 19), Of(2022, 6, 20)},
+			{"Thanksgiving Day", Of(2022, 11, 24), Of(2022, 11, 24)},
+		}},
+		{"JP", jp, 2008, []Holiday{
+			{"Constitution Memorial Day", Of(2008, 5, 3), Of(2008, 5, 3)},
+			{"Children's Day", Of(2008, 5, 5), Of(2008, 5, 5)},
+			{"Greenery Day", Of(2008, 5, 4), Of(2008, 5, 6)},
+		}},
+	}
+	for _, tc := range tcs {
+		got := tc.cal.Holidays(tc.year)
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s.Holidays(%d) = %v, want %v", tc.name, tc.year, got, tc.want)
+		}
+		// The result must not alias the cache.
+		if len(got) > 0 {
+			got[0].Name = "modified"
+			if again := tc.cal.Holidays(tc.year); !slices.Equal(again, tc.want) {
+				t.Errorf("%s.Holidays(%d) after modification = %v, want %v", tc.name, tc.year, again, tc.want)
+			}
+		}
+	}
+}
+
+func TestHolidaysIn(t *testing.T) {
+	t.Parallel()
+	us := NewHolidayCalendar(
+		FixedHoliday("New Year's Day", time.January, 1, ObserveNearestWeekday),
+		FixedHoliday("Christmas Day", time.December, 25, ObserveNearestWeekday),
+	)
+	r := Range{Of(2021, 12, 1), Of(2022, 2, 1)}
+	want := []Holiday{
+		{"Christmas Day", Of(2021, 12, 25), Of(2021, 12, 24)},
+		{"New Year's Day", Of(2022, 1, 1), Of(2021, 12, 31)},
+	}
+	if got := HolidaysIn(us, r); !slices.Equal(got, want) {
+		t.Errorf("HolidaysIn(us, %v) = %v, want %v", r, got, want)
+	}
+	if got := HolidaysIn(us, Range{}); got != nil {
+		t.Errorf("HolidaysIn(us, %v) = %v, want nil", Range{}, got)
+	}
+	for _, tc := range []struct {
+		d    Date
+		want string
+		ok   bool
+	}{
+		{Of(2021, 12, 31), "New Year's Day", true},
+		{Of(2021, 12, 24), "Christmas Day", true},
+		{Of(2021, 12, 25), "", false},
+		{Of(2022, 1, 1), "", false},
+		{Of(2024, 1, 1), "New Year's Day", true},
+	} {
+		h, ok := HolidayOn(us, tc.d)
+		if h.Name != tc.want || ok != tc.ok {
+			t.Errorf("HolidayOn(us, %v) = %q, %v, want %q, %v", tc.d, h.Name, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestMergeCalendars(t *testing.T) {
+	t.Parallel()
+	a := NewHolidayCalendar(
+		FixedHoliday("New Year's Day", time.January, 1, nil),
+		FixedHoliday("Independence Day", time.July, 4, ObserveNearestWeekday),
+	)
+	b := NewHolidayCalendar(
+		FixedHoliday("New Year's Day", time.January, 1, nil),
+		FixedHoliday("Bastille Day", time.July, 14, nil),
+		FixedHoliday("Summer Holiday", time.July, 5, nil),
+	)
+	m := MergeCalendars(a, b)
+	// In 2026, Independence Day is observed on Friday, July 3rd.
+	want := []Holiday{
+		{"New Year's Day", Of(2026, 1, 1), Of(2026, 1, 1)},
+		{"Independence Day", Of(2026, 7, 4), Of(2026, 7, 3)},
+		{"Summer Holiday", Of(2026, 7, 5), Of(2026, 7, 5)},
+		{"Bastille Day", Of(2026, 7, 14), Of(2026, 7, 14)},
+	}
+	if got := m.Holidays(2026); !slices.Equal(got, want) {
+		t.Errorf("Holidays(2026) = %v, want %v", got, want)
+	}
+	sources := []struct {
+		d    Date
+		want []int
+	}{
+		{Of(2026, 1, 1), []int{0, 1}},
+		{Of(2026, 7, 3), []int{0}},
+		{Of(2026, 7, 4), nil},
+		{Of(2026, 7, 14), []int{1}},
+		{Of(2026, 7, 15), nil},
+	}
+	for _, tc := range sources {
+		if got := m.Sources(tc.d); !slices.Equal(got, tc.want) {
+			t.Errorf("Sources(%v) = %v, want %v", tc.d, got, tc.want)
+		}
+	}
+	c := NewBusinessCalendar(Weekend, m)
+	if got, want := c.NextBusinessDay(Of(2026, 7, 2)), Of(2026, 7, 6); got != want {
+		t.Errorf("NextBusinessDay(%v) = %v, want %v", Of(2026, 7, 2), got, want)
+	}
+}
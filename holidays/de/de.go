@@ -0,0 +1,112 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package de provides the public holidays of Germany.
+//
+// Public holidays in Germany are mostly defined by the states (Länder). The
+// calendars reflect the rules in effect since German reunification in 1990.
+// Holidays which are only observed in parts of a state, like the Assumption
+// of Mary in predominantly Catholic communities of Bavaria, are not included.
+// Holidays are never moved when they fall on a weekend.
+package de
+
+import (
+	"slices"
+	"time"
+
+	"gonih.org/date"
+)
+
+var national = []date.HolidayRule{
+	date.FixedHoliday("New Year's Day", time.January, 1, nil),
+	date.EasterHoliday("Good Friday", -2, nil),
+	date.EasterHoliday("Easter Monday", 1, nil),
+	date.FixedHoliday("Labour Day", time.May, 1, nil),
+	date.EasterHoliday("Ascension Day", 39, nil),
+	date.EasterHoliday("Whit Monday", 50, nil),
+	date.FixedHoliday("German Unity Day", time.October, 3, nil),
+	date.FixedHoliday("Christmas Day", time.December, 25, nil),
+	date.FixedHoliday("Second Day of Christmas", time.December, 26, nil),
+}
+
+// reformation2017 is the 500th anniversary of the Reformation, which was a
+// holiday in all states.
+var reformation2017 = date.OneTimeHoliday("Reformation Day", date.Of(2017, time.October, 31), nil)
+
+// National contains the holidays observed in all of Germany.
+var National = date.NewHolidayCalendar(append(national, reformation2017)...)
+
+var (
+	epiphany       = date.FixedHoliday("Epiphany", time.January, 6, nil)
+	womensDay      = date.FixedHoliday("International Women's Day", time.March, 8, nil)
+	easterSunday   = date.EasterHoliday("Easter Sunday", 0, nil)
+	whitSunday     = date.EasterHoliday("Whit Sunday", 49, nil)
+	corpusChristi  = date.EasterHoliday("Corpus Christi", 60, nil)
+	assumption     = date.FixedHoliday("Assumption Day", time.August, 15, nil)
+	childrensDay   = date.FixedHoliday("World Children's Day", time.September, 20, nil)
+	reformationDay = date.FixedHoliday("Reformation Day", time.October, 31, nil)
+	allSaints      = date.FixedHoliday("All Saints' Day", time.November, 1, nil)
+)
+
+// repentance is the Day of Repentance and Prayer, the Wednesday before
+// November 23rd.
+var repentance = date.HolidayRule{
+	Name: "Day of Repentance and Prayer",
+	Date: func(year int) (date.Date, bool) {
+		return date.Previous(time.Wednesday).Adjust(date.Of(year, time.November, 23)), true
+	},
+}
+
+// states maps ISO 3166-2 codes to the holidays of each state, in addition to
+// the national ones.
+var states = map[string][]date.HolidayRule{
+	"BW": {epiphany, corpusChristi, allSaints},
+	"BY": {epiphany, corpusChristi, allSaints},
+	"BE": {
+		since(2019, womensDay),
+		date.OneTimeHoliday("Liberation Day", date.Of(2020, time.May, 8), nil),
+		date.OneTimeHoliday("Liberation Day", date.Of(2025, time.May, 8), nil),
+	},
+	"BB": {easterSunday, whitSunday, reformationDay},
+	"HB": {since(2018, reformationDay)},
+	"HH": {since(2018, reformationDay)},
+	"HE": {corpusChristi},
+	"MV": {since(2023, womensDay), reformationDay},
+	"NI": {since(2018, reformationDay)},
+	"NW": {corpusChristi, allSaints},
+	"RP": {corpusChristi, allSaints},
+	"SL": {corpusChristi, assumption, allSaints},
+	"SN": {reformationDay, repentance},
+	"ST": {epiphany, reformationDay},
+	"SH": {since(2018, reformationDay)},
+	"TH": {since(2019, childrensDay), reformationDay},
+}
+
+var calendars = make(map[string]*date.HolidayCalendar)
+
+func init() {
+	for code, rules := range states {
+		all := slices.Concat(national, rules)
+		// States observing Reformation Day every year must not get it twice.
+		if !slices.ContainsFunc(rules, func(r date.HolidayRule) bool {
+			return r.Name == reformationDay.Name && r.FirstYear == 0
+		}) {
+			all = append(all, reformation2017)
+		}
+		calendars[code] = date.NewHolidayCalendar(all...)
+	}
+}
+
+// State returns the calendar of the state with the given ISO 3166-2 code,
+// without the "DE-" prefix, like "BY" for Bavaria. It returns nil, if code is
+// not a German state.
+func State(code string) *date.HolidayCalendar {
+	return calendars[code]
+}
+
+func since(year int, r date.HolidayRule) date.HolidayRule {
+	r.FirstYear = year
+	return r
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package de
+
+import (
+	"slices"
+	"testing"
+
+	"gonih.org/date"
+	"gonih.org/date/datetest"
+)
+
+func TestCalendars(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		name string
+		cal  *date.HolidayCalendar
+		year int
+		want []string
+	}{
+		{"National", National, 2024, []string{
+			"2024-01-01", "2024-03-29", "2024-04-01", "2024-05-01", "2024-05-09",
+			"2024-05-20", "2024-10-03", "2024-12-25", "2024-12-26",
+		}},
+		{"National", National, 2017, []string{
+			"2017-01-01", "2017-04-14", "2017-04-17", "2017-05-01", "2017-05-25",
+			"2017-06-05", "2017-10-03", "2017-10-31", "2017-12-25", "2017-12-26",
+		}},
+		{"BY", State("BY"), 2024, []string{
+			"2024-01-01", "2024-01-06", "2024-03-29", "2024-04-01", "2024-05-01",
+			"2024-05-09", "2024-05-20", "2024-05-30", "2024-10-03", "2024-11-01",
+			"2024-12-25", "2024-12-26",
+		}},
+		{"SN", State("SN"), 2017, []string{
+			"2017-01-01", "2017-04-14", "2017-04-17", "2017-05-01", "2017-05-25",
+			"2017-06-05", "2017-10-03", "2017-10-31", "2017-11-22", "2017-12-25",
+			"2017-12-26",
+		}},
+		{"BE", State("BE"), 2020, []string{
+			"2020-01-01", "2020-03-08", "2020-04-10", "2020-04-13", "2020-05-01",
+			"2020-05-08", "2020-05-21", "2020-06-01", "2020-10-03", "2020-12-25",
+			"2020-12-26",
+		}},
+		{"HH", State("HH"), 2017, []string{
+			"2017-01-01", "2017-04-14", "2017-04-17", "2017-05-01", "2017-05-25",
+			"2017-06-05", "2017-10-03", "2017-10-31", "2017-12-25", "2017-12-26",
+		}},
+		{"HH", State("HH"), 2018, []string{
+			"2018-01-01", "2018-03-30", "2018-04-02", "2018-05-01", "2018-05-10",
+			"2018-05-21", "2018-10-03", "2018-10-31", "2018-12-25", "2018-12-26",
+		}},
+		{"BB", State("BB"), 2024, []string{
+			"2024-01-01", "2024-03-29", "2024-03-31", "2024-04-01", "2024-05-01",
+			"2024-05-09", "2024-05-19", "2024-05-20", "2024-10-03", "2024-10-31",
+			"2024-12-25", "2024-12-26",
+		}},
+	}
+	for _, tc := range tcs {
+		var want []date.Date
+		for _, s := range tc.want {
+			want = append(want, datetest.MustDate(s))
+		}
+		var got []date.Date
+		for _, h := range tc.cal.Holidays(tc.year) {
+			got = append(got, h.Observed)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s.Holidays(%d) = %v, want %v", tc.name, tc.year, got, want)
+		}
+	}
+}
+
+func TestState(t *testing.T) {
+	t.Parallel()
+	for _, code := range []string{"BW", "BY", "BE", "BB", "HB", "HH", "HE", "MV", "NI", "NW", "RP", "SL", "SN", "ST", "SH", "TH"} {
+		if State(code) == nil {
+			t.Errorf("State(%q) = nil", code)
+		}
+	}
+	if c := State("XX"); c != nil {
+		t.Errorf("State(%q) = %v, want nil", "XX", c)
+	}
+}
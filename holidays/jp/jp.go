@@ -0,0 +1,151 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package jp provides the public holidays of Japan.
+//
+// The calendar reflects the Act on National Holidays as amended since 2000,
+// including the special arrangements for the imperial succession in 2019 and
+// the Olympic Games in 2020 and 2021. The dates of the equinoxes are computed
+// using an approximation which is valid for the years 1980 to 2099. They are
+// officially announced in February of the preceding year.
+package jp
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+
+	"gonih.org/date"
+)
+
+// National contains the national holidays of Japan.
+//
+// If a holiday falls on a Sunday, the next day which is not a holiday is a
+// substitute holiday. A day between two holidays is a citizens' holiday.
+var National date.Calendar = calendar{date.NewHolidayCalendar(rules(
+	fixed("New Year's Day", time.January, 1),
+	weekday("Coming of Age Day", time.January, 2, time.Monday),
+	fixed("National Foundation Day", time.February, 11),
+	fixed("Emperor's Birthday", time.February, 23).since(2020),
+	fixed("Emperor's Birthday", time.December, 23).until(2018),
+	rule("Vernal Equinox Day", func(year int) date.Date {
+		return date.Of(year, time.March, equinox(20.8431, year))
+	}),
+	fixed("Greenery Day", time.April, 29).until(2006),
+	fixed("Shōwa Day", time.April, 29).since(2007),
+	fixed("Constitution Memorial Day", time.May, 3),
+	fixed("Greenery Day", time.May, 4).since(2007),
+	fixed("Children's Day", time.May, 5),
+	fixed("Marine Day", time.July, 20).until(2002),
+	olympic(weekday("Marine Day", time.July, 3, time.Monday).since(2003),
+		date.Of(2020, time.July, 23), date.Of(2021, time.July, 22)),
+	olympic(fixed("Mountain Day", time.August, 11).since(2016),
+		date.Of(2020, time.August, 10), date.Of(2021, time.August, 8)),
+	fixed("Respect for the Aged Day", time.September, 15).until(2002),
+	weekday("Respect for the Aged Day", time.September, 3, time.Monday).since(2003),
+	rule("Autumnal Equinox Day", func(year int) date.Date {
+		return date.Of(year, time.September, equinox(23.2488, year))
+	}),
+	weekday("Health and Sports Day", time.October, 2, time.Monday).until(2019),
+	olympic(weekday("Sports Day", time.October, 2, time.Monday).since(2020),
+		date.Of(2020, time.July, 24), date.Of(2021, time.July, 23)),
+	fixed("Culture Day", time.November, 3),
+	fixed("Labour Thanksgiving Day", time.November, 23),
+	holiday(date.OneTimeHoliday("Enthronement Day", date.Of(2019, time.May, 1), nil)),
+	holiday(date.OneTimeHoliday("Enthronement Ceremony Day", date.Of(2019, time.October, 22), nil)),
+)...)}
+
+// calendar adds the substitute holidays for holidays falling on a Sunday and
+// the citizens' holidays between two national holidays. Unlike in other
+// countries, a holiday falling on a Sunday is still a holiday.
+type calendar struct {
+	*date.HolidayCalendar
+}
+
+func (c calendar) Holidays(year int) []date.Holiday {
+	hs := c.HolidayCalendar.Holidays(year)
+	nominal := make(map[date.Date]bool)
+	observed := make(map[date.Date]bool)
+	for i, h := range hs {
+		nominal[h.Date] = true
+		observed[h.Observed] = true
+		if h.Observed != h.Date {
+			hs[i].Observed = h.Date
+			hs = append(hs, date.Holiday{Name: "Substitute Holiday", Date: h.Date, Observed: h.Observed})
+		}
+	}
+	for _, h := range hs {
+		d := h.Date + 1
+		if nominal[d+1] && !nominal[d] && !observed[d] && d.Weekday() != time.Sunday {
+			hs = append(hs, date.Holiday{Name: "Citizens' Holiday", Date: d, Observed: d})
+		}
+	}
+	slices.SortStableFunc(hs, func(a, b date.Holiday) int {
+		return cmp.Compare(a.Observed, b.Observed)
+	})
+	return hs
+}
+
+// equinox returns the day of the month of an equinox in the given year, where
+// base is the fractional day in 1980.
+func equinox(base float64, year int) int {
+	y := year - 1980
+	return int(math.Floor(base + 0.242194*float64(y) - math.Floor(float64(y)/4)))
+}
+
+// holiday is a date.HolidayRule with some convenience methods. All holidays
+// are observed on the next non-holiday, if they fall on a Sunday.
+type holiday date.HolidayRule
+
+func rules(hs ...holiday) []date.HolidayRule {
+	rs := make([]date.HolidayRule, len(hs))
+	for i, h := range hs {
+		rs[i] = date.HolidayRule(h)
+	}
+	return rs
+}
+
+func rule(name string, f func(year int) date.Date) holiday {
+	return holiday{
+		Name:    name,
+		Date:    func(year int) (date.Date, bool) { return f(year), true },
+		Observe: date.ObserveSundayToNext,
+	}
+}
+
+func fixed(name string, month time.Month, day int) holiday {
+	return holiday(date.FixedHoliday(name, month, day, date.ObserveSundayToNext))
+}
+
+func weekday(name string, month time.Month, n int, wd time.Weekday) holiday {
+	return holiday(date.WeekdayHoliday(name, month, n, wd, date.ObserveSundayToNext))
+}
+
+func (h holiday) since(year int) holiday {
+	h.FirstYear = year
+	return h
+}
+
+func (h holiday) until(year int) holiday {
+	h.LastYear = year
+	return h
+}
+
+// olympic moves h to the given dates in 2020 and 2021, for the Tokyo Olympic
+// Games.
+func olympic(h holiday, d2020, d2021 date.Date) holiday {
+	f := h.Date
+	h.Date = func(year int) (date.Date, bool) {
+		switch year {
+		case 2020:
+			return d2020, true
+		case 2021:
+			return d2021, true
+		}
+		return f(year)
+	}
+	return h
+}
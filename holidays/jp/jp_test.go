@@ -0,0 +1,72 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jp
+
+import (
+	"slices"
+	"testing"
+
+	"gonih.org/date"
+	"gonih.org/date/datetest"
+)
+
+func TestNational(t *testing.T) {
+	t.Parallel()
+	// https://www8.cao.go.jp/chosei/shukujitsu/gaiyou.html
+	tcs := []struct {
+		year int
+		want []string
+	}{
+		{2009, []string{
+			"2009-01-01", "2009-01-12", "2009-02-11", "2009-03-20", "2009-04-29",
+			"2009-05-03", "2009-05-04", "2009-05-05", "2009-05-06", "2009-07-20",
+			"2009-09-21", "2009-09-22", "2009-09-23", "2009-10-12", "2009-11-03",
+			"2009-11-23", "2009-12-23",
+		}},
+		{2019, []string{
+			"2019-01-01", "2019-01-14", "2019-02-11", "2019-03-21", "2019-04-29",
+			"2019-04-30", "2019-05-01", "2019-05-02", "2019-05-03", "2019-05-04",
+			"2019-05-05", "2019-05-06", "2019-07-15", "2019-08-11", "2019-08-12",
+			"2019-09-16", "2019-09-23", "2019-10-14", "2019-10-22", "2019-11-03",
+			"2019-11-04", "2019-11-23",
+		}},
+		{2021, []string{
+			"2021-01-01", "2021-01-11", "2021-02-11", "2021-02-23", "2021-03-20",
+			"2021-04-29", "2021-05-03", "2021-05-04", "2021-05-05", "2021-07-22",
+			"2021-07-23", "2021-08-08", "2021-08-09", "2021-09-20", "2021-09-23",
+			"2021-11-03", "2021-11-23",
+		}},
+		{2024, []string{
+			"2024-01-01", "2024-01-08", "2024-02-11", "2024-02-12", "2024-02-23",
+			"2024-03-20", "2024-04-29", "2024-05-03", "2024-05-04", "2024-05-05",
+			"2024-05-06", "2024-07-15", "2024-08-11", "2024-08-12", "2024-09-16",
+			"2024-09-22", "2024-09-23", "2024-10-14", "2024-11-03", "2024-11-04",
+			"2024-11-23",
+		}},
+		{2026, []string{
+			"2026-01-01", "2026-01-12", "2026-02-11", "2026-02-23", "2026-03-20",
+			"2026-04-29", "2026-05-03", "2026-05-04", "2026-05-05", "2026-05-06",
+			"2026-07-20", "2026-08-11", "2026-09-21", "2026-09-22", "2026-09-23",
+			"2026-10-12", "2026-11-03", "2026-11-23",
+		}},
+	}
+	for _, tc := range tcs {
+		var want []date.Date
+		for _, s := range tc.want {
+			want = append(want, datetest.MustDate(s))
+		}
+		var got []date.Date
+		for _, h := range National.Holidays(tc.year) {
+			got = append(got, h.Observed)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("National.Holidays(%d) = %v, want %v", tc.year, got, want)
+		}
+	}
+	if h, ok := date.HolidayOn(National, datetest.MustDate("2026-09-22")); !ok || h.Name != "Citizens' Holiday" {
+		t.Errorf("HolidayOn(National, 2026-09-22) = %v, %v, want Citizens' Holiday", h, ok)
+	}
+}
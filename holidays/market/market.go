@@ -0,0 +1,108 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package market provides the holidays of major exchanges and settlement
+// systems, on which they are closed for the whole day. Early closes are not
+// included.
+//
+// The calendars reflect the published holiday schedules since 2000 and
+// include unscheduled closures, like national days of mourning or closures
+// caused by natural disasters. As those are added when they occur, the data
+// is versioned by [Version].
+package market
+
+import (
+	"time"
+
+	"gonih.org/date"
+	"gonih.org/date/holidays/jp"
+	"gonih.org/date/holidays/uk"
+)
+
+// Version identifies the state of the data in this package. It changes
+// whenever a closure is added or a rule is corrected.
+const Version = "2025.1"
+
+// NYSE contains the holidays of the New York Stock Exchange.
+//
+// A holiday falling on a Sunday is observed on the following Monday. A
+// holiday falling on a Saturday is observed on the preceding Friday, unless
+// that Friday is the last trading day of a month, as per NYSE Rule 7.2. In
+// that case, it is observed on the Saturday and the exchange is not closed.
+// In practice, this only affects New Year's Day.
+var NYSE date.Calendar = date.NewHolidayCalendar(usRules()...)
+
+// NASDAQ contains the holidays of the Nasdaq Stock Market. They are the same
+// as those of the [NYSE].
+var NASDAQ date.Calendar = date.NewHolidayCalendar(usRules()...)
+
+// LSE contains the holidays of the London Stock Exchange, which is closed on
+// the bank holidays of England and Wales.
+var LSE date.Calendar = uk.EnglandAndWales
+
+// TARGET2 contains the closing days of TARGET2, the real-time gross
+// settlement system of the Eurosystem, which determine the settlement days
+// for the euro. The rules are those in effect since 2002. Holidays are not
+// moved, if they fall on a weekend.
+var TARGET2 date.Calendar = date.NewHolidayCalendar(
+	date.FixedHoliday("New Year's Day", time.January, 1, nil),
+	date.EasterHoliday("Good Friday", -2, nil),
+	date.EasterHoliday("Easter Monday", 1, nil),
+	date.FixedHoliday("Labour Day", time.May, 1, nil),
+	date.FixedHoliday("Christmas Day", time.December, 25, nil),
+	date.FixedHoliday("Christmas Holiday", time.December, 26, nil),
+)
+
+// TSE contains the holidays of the Tokyo Stock Exchange, which is closed on
+// the national holidays of Japan, as well as from December 31st to January
+// 3rd.
+var TSE date.Calendar = date.MergeCalendars(jp.National, date.NewHolidayCalendar(
+	date.FixedHoliday("Bank Holiday", time.January, 2, nil),
+	date.FixedHoliday("Bank Holiday", time.January, 3, nil),
+	date.FixedHoliday("Bank Holiday", time.December, 31, nil),
+	date.OneTimeHoliday("System Failure", date.Of(2020, time.October, 1), nil),
+))
+
+// usRules returns the holiday rules of the NYSE and Nasdaq.
+func usRules() []date.HolidayRule {
+	return []date.HolidayRule{
+		date.FixedHoliday("New Year's Day", time.January, 1, observeNYSE),
+		since(1998, date.WeekdayHoliday("Martin Luther King Jr. Day", time.January, 3, time.Monday, nil)),
+		date.WeekdayHoliday("Washington's Birthday", time.February, 3, time.Monday, nil),
+		date.EasterHoliday("Good Friday", -2, nil),
+		date.WeekdayHoliday("Memorial Day", time.May, -1, time.Monday, nil),
+		since(2022, date.FixedHoliday("Juneteenth National Independence Day", time.June, 19, observeNYSE)),
+		date.FixedHoliday("Independence Day", time.July, 4, observeNYSE),
+		date.WeekdayHoliday("Labor Day", time.September, 1, time.Monday, nil),
+		date.WeekdayHoliday("Thanksgiving Day", time.November, 4, time.Thursday, nil),
+		date.FixedHoliday("Christmas Day", time.December, 25, observeNYSE),
+
+		date.OneTimeHoliday("September 11 Attacks", date.Of(2001, time.September, 11), nil),
+		date.OneTimeHoliday("September 11 Attacks", date.Of(2001, time.September, 12), nil),
+		date.OneTimeHoliday("September 11 Attacks", date.Of(2001, time.September, 13), nil),
+		date.OneTimeHoliday("September 11 Attacks", date.Of(2001, time.September, 14), nil),
+		date.OneTimeHoliday("National Day of Mourning for Ronald Reagan", date.Of(2004, time.June, 11), nil),
+		date.OneTimeHoliday("National Day of Mourning for Gerald Ford", date.Of(2007, time.January, 2), nil),
+		date.OneTimeHoliday("Hurricane Sandy", date.Of(2012, time.October, 29), nil),
+		date.OneTimeHoliday("Hurricane Sandy", date.Of(2012, time.October, 30), nil),
+		date.OneTimeHoliday("National Day of Mourning for George H. W. Bush", date.Of(2018, time.December, 5), nil),
+		date.OneTimeHoliday("National Day of Mourning for Jimmy Carter", date.Of(2025, time.January, 9), nil),
+	}
+}
+
+// observeNYSE implements NYSE Rule 7.2: Like date.ObserveNearestWeekday,
+// except that a holiday falling on a Saturday is not observed on the
+// preceding Friday, if that is the last day of a month.
+func observeNYSE(d date.Date, taken func(date.Date) bool) date.Date {
+	if d.Weekday() == time.Saturday && (d - 1).IsLastOfMonth() {
+		return d
+	}
+	return date.ObserveNearestWeekday(d, taken)
+}
+
+func since(year int, r date.HolidayRule) date.HolidayRule {
+	r.FirstYear = year
+	return r
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package market
+
+import (
+	"slices"
+	"testing"
+
+	"gonih.org/date"
+	"gonih.org/date/datetest"
+)
+
+func TestCalendars(t *testing.T) {
+	t.Parallel()
+	// The published closures on weekdays, in the given year.
+	tcs := []struct {
+		name string
+		cal  date.Calendar
+		year int
+		want []string
+	}{
+		// https://www.nyse.com/markets/hours-calendars
+		{"NYSE", NYSE, 2001, []string{
+			"2001-01-01", "2001-01-15", "2001-02-19", "2001-04-13", "2001-05-28",
+			"2001-07-04", "2001-09-03", "2001-09-11", "2001-09-12", "2001-09-13",
+			"2001-09-14", "2001-11-22", "2001-12-25",
+		}},
+		{"NYSE", NYSE, 2022, []string{
+			"2022-01-17", "2022-02-21", "2022-04-15", "2022-05-30", "2022-06-20",
+			"2022-07-04", "2022-09-05", "2022-11-24", "2022-12-26",
+		}},
+		{"NYSE", NYSE, 2025, []string{
+			"2025-01-01", "2025-01-09", "2025-01-20", "2025-02-17", "2025-04-18",
+			"2025-05-26", "2025-06-19", "2025-07-04", "2025-09-01", "2025-11-27",
+			"2025-12-25",
+		}},
+		{"NASDAQ", NASDAQ, 2026, []string{
+			"2026-01-01", "2026-01-19", "2026-02-16", "2026-04-03", "2026-05-25",
+			"2026-06-19", "2026-07-03", "2026-09-07", "2026-11-26", "2026-12-25",
+		}},
+		{"NYSE", NYSE, 2027, []string{
+			"2027-01-01", "2027-01-18", "2027-02-15", "2027-03-26", "2027-05-31",
+			"2027-06-18", "2027-07-05", "2027-09-06", "2027-11-25", "2027-12-24",
+		}},
+		// https://www.londonstockexchange.com/equities-trading/business-days
+		{"LSE", LSE, 2022, []string{
+			"2022-01-03", "2022-04-15", "2022-04-18", "2022-05-02", "2022-06-02",
+			"2022-06-03", "2022-08-29", "2022-09-19", "2022-12-26", "2022-12-27",
+		}},
+		{"LSE", LSE, 2023, []string{
+			"2023-01-02", "2023-04-07", "2023-04-10", "2023-05-01", "2023-05-08",
+			"2023-05-29", "2023-08-28", "2023-12-25", "2023-12-26",
+		}},
+		// https://www.ecb.europa.eu/paym/target/t2/html/index.en.html
+		{"TARGET2", TARGET2, 2022, []string{
+			"2022-04-15", "2022-04-18", "2022-12-26",
+		}},
+		{"TARGET2", TARGET2, 2024, []string{
+			"2024-01-01", "2024-03-29", "2024-04-01", "2024-05-01", "2024-12-25",
+			"2024-12-26",
+		}},
+		// https://www.jpx.co.jp/english/corporate/about-jpx/calendar/
+		{"TSE", TSE, 2019, []string{
+			"2019-01-01", "2019-01-02", "2019-01-03", "2019-01-14", "2019-02-11",
+			"2019-03-21", "2019-04-29", "2019-04-30", "2019-05-01", "2019-05-02",
+			"2019-05-03", "2019-05-06", "2019-07-15", "2019-08-12", "2019-09-16",
+			"2019-09-23", "2019-10-14", "2019-10-22", "2019-11-04", "2019-12-31",
+		}},
+		{"TSE", TSE, 2020, []string{
+			"2020-01-01", "2020-01-02", "2020-01-03", "2020-01-13", "2020-02-11",
+			"2020-02-24", "2020-03-20", "2020-04-29", "2020-05-04", "2020-05-05",
+			"2020-05-06", "2020-07-23", "2020-07-24", "2020-08-10", "2020-09-21",
+			"2020-09-22", "2020-10-01", "2020-11-03", "2020-11-23", "2020-12-31",
+		}},
+		{"TSE", TSE, 2024, []string{
+			"2024-01-01", "2024-01-02", "2024-01-03", "2024-01-08", "2024-02-12",
+			"2024-02-23", "2024-03-20", "2024-04-29", "2024-05-03", "2024-05-06",
+			"2024-07-15", "2024-08-12", "2024-09-16", "2024-09-23", "2024-10-14",
+			"2024-11-04", "2024-12-31",
+		}},
+	}
+	for _, tc := range tcs {
+		var want []date.Date
+		for _, s := range tc.want {
+			want = append(want, datetest.MustDate(s))
+		}
+		var got []date.Date
+		for _, h := range date.HolidaysIn(tc.cal, date.YearRange(tc.year)) {
+			if date.Workdays.Contains(h.Observed.Weekday()) && !slices.Contains(got, h.Observed) {
+				got = append(got, h.Observed)
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s closures in %d = %v, want %v", tc.name, tc.year, got, want)
+		}
+	}
+}
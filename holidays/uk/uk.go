@@ -0,0 +1,112 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package uk provides the bank holidays of the United Kingdom.
+//
+// Each of the three jurisdictions of the United Kingdom has its own bank
+// holidays. A bank holiday falling on a weekend is observed on the next
+// weekday which is not already a bank holiday.
+//
+// The calendars reflect the rules in effect since 1978 as well as later
+// special bank holidays and moved dates, as published on
+// https://www.gov.uk/bank-holidays.
+package uk
+
+import (
+	"time"
+
+	"gonih.org/date"
+)
+
+var (
+	newYear    = date.FixedHoliday("New Year's Day", time.January, 1, date.ObserveNextWeekday)
+	goodFriday = date.EasterHoliday("Good Friday", -2, nil)
+	easter     = date.EasterHoliday("Easter Monday", 1, nil)
+	christmas  = date.FixedHoliday("Christmas Day", time.December, 25, date.ObserveNextWeekday)
+	boxingDay  = date.FixedHoliday("Boxing Day", time.December, 26, date.ObserveNextWeekday)
+
+	earlyMay = moved(
+		date.WeekdayHoliday("Early May bank holiday", time.May, 1, time.Monday, nil),
+		map[int]date.Date{
+			1995: date.Of(1995, time.May, 8),
+			2020: date.Of(2020, time.May, 8),
+		},
+	)
+	spring = moved(
+		date.WeekdayHoliday("Spring bank holiday", time.May, -1, time.Monday, nil),
+		map[int]date.Date{
+			2002: date.Of(2002, time.June, 4),
+			2012: date.Of(2012, time.June, 4),
+			2022: date.Of(2022, time.June, 2),
+		},
+	)
+
+	// special are bank holidays proclaimed for a single occasion.
+	special = []date.HolidayRule{
+		date.OneTimeHoliday("Millennium Celebrations", date.Of(1999, time.December, 31), nil),
+		date.OneTimeHoliday("Golden Jubilee", date.Of(2002, time.June, 3), nil),
+		date.OneTimeHoliday("Royal Wedding", date.Of(2011, time.April, 29), nil),
+		date.OneTimeHoliday("Diamond Jubilee", date.Of(2012, time.June, 5), nil),
+		date.OneTimeHoliday("Platinum Jubilee", date.Of(2022, time.June, 3), nil),
+		date.OneTimeHoliday("State Funeral of Queen Elizabeth II", date.Of(2022, time.September, 19), nil),
+		date.OneTimeHoliday("Coronation of King Charles III", date.Of(2023, time.May, 8), nil),
+	}
+)
+
+// EnglandAndWales contains the bank holidays of England and Wales.
+var EnglandAndWales = date.NewHolidayCalendar(append([]date.HolidayRule{
+	newYear,
+	goodFriday,
+	easter,
+	earlyMay,
+	spring,
+	date.WeekdayHoliday("Summer bank holiday", time.August, -1, time.Monday, nil),
+	christmas,
+	boxingDay,
+}, special...)...)
+
+// Scotland contains the bank holidays of Scotland.
+var Scotland = date.NewHolidayCalendar(append([]date.HolidayRule{
+	newYear,
+	date.FixedHoliday("2nd January", time.January, 2, date.ObserveNextWeekday),
+	goodFriday,
+	earlyMay,
+	spring,
+	date.WeekdayHoliday("Summer bank holiday", time.August, 1, time.Monday, nil),
+	{
+		Name:      "St Andrew's Day",
+		Date:      func(year int) (date.Date, bool) { return date.Of(year, time.November, 30), true },
+		FirstYear: 2007,
+		Observe:   date.ObserveNextWeekday,
+	},
+	christmas,
+	boxingDay,
+}, special...)...)
+
+// NorthernIreland contains the bank holidays of Northern Ireland.
+var NorthernIreland = date.NewHolidayCalendar(append([]date.HolidayRule{
+	newYear,
+	date.FixedHoliday("St Patrick's Day", time.March, 17, date.ObserveNextWeekday),
+	goodFriday,
+	easter,
+	earlyMay,
+	spring,
+	date.FixedHoliday("Battle of the Boyne (Orangemen's Day)", time.July, 12, date.ObserveNextWeekday),
+	date.WeekdayHoliday("Summer bank holiday", time.August, -1, time.Monday, nil),
+	christmas,
+	boxingDay,
+}, special...)...)
+
+// moved returns r, with the date changed in the given years.
+func moved(r date.HolidayRule, years map[int]date.Date) date.HolidayRule {
+	f := r.Date
+	r.Date = func(year int) (date.Date, bool) {
+		if d, ok := years[year]; ok {
+			return d, true
+		}
+		return f(year)
+	}
+	return r
+}
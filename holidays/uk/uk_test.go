@@ -0,0 +1,65 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uk
+
+import (
+	"slices"
+	"testing"
+
+	"gonih.org/date"
+	"gonih.org/date/datetest"
+)
+
+func TestCalendars(t *testing.T) {
+	t.Parallel()
+	// https://www.gov.uk/bank-holidays
+	tcs := []struct {
+		name string
+		cal  *date.HolidayCalendar
+		year int
+		want []string
+	}{
+		{"EnglandAndWales", EnglandAndWales, 2020, []string{
+			"2020-01-01", "2020-04-10", "2020-04-13", "2020-05-08", "2020-05-25",
+			"2020-08-31", "2020-12-25", "2020-12-28",
+		}},
+		{"EnglandAndWales", EnglandAndWales, 2021, []string{
+			"2021-01-01", "2021-04-02", "2021-04-05", "2021-05-03", "2021-05-31",
+			"2021-08-30", "2021-12-27", "2021-12-28",
+		}},
+		{"EnglandAndWales", EnglandAndWales, 2022, []string{
+			"2022-01-03", "2022-04-15", "2022-04-18", "2022-05-02", "2022-06-02",
+			"2022-06-03", "2022-08-29", "2022-09-19", "2022-12-26", "2022-12-27",
+		}},
+		{"Scotland", Scotland, 2022, []string{
+			"2022-01-03", "2022-01-04", "2022-04-15", "2022-05-02", "2022-06-02",
+			"2022-06-03", "2022-08-01", "2022-09-19", "2022-11-30", "2022-12-26",
+			"2022-12-27",
+		}},
+		{"Scotland", Scotland, 2023, []string{
+			"2023-01-02", "2023-01-03", "2023-04-07", "2023-05-01", "2023-05-08",
+			"2023-05-29", "2023-08-07", "2023-11-30", "2023-12-25", "2023-12-26",
+		}},
+		{"NorthernIreland", NorthernIreland, 2023, []string{
+			"2023-01-02", "2023-03-17", "2023-04-07", "2023-04-10", "2023-05-01",
+			"2023-05-08", "2023-05-29", "2023-07-12", "2023-08-28", "2023-12-25",
+			"2023-12-26",
+		}},
+	}
+	for _, tc := range tcs {
+		var want []date.Date
+		for _, s := range tc.want {
+			want = append(want, datetest.MustDate(s))
+		}
+		var got []date.Date
+		for _, h := range tc.cal.Holidays(tc.year) {
+			got = append(got, h.Observed)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s.Holidays(%d) = %v, want %v", tc.name, tc.year, got, want)
+		}
+	}
+}
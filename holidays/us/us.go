@@ -0,0 +1,51 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package us provides the public holidays of the United States.
+package us
+
+import (
+	"time"
+
+	"gonih.org/date"
+)
+
+// Federal is the calendar of US federal holidays, as defined in 5 U.S.C.
+// 6103. A holiday falling on a Saturday is observed on the preceding Friday
+// and one falling on a Sunday on the following Monday.
+//
+// The rules are those in effect since the Uniform Monday Holiday Act took
+// effect in 1971. Holidays established later, like Martin Luther King Jr. Day
+// and Juneteenth, are only included from their first observance.
+var Federal = date.NewHolidayCalendar(
+	date.FixedHoliday("New Year's Day", time.January, 1, date.ObserveNearestWeekday),
+	since(1986, date.WeekdayHoliday("Martin Luther King Jr. Day", time.January, 3, time.Monday, nil)),
+	date.WeekdayHoliday("Washington's Birthday", time.February, 3, time.Monday, nil),
+	date.WeekdayHoliday("Memorial Day", time.May, -1, time.Monday, nil),
+	since(2021, date.FixedHoliday("Juneteenth National Independence Day", time.June, 19, date.ObserveNearestWeekday)),
+	date.FixedHoliday("Independence Day", time.July, 4, date.ObserveNearestWeekday),
+	date.WeekdayHoliday("Labor Day", time.September, 1, time.Monday, nil),
+	date.WeekdayHoliday("Columbus Day", time.October, 2, time.Monday, nil),
+	veteransDay,
+	date.WeekdayHoliday("Thanksgiving Day", time.November, 4, time.Thursday, nil),
+	date.FixedHoliday("Christmas Day", time.December, 25, date.ObserveNearestWeekday),
+)
+
+// veteransDay was moved to the fourth Monday of October from 1971 to 1977.
+var veteransDay = date.HolidayRule{
+	Name: "Veterans Day",
+	Date: func(year int) (date.Date, bool) {
+		if 1971 <= year && year <= 1977 {
+			return date.NthInMonth(4, time.Monday).Adjust(date.Of(year, time.October, 1)), true
+		}
+		return date.Of(year, time.November, 11), true
+	},
+	Observe: date.ObserveNearestWeekday,
+}
+
+func since(year int, r date.HolidayRule) date.HolidayRule {
+	r.FirstYear = year
+	return r
+}
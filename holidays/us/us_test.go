@@ -0,0 +1,56 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package us
+
+import (
+	"slices"
+	"testing"
+
+	"gonih.org/date"
+	"gonih.org/date/datetest"
+)
+
+func TestFederal(t *testing.T) {
+	t.Parallel()
+	// https://www.opm.gov/policy-data-oversight/pay-leave/federal-holidays/
+	tcs := []struct {
+		year int
+		want []string
+	}{
+		{2020, []string{
+			"2020-01-01", "2020-01-20", "2020-02-17", "2020-05-25", "2020-07-03",
+			"2020-09-07", "2020-10-12", "2020-11-11", "2020-11-26", "2020-12-25",
+		}},
+		{2021, []string{
+			"2021-01-01", "2021-01-18", "2021-02-15", "2021-05-31", "2021-06-18",
+			"2021-07-05", "2021-09-06", "2021-10-11", "2021-11-11", "2021-11-25",
+			"2021-12-24",
+		}},
+		{2022, []string{
+			"2021-12-31", "2022-01-17", "2022-02-21", "2022-05-30", "2022-06-20",
+			"2022-07-04", "2022-09-05", "2022-10-10", "2022-11-11", "2022-11-24",
+			"2022-12-26",
+		}},
+		{2023, []string{
+			"2023-01-02", "2023-01-16", "2023-02-20", "2023-05-29", "2023-06-19",
+			"2023-07-04", "2023-09-04", "2023-10-09", "2023-11-10", "2023-11-23",
+			"2023-12-25",
+		}},
+	}
+	for _, tc := range tcs {
+		var want []date.Date
+		for _, s := range tc.want {
+			want = append(want, datetest.MustDate(s))
+		}
+		var got []date.Date
+		for _, h := range Federal.Holidays(tc.year) {
+			got = append(got, h.Observed)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Federal.Holidays(%d) = %v, want %v", tc.year, got, want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// An Amount is a number of some calendar unit, like "2 months".
+type Amount struct {
+	N    int
+	Unit Unit
+}
+
+// String returns a human-readable representation of a, like "2 months" or
+// "1 day".
+func (a Amount) String() string {
+	name := a.Unit.String()
+	if (a.N == 1 || a.N == -1) && a.Unit.valid() {
+		name = unitNames[a.Unit][0]
+	}
+	return strconv.Itoa(a.N) + " " + name
+}
+
+// A Humanizer describes the difference between two dates in human-readable
+// form. The zero value uses years, months and days and includes all non-zero
+// units.
+type Humanizer struct {
+	// Units are the units used to describe the difference. Invalid units
+	// are ignored. If it contains no valid units, years, months and days are
+	// used. The order of Units does not matter.
+	Units []Unit
+
+	// Precision is the maximum number of units to include, starting with the
+	// largest non-zero unit. Any remaining smaller units are truncated. If
+	// Precision is zero, all non-zero units are included.
+	Precision int
+}
+
+// HumanizeDiff describes the difference between a and b in years, months and
+// days, like "2 months, 5 days". The order of a and b does not matter.
+func HumanizeDiff(a, b Date) string {
+	return Humanizer{}.Diff(a, b)
+}
+
+// Diff describes the difference between a and b, like "2 months, 5 days". The
+// order of a and b does not matter. If the difference is smaller than the
+// smallest unit, the result is zero of that unit, like "0 days".
+func (h Humanizer) Diff(a, b Date) string {
+	amounts := h.Amounts(a, b)
+	if len(amounts) == 0 {
+		return Amount{0, h.units()[len(h.units())-1]}.String()
+	}
+	parts := make([]string, len(amounts))
+	for i, a := range amounts {
+		parts[i] = a.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Amounts returns the difference between a and b as a list of non-zero
+// amounts, ordered from the largest to the smallest unit. All amounts are
+// positive, the order of a and b does not matter.
+//
+// Month-based units are computed as in [Between], so the difference between
+// January 31st and February 29th is one month.
+func (h Humanizer) Amounts(a, b Date) []Amount {
+	if b < a {
+		a, b = b, a
+	}
+	var (
+		out    []Amount
+		months = -1 // whole months between a and b, computed lazily
+		used   int  // months used by larger units
+		days   = -1 // days remaining after subtracting months
+	)
+	for _, u := range h.units() {
+		if h.Precision > 0 && len(out) == h.Precision {
+			break
+		}
+		var n int
+		if m := u.months(); m > 0 {
+			if months < 0 {
+				months = monthsBetween(a, b)
+			}
+			n = (months - used) / m
+			used += n * m
+		} else {
+			if days < 0 {
+				days = int(b - a.addMonthsClamped(used))
+			}
+			n = days / u.days()
+			days -= n * u.days()
+		}
+		if n != 0 {
+			out = append(out, Amount{n, u})
+		}
+	}
+	return out
+}
+
+// units returns the units of h, from largest to smallest.
+func (h Humanizer) units() []Unit {
+	units := slices.DeleteFunc(slices.Clone(h.Units), func(u Unit) bool {
+		return !u.valid()
+	})
+	if len(units) == 0 {
+		return []Unit{Years, Months, Days}
+	}
+	slices.Sort(units)
+	units = slices.Compact(units)
+	slices.Reverse(units)
+	return units
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "testing"
+
+func TestHumanizeDiff(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		h    Humanizer
+		a, b Date
+		want string
+	}{
+		{Humanizer{}, Of(2024, 5, 14), Of(2024, 5, 14), "0 days"},
+		{Humanizer{}, Of(2024, 5, 14), Of(2024, 5, 15), "1 day"},
+		{Humanizer{}, Of(2024, 5, 14), Of(2024, 7, 19), "2 months, 5 days"},
+		{Humanizer{}, Of(2024, 7, 19), Of(2024, 5, 14), "2 months, 5 days"},
+		{Humanizer{}, Of(2024, 5, 14), Of(2025, 6, 14), "1 year, 1 month"},
+		{Humanizer{}, Of(2024, 1, 31), Of(2024, 2, 29), "1 month"},
+		{Humanizer{Precision: 1}, Of(2024, 5, 14), Of(2025, 7, 19), "1 year"},
+		{Humanizer{Precision: 2}, Of(2024, 5, 14), Of(2025, 7, 19), "1 year, 2 months"},
+		{Humanizer{Precision: 1}, Of(2024, 5, 14), Of(2024, 5, 20), "6 days"},
+		{Humanizer{Units: []Unit{Days}}, Of(2024, 5, 14), Of(2025, 5, 14), "365 days"},
+		{Humanizer{Units: []Unit{Weeks, Days}}, Of(2024, 5, 14), Of(2024, 6, 1), "2 weeks, 4 days"},
+		{Humanizer{Units: []Unit{Weeks}}, Of(2024, 5, 14), Of(2024, 5, 20), "0 weeks"},
+		{Humanizer{Units: []Unit{Days, Months, Weeks}}, Of(2024, 5, 14), Of(2024, 7, 30), "2 months, 2 weeks, 2 days"},
+		{Humanizer{Units: []Unit{Years, Quarters, Months}}, Of(2024, 5, 14), Of(2025, 10, 1), "1 year, 1 quarter, 1 month"},
+		{Humanizer{Units: []Unit{Years, Days}}, Of(2024, 5, 14), Of(2025, 6, 14), "1 year, 31 days"},
+		{Humanizer{Units: []Unit{Weeks, -1, 42}}, Of(2024, 5, 14), Of(2024, 6, 1), "2 weeks"},
+		{Humanizer{Units: []Unit{42}}, Of(2024, 5, 14), Of(2024, 6, 1), "18 days"},
+	}
+	for _, tc := range tcs {
+		if got := tc.h.Diff(tc.a, tc.b); got != tc.want {
+			t.Errorf("%+v.Diff(%v, %v) = %q, want %q", tc.h, tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestAmountString(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		a    Amount
+		want string
+	}{
+		{Amount{0, Days}, "0 days"},
+		{Amount{1, Days}, "1 day"},
+		{Amount{-1, Weeks}, "-1 week"},
+		{Amount{2, Quarters}, "2 quarters"},
+		{Amount{3, Unit(42)}, "3 Unit(42)"},
+	}
+	for _, tc := range tcs {
+		if got := tc.a.String(); got != tc.want {
+			t.Errorf("%#v.String() = %q, want %q", tc.a, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseICal parses an iCalendar DATE value, like "20240514". iCalendar DATE
+// values (RFC 5545, section 3.3.4) use the [ISOBasic] layout.
+func ParseICal(s string) (Date, error) {
+	return Parse(ISOBasic, s)
+}
+
+// ParseICalList parses a comma-separated list of iCalendar DATE values, like
+// "20240514,20240521", as used by the RDATE and EXDATE properties.
+func ParseICalList(s string) ([]Date, error) {
+	fields := strings.Split(s, ",")
+	ds := make([]Date, 0, len(fields))
+	for _, f := range fields {
+		d, err := ParseICal(f)
+		if err != nil {
+			return nil, err
+		}
+		ds = append(ds, d)
+	}
+	return ds, nil
+}
+
+// FormatICal formats d as an iCalendar DATE value, like "20240514".
+// iCalendar only allows the years 0000 to 9999. Other years are formatted
+// like by [Date.Format], which is not a valid DATE value and is rejected by
+// [ParseICal].
+func FormatICal(d Date) string {
+	return d.Format(ISOBasic)
+}
+
+// FormatICalList formats ds as a comma-separated list of iCalendar DATE
+// values, like "20240514,20240521". Like for [FormatICal], the years of ds
+// must be in the range 0000 to 9999 for the values to be valid.
+func FormatICalList(ds []Date) string {
+	return string(appendICalList(nil, ds))
+}
+
+func appendICalList(b []byte, ds []Date) []byte {
+	for i, d := range ds {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = d.AppendFormat(b, ISOBasic)
+	}
+	return b
+}
+
+// ParseICalProperty parses an iCalendar content line of a property with
+// DATE values, like
+//
+//	EXDATE;VALUE=DATE:20240514,20240521
+//
+// It returns the upper-cased name of the property and its values. The VALUE
+// parameter must be present and set to DATE, as RFC 5545 specifies DATE-TIME
+// as the default for all properties accepting dates. Other parameters are
+// ignored. Folded lines must be unfolded before calling ParseICalProperty.
+func ParseICalProperty(line string) (name string, ds []Date, err error) {
+	head, value, ok := cutUnquoted(line, ':')
+	if !ok {
+		return "", nil, fmt.Errorf("parsing iCalendar property %q: missing value", line)
+	}
+	name, params, _ := strings.Cut(head, ";")
+	if name == "" {
+		return "", nil, fmt.Errorf("parsing iCalendar property %q: missing name", line)
+	}
+	isDate := false
+	for params != "" {
+		var param string
+		param, params, _ = cutUnquoted(params, ';')
+		k, v, _ := strings.Cut(param, "=")
+		if strings.EqualFold(k, "VALUE") {
+			isDate = strings.EqualFold(v, "DATE")
+			if !isDate {
+				return "", nil, fmt.Errorf("parsing iCalendar property %q: unsupported value type %q", line, v)
+			}
+		}
+	}
+	if !isDate {
+		return "", nil, fmt.Errorf("parsing iCalendar property %q: missing VALUE=DATE parameter", line)
+	}
+	ds, err = ParseICalList(value)
+	if err != nil {
+		return "", nil, err
+	}
+	return strings.ToUpper(name), ds, nil
+}
+
+// FormatICalProperty formats an iCalendar content line for the property with
+// the given name and DATE values, like
+//
+//	EXDATE;VALUE=DATE:20240514,20240521
+//
+// The returned line is not folded. Like for [FormatICal], the years of ds
+// must be in the range 0000 to 9999 for the values to be valid.
+func FormatICalProperty(name string, ds []Date) string {
+	b := make([]byte, 0, len(name)+len(";VALUE=DATE:")+9*len(ds))
+	b = append(b, name...)
+	b = append(b, ";VALUE=DATE:"...)
+	return string(appendICalList(b, ds))
+}
+
+// cutUnquoted is like strings.Cut, but ignores sep inside double quotes.
+func cutUnquoted(s string, sep byte) (before, after string, found bool) {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				return s[:i], s[i+1:], true
+			}
+		}
+	}
+	return s, "", false
+}
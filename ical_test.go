@@ -0,0 +1,74 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseICalList(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		value string
+		want  []Date
+	}{
+		{"20240514", []Date{Of(2024, 5, 14)}},
+		{"20240514,20240521,19991231", []Date{Of(2024, 5, 14), Of(2024, 5, 21), Of(1999, 12, 31)}},
+		{"", nil},
+		{"2024-05-14", nil},
+		{"20240514,", nil},
+		{"20240532", nil},
+	}
+	for _, tc := range tcs {
+		got, err := ParseICalList(tc.value)
+		if (err != nil) != (tc.want == nil) {
+			t.Errorf("ParseICalList(%q) = _, %v, want error: %v", tc.value, err, tc.want == nil)
+			continue
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("ParseICalList(%q) = %v, want %v", tc.value, got, tc.want)
+		}
+		if tc.want == nil {
+			continue
+		}
+		if got := FormatICalList(tc.want); got != tc.value {
+			t.Errorf("FormatICalList(%v) = %q, want %q", tc.want, got, tc.value)
+		}
+	}
+}
+
+func TestParseICalProperty(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		line string
+		name string
+		want []Date
+	}{
+		{"EXDATE;VALUE=DATE:20240514,20240521", "EXDATE", []Date{Of(2024, 5, 14), Of(2024, 5, 21)}},
+		{"rdate;value=date:20240514", "RDATE", []Date{Of(2024, 5, 14)}},
+		{`DTSTART;X-NOTE="a:b;c";VALUE=DATE:20240514`, "DTSTART", []Date{Of(2024, 5, 14)}},
+		{"EXDATE:20240514T000000Z", "", nil},
+		{"EXDATE;VALUE=DATE-TIME:20240514T000000Z", "", nil},
+		{"EXDATE;VALUE=DATE", "", nil},
+		{";VALUE=DATE:20240514", "", nil},
+		{"EXDATE;VALUE=DATE:2024-05-14", "", nil},
+	}
+	for _, tc := range tcs {
+		name, got, err := ParseICalProperty(tc.line)
+		if (err != nil) != (tc.want == nil) {
+			t.Errorf("ParseICalProperty(%q) = _, _, %v, want error: %v", tc.line, err, tc.want == nil)
+			continue
+		}
+		if name != tc.name || !slices.Equal(got, tc.want) {
+			t.Errorf("ParseICalProperty(%q) = %q, %v, want %q, %v", tc.line, name, got, tc.name, tc.want)
+		}
+	}
+	ds := []Date{Of(2024, 5, 14), Of(2024, 5, 21)}
+	if got, want := FormatICalProperty("EXDATE", ds), "EXDATE;VALUE=DATE:20240514,20240521"; got != want {
+		t.Errorf("FormatICalProperty(%q, %v) = %q, want %q", "EXDATE", ds, got, want)
+	}
+}
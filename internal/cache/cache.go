@@ -3,76 +3,255 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Package cache implements a very simple random-replacement cache to memoize
-// expensive operations.
+// Package cache implements a simple cache to memoize expensive operations.
+//
+// Elements are evicted using the SIEVE algorithm: new elements are added to
+// the front of a queue and a hand moves from the back of the queue to the
+// front, evicting the first element which has not been looked up since the
+// hand last passed it. This approximates LRU eviction, but a lookup only has
+// to mark the element as visited, so lookups can run concurrently.
 package cache
 
 import (
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // DefaultSize is the default size of a cache.
 const DefaultSize = 1 << 10
 
-// Cache is a simple random-replacement cache suitable to memoize expensive
-// operations.
+// MaxErrs is the maximum number of errors cached by GetErr.
+const MaxErrs = 1 << 10
+
+// Cache is a simple cache suitable to memoize expensive operations.
 //
 // Its zero value is safe to use. It is safe for concurrent use.
 type Cache[K comparable, V any] struct {
-	// MaxSize is the maximum size of the cache. If it is zero, DefaultSize is used.
+	// MaxSize is the maximum size of the cache. If it is zero, DefaultSize is
+	// used. If it is negative, no elements are cached.
 	//
-	// If V implements Sizer, it is used to estimate size. Otherwise every
-	// element is assumed to have size 1.
-	//
-	// MaxSize is not safe to mutate concurrently with calls to Get.
+	// MaxSize is not safe to mutate concurrently with calls to Get, use
+	// SetMaxSize instead.
 	MaxSize int64
 
-	mu sync.RWMutex
-	m  map[K]V
-	n  int64
+	// Size estimates the size of an element. If it is nil and V implements
+	// Sizer, that is used. Otherwise every element is assumed to have size 1.
+	// The size must be positive and never change for the same element.
+	//
+	// Size is not safe to mutate concurrently with calls to Get.
+	Size func(K, V) int64
+
+	// ErrTTL is the duration for which errors returned by the fill function
+	// passed to GetErr are cached. If it is zero, errors are not cached and
+	// every call to GetErr for a missing element calls fill.
+	//
+	// Cached errors do not count towards MaxSize. Instead, at most MaxErrs
+	// errors are cached. When that limit is reached, expired errors are
+	// removed and, if that does not free enough room, arbitrary others.
+	// ErrTTL is not safe to mutate concurrently with calls to GetErr.
+	ErrTTL time.Duration
+
+	mu   sync.RWMutex
+	m    map[K]*entry[K, V]
+	n    int64
+	errs map[K]cachedErr
+
+	// Queue of elements for SIEVE eviction, from the newest at head to the
+	// oldest at tail. hand is the next candidate for eviction, if not nil.
+	head, tail, hand *entry[K, V]
+
+	hits, misses, evictions atomic.Int64
+}
+
+// entry is an element of a Cache.
+type entry[K comparable, V any] struct {
+	k          K
+	v          V
+	size       int64
+	visited    atomic.Bool
+	prev, next *entry[K, V] // towards head and tail, respectively
+}
+
+// Stats are statistics about the use of a Cache.
+type Stats struct {
+	Hits      int64 // number of lookups of present elements
+	Misses    int64 // number of lookups calling the fill function
+	Evictions int64 // number of elements evicted to make room for others
+	Len       int   // number of elements in the cache
+	Size      int64 // total size of the elements in the cache
+}
+
+// Stats returns statistics about the use of c.
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Len:       len(c.m),
+		Size:      c.n,
+	}
+}
+
+// cachedErr is a cached error returned by a fill function.
+type cachedErr struct {
+	err     error
+	expires time.Time
 }
 
 // Get the element associated with k from the cache, using fill to populate
 // missing elements.
 func (c *Cache[K, V]) Get(k K, fill func(K) V) V {
 	c.mu.RLock()
-	if v, ok := c.m[k]; ok {
+	if e, ok := c.m[k]; ok {
 		c.mu.RUnlock()
-		return v
+		c.hit(e)
+		return e.v
 	}
 	c.mu.RUnlock()
 
-	nv := fill(k)
+	c.misses.Add(1)
+	return c.add(k, fill(k))
+}
+
+// hit records a lookup of e.
+func (c *Cache[K, V]) hit(e *entry[K, V]) {
+	c.hits.Add(1)
+	if !e.visited.Load() {
+		e.visited.Store(true)
+	}
+}
 
+// add adds nv as the element for k, unless another goroutine added one in the
+// meantime, and returns the element.
+func (c *Cache[K, V]) add(k K, nv V) V {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if v, ok := c.m[k]; ok {
+	if e, ok := c.m[k]; ok {
 		// another goroutine filled the cache in the meantime
-		return v
+		return e.v
+	}
+	limit := c.maxSizeRLocked()
+	if limit < 0 {
+		return nv
+	}
+	e := &entry[K, V]{k: k, v: nv, size: c.size(k, nv)}
+	if e.size > limit {
+		// Adding e would evict everything else.
+		return nv
 	}
 	if c.m == nil {
-		c.m = make(map[K]V)
+		c.m = make(map[K]*entry[K, V])
 	}
-	c.m[k] = nv
-	c.n += size(nv)
-	for k := range c.m {
-		if !c.fullRLocked() {
-			break
-		}
-		c.evictLocked(k)
+	c.m[k] = e
+	c.n += e.size
+	e.next = c.head
+	if c.head != nil {
+		c.head.prev = e
+	}
+	c.head = e
+	if c.tail == nil {
+		c.tail = e
 	}
+	c.shrinkLocked(limit)
 	return nv
 }
 
-// full returns whether c is full. c.mu must be held for reading when calling
-// it.
-func (c *Cache[K, V]) fullRLocked() bool {
-	m := c.MaxSize
-	if m == 0 {
-		m = DefaultSize
+// shrinkLocked evicts elements until the size of c is at most limit. c.mu
+// must be held for writing when calling it.
+func (c *Cache[K, V]) shrinkLocked(limit int64) {
+	for c.n > limit && c.tail != nil {
+		e := c.hand
+		if e == nil {
+			e = c.tail
+		}
+		for e.visited.Load() {
+			e.visited.Store(false)
+			if e = e.prev; e == nil {
+				e = c.tail
+			}
+		}
+		c.hand = e.prev
+		c.evictLocked(e.k)
+		c.evictions.Add(1)
 	}
-	return c.n > m
+}
+
+// GetErr gets the element associated with k from the cache, using fill to
+// populate missing elements. If fill returns an error, the element is not
+// added to the cache and the error is returned. If ErrTTL is positive, the
+// error is remembered for that duration and returned by calls to GetErr for k
+// without calling fill again.
+func (c *Cache[K, V]) GetErr(k K, fill func(K) (V, error)) (V, error) {
+	c.mu.RLock()
+	if e, ok := c.m[k]; ok {
+		c.mu.RUnlock()
+		c.hit(e)
+		return e.v, nil
+	}
+	if e, ok := c.errs[k]; ok && time.Now().Before(e.expires) {
+		c.mu.RUnlock()
+		c.hits.Add(1)
+		return *new(V), e.err
+	}
+	c.mu.RUnlock()
+
+	c.misses.Add(1)
+	nv, err := fill(k)
+	if err != nil {
+		if c.ErrTTL > 0 {
+			c.addErr(k, err)
+		}
+		return nv, err
+	}
+	return c.add(k, nv), nil
+}
+
+// addErr caches err as the result of filling k.
+func (c *Cache[K, V]) addErr(k K, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.errs == nil {
+		c.errs = make(map[K]cachedErr)
+	}
+	now := time.Now()
+	if _, ok := c.errs[k]; !ok && len(c.errs) >= MaxErrs {
+		// Make room for a quarter of MaxErrs at once, so the cost of
+		// iterating over errs is amortized over the following insertions.
+		for k, e := range c.errs {
+			if !now.Before(e.expires) {
+				delete(c.errs, k)
+			}
+		}
+		for k := range c.errs {
+			if len(c.errs) <= MaxErrs*3/4 {
+				break
+			}
+			delete(c.errs, k)
+		}
+	}
+	c.errs[k] = cachedErr{err, now.Add(c.ErrTTL)}
+}
+
+// SetMaxSize sets MaxSize to n and evicts elements as needed. Unlike
+// assigning MaxSize, it is safe to call concurrently with other methods.
+func (c *Cache[K, V]) SetMaxSize(n int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MaxSize = n
+	c.shrinkLocked(max(c.maxSizeRLocked(), 0))
+}
+
+// maxSizeRLocked returns the effective maximum size of c. c.mu must be held
+// for reading when calling it.
+func (c *Cache[K, V]) maxSizeRLocked() int64 {
+	if c.MaxSize == 0 {
+		return DefaultSize
+	}
+	return c.MaxSize
 }
 
 // Evict the element for k from the cache. If there is no such element, Evict
@@ -81,14 +260,30 @@ func (c *Cache[K, V]) Evict(k K) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.evictLocked(k)
+	delete(c.errs, k)
 }
 
 // evictLocked evicts the given key from the cache. c.mu must be held for
 // writing when calling it.
 func (c *Cache[K, V]) evictLocked(k K) {
-	if v, ok := c.m[k]; ok {
-		delete(c.m, k)
-		c.n -= size(v)
+	e, ok := c.m[k]
+	if !ok {
+		return
+	}
+	delete(c.m, k)
+	c.n -= e.size
+	if c.hand == e {
+		c.hand = e.prev
+	}
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else {
+		c.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else {
+		c.tail = e.prev
 	}
 }
 
@@ -97,7 +292,9 @@ func (c *Cache[K, V]) Flush() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	clear(c.m)
+	clear(c.errs)
 	c.n = 0
+	c.head, c.tail, c.hand = nil, nil, nil
 }
 
 // Sizer is an optional interface for a value to report its own size. The
@@ -106,7 +303,11 @@ type Sizer interface {
 	Size() int64
 }
 
-func size[V any](v V) int64 {
+// size returns the size of the element v for k.
+func (c *Cache[K, V]) size(k K, v V) int64 {
+	if c.Size != nil {
+		return c.Size(k, v)
+	}
 	if s, ok := any(v).(Sizer); ok {
 		return s.Size()
 	}
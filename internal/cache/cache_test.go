@@ -0,0 +1,143 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetErr(t *testing.T) {
+	var calls int
+	errFill := errors.New("fill failed")
+	fill := func(k string) (int, error) {
+		calls++
+		if k == "bad" {
+			return 0, errFill
+		}
+		return len(k), nil
+	}
+
+	var c Cache[string, int]
+	for i := 0; i < 2; i++ {
+		if v, err := c.GetErr("good", fill); v != 4 || err != nil {
+			t.Fatalf("GetErr(%q) = %v, %v, want 4, <nil>", "good", v, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("fill called %d times, want 1", calls)
+	}
+
+	calls = 0
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetErr("bad", fill); err != errFill {
+			t.Fatalf("GetErr(%q) = _, %v, want %v", "bad", err, errFill)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("fill called %d times with ErrTTL = 0, want 2", calls)
+	}
+
+	c.ErrTTL = time.Hour
+	calls = 0
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetErr("bad", fill); err != errFill {
+			t.Fatalf("GetErr(%q) = _, %v, want %v", "bad", err, errFill)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("fill called %d times with ErrTTL = %v, want 1", calls, c.ErrTTL)
+	}
+	c.Evict("bad")
+	c.GetErr("bad", fill)
+	if calls != 2 {
+		t.Fatalf("fill called %d times after Evict, want 2", calls)
+	}
+
+	c.ErrTTL = time.Nanosecond
+	c.Flush()
+	calls = 0
+	c.GetErr("bad", fill)
+	time.Sleep(time.Millisecond)
+	c.GetErr("bad", fill)
+	if calls != 2 {
+		t.Fatalf("fill called %d times after error expired, want 2", calls)
+	}
+}
+
+func TestMaxErrs(t *testing.T) {
+	errFill := errors.New("fill failed")
+	fill := func(int) (int, error) { return 0, errFill }
+	c := Cache[int, int]{ErrTTL: time.Hour}
+	for k := range 3 * MaxErrs {
+		c.GetErr(k, fill)
+		if n := len(c.errs); n > MaxErrs {
+			t.Fatalf("%d errors cached after %d calls to GetErr, want at most %d", n, k+1, MaxErrs)
+		}
+	}
+	// The error for the last key must not have been evicted.
+	calls := 0
+	c.GetErr(3*MaxErrs-1, func(int) (int, error) { calls++; return 0, errFill })
+	if calls != 0 {
+		t.Errorf("fill called for the most recently failed key, want cached error")
+	}
+}
+
+func TestStats(t *testing.T) {
+	c := Cache[string, int]{MaxSize: 2}
+	fill := func(k string) int { return len(k) }
+	for _, k := range []string{"a", "a", "bb", "a", "ccc"} {
+		c.Get(k, fill)
+	}
+	want := Stats{Hits: 2, Misses: 3, Evictions: 1, Len: 2, Size: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEviction(t *testing.T) {
+	c := Cache[string, int]{MaxSize: 3}
+	fill := func(k string) int { return len(k) }
+	for _, k := range []string{"a", "b", "c", "a", "d", "c", "e"} {
+		c.Get(k, fill)
+	}
+	// "a" is kept when adding "d", as it has been looked up, and "b" is
+	// evicted. When adding "e", the hand moves on from "b" towards newer
+	// elements, skips "c", which has been looked up, and evicts "d".
+	for k, want := range map[string]bool{"a": true, "b": false, "c": true, "d": false, "e": true} {
+		c.mu.RLock()
+		_, ok := c.m[k]
+		c.mu.RUnlock()
+		if ok != want {
+			t.Errorf("%q cached = %v, want %v", k, ok, want)
+		}
+	}
+	if got, want := c.Stats().Evictions, int64(2); got != want {
+		t.Errorf("Stats().Evictions = %d, want %d", got, want)
+	}
+}
+
+func TestSize(t *testing.T) {
+	c := Cache[string, int]{MaxSize: 10, Size: func(k string, v int) int64 { return int64(v) }}
+	fill := func(k string) int { return len(k) }
+	for _, k := range []string{"aaaa", "bbbb", "ccccccccccc"} {
+		c.Get(k, fill)
+	}
+	if got, want := c.Stats(), (Stats{Misses: 3, Len: 2, Size: 8}); got != want {
+		t.Errorf("Stats() = %+v, want %+v, as elements larger than MaxSize are not cached", got, want)
+	}
+	c.Get("ccccc", fill)
+	if got, want := c.Stats(), (Stats{Misses: 4, Evictions: 1, Len: 2, Size: 9}); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	c.SetMaxSize(-1)
+	c.Get("aaaa", fill)
+	if got, want := c.Stats(), (Stats{Misses: 5, Evictions: 3, Len: 0, Size: 0}); got != want {
+		t.Errorf("Stats() = %+v after SetMaxSize(-1), want %+v", got, want)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command cldrgen generates the locale subpackages of gonih.org/date/locale
+// from the JSON data of the Unicode CLDR.
+//
+// Usage:
+//
+//	cldrgen -cldr dir -out dir locale...
+//
+// For every locale, the names of months and weekdays are read from the file
+// main/<locale>/ca-gregorian.json below the -cldr directory, which has the
+// layout of the cldr-dates-full package of the cldr-json project. A package
+// is written to <locale>/<locale>.go below the -out directory, with dashes
+// removed from the locale and all letters lower-cased. The package registers
+// its locale with date.RegisterLocale.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("cldrgen: ")
+	cldr := flag.String("cldr", "", "directory containing the CLDR JSON data")
+	out := flag.String("out", ".", "directory to write packages to")
+	flag.Parse()
+	if *cldr == "" || flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: cldrgen -cldr dir -out dir locale...")
+		os.Exit(2)
+	}
+	for _, tag := range flag.Args() {
+		n, err := load(*cldr, tag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		src, err := generate(tag, n)
+		if err != nil {
+			log.Fatal(err)
+		}
+		name := filepath.Join(*out, pkgName(tag), pkgName(tag)+".go")
+		if err := os.MkdirAll(filepath.Dir(name), 0o777); err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(name, src, 0o666); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// widths maps the width of a name, like "wide" or "abbreviated", to the names
+// of that width, keyed by month number or weekday abbreviation.
+type widths map[string]map[string]string
+
+// context maps the context of a name, "format" or "stand-alone", to its
+// widths.
+type context map[string]widths
+
+// names are the names of a locale, as stored in ca-gregorian.json.
+type names struct {
+	Months context `json:"months"`
+	Days   context `json:"days"`
+}
+
+// load reads the names of the given locale from the CLDR data in dir.
+func load(dir, tag string) (*names, error) {
+	b, err := os.ReadFile(filepath.Join(dir, "main", tag, "ca-gregorian.json"))
+	if err != nil {
+		return nil, err
+	}
+	var v struct {
+		Main map[string]struct {
+			Dates struct {
+				Calendars struct {
+					Gregorian names `json:"gregorian"`
+				} `json:"calendars"`
+			} `json:"dates"`
+		} `json:"main"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("%s: %w", tag, err)
+	}
+	m, ok := v.Main[tag]
+	if !ok {
+		return nil, fmt.Errorf("%s: no data for locale", tag)
+	}
+	return &m.Dates.Calendars.Gregorian, nil
+}
+
+// dayKeys are the keys of the days of the week, in the order of time.Weekday.
+var dayKeys = [...]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// months returns the month names of the given context and width.
+func (n *names) months(ctx, width string) ([]string, error) {
+	m := n.Months[ctx][width]
+	out := make([]string, 12)
+	for i := range out {
+		s, ok := m[strconv.Itoa(i+1)]
+		if !ok {
+			return nil, fmt.Errorf("missing %s %s name of month %d", ctx, width, i+1)
+		}
+		out[i] = s
+	}
+	return out, nil
+}
+
+// days returns the weekday names of the given context and width.
+func (n *names) days(ctx, width string) ([]string, error) {
+	m := n.Days[ctx][width]
+	out := make([]string, len(dayKeys))
+	for i, k := range dayKeys {
+		s, ok := m[k]
+		if !ok {
+			return nil, fmt.Errorf("missing %s %s name of %s", ctx, width, k)
+		}
+		out[i] = s
+	}
+	return out, nil
+}
+
+// fields lists the fields of date.Locale and where their names come from. A
+// field with a fallback is omitted, if it equals the fallback. Narrow names
+// are mostly used on their own, like in calendar headers, so their
+// stand-alone forms are used.
+var fields = []struct {
+	name       string
+	days       bool
+	ctx, width string
+	fallback   string
+}{
+	{"Months", false, "format", "wide", ""},
+	{"ShortMonths", false, "format", "abbreviated", ""},
+	{"StandaloneMonths", false, "stand-alone", "wide", "Months"},
+	{"ShortStandaloneMonths", false, "stand-alone", "abbreviated", "ShortMonths"},
+	{"Weekdays", true, "format", "wide", ""},
+	{"ShortWeekdays", true, "format", "abbreviated", ""},
+	{"MinWeekdays", true, "format", "short", ""},
+	{"NarrowMonths", false, "stand-alone", "narrow", ""},
+	{"NarrowWeekdays", true, "stand-alone", "narrow", ""},
+}
+
+// generate returns the source of the package for the given locale.
+func generate(tag string, n *names) ([]byte, error) {
+	pkg := pkgName(tag)
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "// Code generated by cldrgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "// Package %s provides the names of months and weekdays of the %q locale,\n", pkg, tag)
+	fmt.Fprintf(buf, "// derived from the Unicode CLDR.\n")
+	fmt.Fprintf(buf, "package %s\n\n", pkg)
+	fmt.Fprintf(buf, "import \"gonih.org/date\"\n\n")
+	fmt.Fprintf(buf, "// Locale is the %q locale.\n", tag)
+	fmt.Fprintf(buf, "var Locale = &date.Locale{\n")
+	values := make(map[string][]string)
+	for _, f := range fields {
+		var (
+			s   []string
+			err error
+		)
+		if f.days {
+			s, err = n.days(f.ctx, f.width)
+		} else {
+			s, err = n.months(f.ctx, f.width)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tag, err)
+		}
+		values[f.name] = s
+		if f.fallback != "" && slices.Equal(s, values[f.fallback]) {
+			continue
+		}
+		fmt.Fprintf(buf, "%s: [%d]string{\n", f.name, len(s))
+		for _, s := range s {
+			fmt.Fprintf(buf, "%q,\n", s)
+		}
+		fmt.Fprintf(buf, "},\n")
+	}
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func init() {\n")
+	fmt.Fprintf(buf, "date.RegisterLocale(%q, Locale)\n", tag)
+	fmt.Fprintf(buf, "}\n")
+	return format.Source(buf.Bytes())
+}
+
+// pkgName returns the name of the package for the given locale.
+func pkgName(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(tag, "-", ""))
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerated checks that the generated locale packages are up to date.
+func TestGenerated(t *testing.T) {
+	const dir = "../../locale"
+	tags, err := os.ReadDir(filepath.Join(dir, "cldr", "main"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) == 0 {
+		t.Fatal("no locales found")
+	}
+	for _, e := range tags {
+		tag := e.Name()
+		n, err := load(filepath.Join(dir, "cldr"), tag)
+		if err != nil {
+			t.Errorf("load(%q) = %v", tag, err)
+			continue
+		}
+		got, err := generate(tag, n)
+		if err != nil {
+			t.Errorf("generate(%q) = %v", tag, err)
+			continue
+		}
+		want, err := os.ReadFile(filepath.Join(dir, pkgName(tag), pkgName(tag)+".go"))
+		if err != nil {
+			t.Errorf("package for %q missing: %v", tag, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("package for %q is out of date, run go generate in %s", tag, dir)
+		}
+	}
+}
+
+func TestGenerateMissing(t *testing.T) {
+	n := &names{Months: context{"format": widths{"wide": {"1": "January"}}}}
+	if _, err := generate("xx", n); err == nil {
+		t.Error("generate with missing names succeeded")
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OfISOWeek returns the date of weekday wd in the given ISO 8601 week of the
+// given ISO year. It is the inverse of [Date.ISOWeek]. Weeks outside the
+// range of the year are normalized, so week 0 is the last week of the previous
+// year.
+func OfISOWeek(year, week int, wd time.Weekday) Date {
+	// January 4th is always in the first week.
+	monday := Of(year, time.January, 4).ISOWeekStart()
+	return monday + Date(7*(week-1)+isoWeekday(wd)-1)
+}
+
+// ISOWeekStart returns the Monday of the ISO 8601 week of d.
+func (d Date) ISOWeekStart() Date {
+	return d - Date(isoWeekday(d.Weekday())-1)
+}
+
+// ISOWeekday returns the day of the week with the ISO 8601 number n, from 1
+// for Monday to 7 for Sunday. It is the inverse of [Date.ISOWeekdayNumber]. It
+// panics, if n is out of range.
+func ISOWeekday(n int) time.Weekday {
+	if n < 1 || n > 7 {
+		panic("date: ISO weekday number " + strconv.Itoa(n) + " out of range")
+	}
+	return time.Weekday(n % 7)
+}
+
+// isoWeekday returns the ISO 8601 number of wd, from 1 for Monday to 7 for
+// Sunday.
+func isoWeekday(wd time.Weekday) int {
+	if wd == time.Sunday {
+		return 7
+	}
+	return int(wd)
+}
+
+// isoWeeksIn returns the number of ISO 8601 weeks in the given ISO year, which
+// is 52 or 53.
+func isoWeeksIn(year int) int {
+	// December 28th is always in the last week.
+	_, week := Of(year, time.December, 28).ISOWeek()
+	return week
+}
+
+// FormatISOWeek formats d as an ISO 8601 week date in extended format, like
+// "2024-W21-3" for Wednesday of week 21 of 2024. The year is the ISO year, as
+// returned by [Date.ISOWeek], which differs from the calendar year for some
+// days around New Year.
+func FormatISOWeek(d Date) string {
+	return string(appendISOWeek(nil, d, true))
+}
+
+// FormatISOWeekBasic formats d as an ISO 8601 week date in basic format,
+// without separators, like "2024W213".
+func FormatISOWeekBasic(d Date) string {
+	return string(appendISOWeek(nil, d, false))
+}
+
+func appendISOWeek(b []byte, d Date, extended bool) []byte {
+	year, week := d.ISOWeek()
+	b = appendInt(b, year, 4)
+	if extended {
+		b = append(b, '-')
+	}
+	b = append(b, 'W')
+	b = appendInt(b, week, 2)
+	if extended {
+		b = append(b, '-')
+	}
+	return append(b, byte('0'+isoWeekday(d.Weekday())))
+}
+
+// ParseISOWeek parses an ISO 8601 week date in extended format, like
+// "2024-W21-3", or in basic format, like "2024W213". The year must have four
+// digits, the week must exist in that year and the day must be in the range
+// 1 (Monday) to 7 (Sunday).
+func ParseISOWeek(s string) (Date, error) {
+	year, week, day, err := parseISOWeek(s, true)
+	if err != nil {
+		return 0, err
+	}
+	return OfISOWeek(year, week, time.Weekday(day%7)), nil
+}
+
+// parseISOWeek parses an ISO 8601 week date, as documented for ParseISOWeek.
+// If withDay is false, the day is omitted, like in "2024-W21", and returned as
+// zero.
+func parseISOWeek(s string, withDay bool) (year, week, day int, err error) {
+	var (
+		v    = s
+		fail = func(kind error, msg string) (int, int, int, error) {
+			return 0, 0, 0, &ParseError{Value: s, Message: msg, Err: kind}
+		}
+	)
+	year, v = digits(v, 4)
+	extended := len(v) > 0 && v[0] == '-'
+	if extended {
+		v = v[1:]
+	}
+	if len(v) == 0 || v[0] != 'W' {
+		return fail(ErrSyntax, "invalid ISO 8601 week date syntax")
+	}
+	week, v = digits(v[1:], 2)
+	if withDay {
+		if extended {
+			if len(v) == 0 || v[0] != '-' {
+				return fail(ErrSyntax, "invalid ISO 8601 week date syntax")
+			}
+			v = v[1:]
+		}
+		day, v = digits(v, 1)
+	}
+	if year < 0 || week < 0 || day < 0 || len(v) > 0 {
+		return fail(ErrSyntax, "invalid ISO 8601 week date syntax")
+	}
+	if week < 1 || week > isoWeeksIn(year) {
+		return fail(ErrRange, "week out of range")
+	}
+	if withDay && (day < 1 || day > 7) {
+		return fail(ErrRange, "day of week out of range")
+	}
+	return year, week, day, nil
+}
+
+// ParseISOWeekBasic is like [ParseISOWeek], but only accepts the basic format,
+// like "2024W213".
+func ParseISOWeekBasic(s string) (Date, error) {
+	if strings.Contains(s, "-") {
+		return 0, &ParseError{Value: s, Message: "invalid ISO 8601 week date syntax", Err: ErrSyntax}
+	}
+	return ParseISOWeek(s)
+}
+
+// IsEvenISOWeek reports whether the ISO 8601 week number of d is even.
+//
+// Week numbers do not alternate between odd and even across every year
+// boundary: in years with 53 weeks, week 53 is followed by week 1 of the next
+// year, so two odd weeks are adjacent. For schedules alternating every other
+// week, use [SameISOWeekParity] instead.
+func (d Date) IsEvenISOWeek() bool {
+	_, week := d.ISOWeek()
+	return week%2 == 0
+}
+
+// SameISOWeekParity reports whether the ISO 8601 weeks of a and b are an even
+// number of weeks apart. Unlike comparing the results of [Date.IsEvenISOWeek],
+// it is correct across years with 53 weeks. For example, to check whether a
+// biweekly pickup starting on anchor happens in the week of d:
+//
+//	pickup := date.SameISOWeekParity(anchor, d)
+func SameISOWeekParity(a, b Date) bool {
+	return (b.ISOWeekStart()-a.ISOWeekStart())/7%2 == 0
+}
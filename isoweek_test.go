@@ -0,0 +1,215 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestISOWeekDate(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d        Date
+		extended string
+		basic    string
+	}{
+		{Of(2024, 5, 22), "2024-W21-3", "2024W213"},
+		{Of(2024, 1, 1), "2024-W01-1", "2024W011"},
+		{Of(2021, 1, 3), "2020-W53-7", "2020W537"},
+		{Of(2024, 12, 30), "2025-W01-1", "2025W011"},
+		{Of(2026, 12, 31), "2026-W53-4", "2026W534"},
+		{Of(2027, 1, 1), "2026-W53-5", "2026W535"},
+	}
+	for _, tc := range tcs {
+		if got := FormatISOWeek(tc.d); got != tc.extended {
+			t.Errorf("FormatISOWeek(%v) = %q, want %q", tc.d, got, tc.extended)
+		}
+		if got := FormatISOWeekBasic(tc.d); got != tc.basic {
+			t.Errorf("FormatISOWeekBasic(%v) = %q, want %q", tc.d, got, tc.basic)
+		}
+		for _, s := range []string{tc.extended, tc.basic} {
+			if got, err := ParseISOWeek(s); err != nil || got != tc.d {
+				t.Errorf("ParseISOWeek(%q) = %v, %v, want %v, <nil>", s, got, err, tc.d)
+			}
+		}
+		if got, err := ParseISOWeekBasic(tc.basic); err != nil || got != tc.d {
+			t.Errorf("ParseISOWeekBasic(%q) = %v, %v, want %v, <nil>", tc.basic, got, err, tc.d)
+		}
+		if got, err := ParseISOWeekBasic(tc.extended); err == nil {
+			t.Errorf("ParseISOWeekBasic(%q) = %v, want error", tc.extended, got)
+		}
+	}
+}
+
+func TestParseISOWeekErrors(t *testing.T) {
+	t.Parallel()
+	tcs := []string{
+		"",
+		"2024",
+		"2024W21",
+		"2024-W213",
+		"2024W21-3",
+		"2024-W21-3x",
+		"2024w213",
+		"24W213",
+		"2024W003",
+		"2024W533",
+		"2026W543",
+		"2024W210",
+		"2024W218",
+	}
+	for _, s := range tcs {
+		if d, err := ParseISOWeek(s); err == nil {
+			t.Errorf("ParseISOWeek(%q) = %v, want error", s, d)
+		}
+	}
+}
+
+func TestParseISOWeekBasicErrors(t *testing.T) {
+	t.Parallel()
+	tcs := []string{
+		"",
+		"2024",
+		"2024W21",
+		"2024-W21-3",
+		"2024W21-3",
+		"2024W213x",
+		"2024w213",
+		"24W213",
+		"2024W003",
+		"2024W533",
+		"2026W543",
+		"2024W210",
+		"2024W218",
+	}
+	for _, s := range tcs {
+		if d, err := ParseISOWeekBasic(s); err == nil {
+			t.Errorf("ParseISOWeekBasic(%q) = %v, want error", s, d)
+		}
+	}
+}
+
+func FuzzOfISOWeek(f *testing.F) {
+	f.Add(2024, 21, 3)
+	f.Fuzz(func(t *testing.T, year, week, wd int) {
+		if year < 1 || year > 9998 || week < -1000 || week > 1000 {
+			return
+		}
+		wd = (wd%7 + 7) % 7
+		d := OfISOWeek(year, week, time.Weekday(wd))
+		if 1 <= week && week <= isoWeeksIn(year) {
+			if y, w := d.ISOWeek(); y != year || w != week {
+				t.Errorf("OfISOWeek(%d, %d, %v).ISOWeek() = %d, %d", year, week, time.Weekday(wd), y, w)
+			}
+		}
+		if y, _ := d.ISOWeek(); y < 0 || y > 9999 {
+			return
+		}
+		if got, err := ParseISOWeekBasic(FormatISOWeekBasic(d)); err != nil || got != d {
+			t.Errorf("ParseISOWeekBasic(FormatISOWeekBasic(%v)) = %v, %v", d, got, err)
+		}
+		if got, err := ParseISOWeek(FormatISOWeek(d)); err != nil || got != d {
+			t.Errorf("ParseISOWeek(FormatISOWeek(%v)) = %v, %v", d, got, err)
+		}
+	})
+}
+
+func TestISOWeekdayNumber(t *testing.T) {
+	t.Parallel()
+	monday := Of(2024, 5, 20)
+	f := NewFormatter("Mon# 2006-01-02").SetExtended(true)
+	ps := Parser{Extended: true}
+	for i := range 7 {
+		d := monday + Date(i)
+		if got, want := d.ISOWeekdayNumber(), i+1; got != want {
+			t.Errorf("%v.ISOWeekdayNumber() = %d, want %d", d, got, want)
+		}
+		if got, want := ISOWeekday(i+1), d.Weekday(); got != want {
+			t.Errorf("ISOWeekday(%d) = %v, want %v", i+1, got, want)
+		}
+		if got := d.ISOWeekStart(); got != monday {
+			t.Errorf("%v.ISOWeekStart() = %v, want %v", d, got, monday)
+		}
+		want := strconv.Itoa(i+1) + " 2024-05-" + strconv.Itoa(20+i)
+		if got := f.Format(d); got != want {
+			t.Errorf("NewFormatter(%q).SetExtended(true).Format(%v) = %q, want %q", "Mon# 2006-01-02", d, got, want)
+		}
+		if got, err := ps.Parse("Mon# 2006-01-02", want); err != nil || got != d {
+			t.Errorf("Parser{Extended: true}.Parse(%q, %q) = %v, %v, want %v, <nil>", "Mon# 2006-01-02", want, got, err, d)
+		}
+	}
+	for _, v := range []string{"0 2024-05-20", "8 2024-05-20", "x 2024-05-20", "11 2024-05-20"} {
+		if got, err := ps.Parse("Mon# 2006-01-02", v); err == nil {
+			t.Errorf("Parser{Extended: true}.Parse(%q, %q) = %v, <nil>, want error", "Mon# 2006-01-02", v, got)
+		}
+	}
+	_, warn, err := ps.ParseLenient("Mon# 2006-01-02", "7 2024-05-20")
+	if want := []ParseWarning{{WeekdayMismatch, "7"}}; err != nil || !slices.Equal(warn, want) {
+		t.Errorf("ParseLenient(%q, %q) = _, %v, %v, want %v, <nil>", "Mon# 2006-01-02", "7 2024-05-20", warn, err, want)
+	}
+	if got, want := NewFormatter("Mon#Mon Monday").SetExtended(true).Format(monday), "1Mon Monday"; got != want {
+		t.Errorf("NewFormatter(%q).SetExtended(true).Format(%v) = %q, want %q", "Mon#Mon Monday", monday, got, want)
+	}
+	for _, n := range []int{0, 8} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ISOWeekday(%d) did not panic", n)
+				}
+			}()
+			ISOWeekday(n)
+		}()
+	}
+}
+
+func TestISOWeekParity(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d    Date
+		even bool
+	}{
+		{Of(2024, 5, 20), false},  // W21
+		{Of(2024, 5, 27), true},   // W22
+		{Of(2020, 12, 31), false}, // 2020-W53
+		{Of(2021, 1, 4), false},   // 2021-W01
+		{Of(2021, 1, 3), false},   // 2020-W53
+		{Of(2024, 12, 30), false}, // 2025-W01
+		{Of(2024, 12, 29), true},  // 2024-W52
+	}
+	for _, tc := range tcs {
+		if got := tc.d.IsEvenISOWeek(); got != tc.even {
+			t.Errorf("%v.IsEvenISOWeek() = %v, want %v", tc.d, got, tc.even)
+		}
+	}
+
+	pairs := []struct {
+		a, b Date
+		want bool
+	}{
+		{Of(2024, 5, 20), Of(2024, 5, 26), true},
+		{Of(2024, 5, 20), Of(2024, 5, 27), false},
+		{Of(2024, 5, 26), Of(2024, 6, 3), true},
+		{Of(2024, 5, 26), Of(2024, 5, 19), false},
+		// 2020 has 53 weeks, so 2020-W53 and 2021-W01 are adjacent.
+		{Of(2020, 12, 31), Of(2021, 1, 4), false},
+		{Of(2020, 12, 31), Of(2021, 1, 11), true},
+		{Of(2020, 1, 1), Of(2021, 1, 1), true},
+		{Of(-1, 1, 1), Of(1, 1, 1), SameISOWeekParity(Of(1, 1, 1), Of(-1, 1, 1))},
+	}
+	for _, tc := range pairs {
+		if got := SameISOWeekParity(tc.a, tc.b); got != tc.want {
+			t.Errorf("SameISOWeekParity(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+	for d := Of(2020, 1, 1); d < Of(2030, 1, 1); d++ {
+		if !SameISOWeekParity(d, d+14) || SameISOWeekParity(d, d+7) {
+			t.Fatalf("SameISOWeekParity does not alternate at %v", d)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// MarshalJSON implements the json.Marshaler interface. The date is encoded as
+// a JSON string in ISO 8601 format. The zero Date is encoded like any other
+// date, use the omitzero option to omit it.
+func (d Date) MarshalJSON() ([]byte, error) {
+	b := append(make([]byte, 0, len(RFC3339)+2), '"')
+	b = d.AppendFormat(b, RFC3339)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The date must be a
+// JSON string in ISO 8601 format. An empty string decodes as the zero Date. As
+// is the convention, null is a no-op. To distinguish a missing date from a
+// given one, use [Optional].
+func (d *Date) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, d, RFC3339)
+}
+
+// MarshalJSON implements the json.Marshaler interface. The date is encoded as
+// a JSON string using f.Layout.
+func (f Formatted) MarshalJSON() ([]byte, error) {
+	// Layouts can contain arbitrary literals, so let encoding/json escape.
+	return json.Marshal(f.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The date must be a
+// JSON string using f.Layout, which is left unchanged. An empty string
+// decodes as the zero Date. As is the convention, null is a no-op.
+func (f *Formatted) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, &f.Date, f.layout())
+}
+
+// MarshalJSON implements the json.Marshaler interface. The date is encoded as
+// a JSON string in ISO 8601 format.
+func (t Truncated) MarshalJSON() ([]byte, error) {
+	return t.Date.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The date must be a
+// JSON string containing a date in ISO 8601 format or an RFC 3339 timestamp,
+// as by [ParseTruncate]. t.Location is left unchanged. An empty string
+// decodes as the zero Date. As is the convention, null is a no-op.
+func (t *Truncated) UnmarshalJSON(b []byte) error {
+	s, null, err := jsonString(b)
+	if err != nil || null {
+		return err
+	}
+	if s == "" {
+		t.Date = 0
+		return nil
+	}
+	v, err := ParseTruncate(s, t.location())
+	if err == nil {
+		t.Date = v
+	}
+	return err
+}
+
+// unmarshalJSON parses the JSON string b using layout and stores the result
+// in d, as documented for Date.UnmarshalJSON.
+func unmarshalJSON(b []byte, d *Date, layout string) error {
+	if len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
+		if v, ok := parseFast(layout, b[1:len(b)-1]); ok {
+			*d = v
+			return nil
+		}
+	}
+	s, null, err := jsonString(b)
+	if err != nil || null {
+		return err
+	}
+	if s == "" {
+		*d = 0
+		return nil
+	}
+	v, err := Parse(layout, s)
+	if err == nil {
+		*d = v
+	}
+	return err
+}
+
+// jsonString returns the contents of the JSON string b, with escapes decoded.
+// It reports whether b is null instead.
+func jsonString(b []byte) (s string, null bool, err error) {
+	if string(b) == "null" {
+		return "", true, nil
+	}
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		return "", false, errors.New("date must be a JSON string")
+	}
+	s = string(b[1 : len(b)-1])
+	if bytes.IndexByte(b, '\\') >= 0 {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return "", false, err
+		}
+	}
+	return s, false, nil
+}
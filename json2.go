@@ -0,0 +1,313 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.jsonv2 && go1.27
+
+package date
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// MarshalJSONTo implements the json.MarshalerTo interface of
+// encoding/json/v2. The date is encoded as a JSON string in ISO 8601 format.
+func (d Date) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return writeJSONDate(enc, d, RFC3339)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface of
+// encoding/json/v2. The date must be a JSON string in ISO 8601 format. An
+// empty string decodes as the zero Date and a JSON null is a no-op.
+func (d *Date) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readJSONDate(dec, d, RFC3339)
+}
+
+// JSONLayout returns options for encoding/json/v2, which encode and decode
+// all Dates as JSON strings in the given layout, instead of ISO 8601.
+//
+// For example, to use US-style dates:
+//
+//	json.Marshal(v, date.JSONLayout("01/02/2006"))
+func JSONLayout(layout string) json.Options {
+	return json.JoinOptions(
+		json.WithMarshalers(json.MarshalToFunc(func(enc *jsontext.Encoder, d Date) error {
+			return writeJSONDate(enc, d, layout)
+		})),
+		json.WithUnmarshalers(json.UnmarshalFromFunc(func(dec *jsontext.Decoder, d *Date) error {
+			return readJSONDate(dec, d, layout)
+		})),
+	)
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface of
+// encoding/json/v2. The date is encoded as a JSON string using f.Layout.
+func (f Formatted) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return writeJSONDate(enc, f.Date, f.layout())
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface of
+// encoding/json/v2. The date must be a JSON string using f.Layout. An empty
+// string decodes as the zero Date and a JSON null is a no-op.
+func (f *Formatted) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readJSONDate(dec, &f.Date, f.layout())
+}
+
+// JSONTagLayouts returns options for encoding/json/v2, which encode and
+// decode Date fields of structs using the layout given in their "date" struct
+// tag. For example:
+//
+//	type Message struct {
+//		Sent  date.Date `json:"sent" date:"02.01.2006"`
+//		Valid date.Date `json:"valid" date:"20060102"`
+//	}
+//	b, err := json.Marshal(msg, date.JSONTagLayouts())
+//
+// Dates without a date tag are not affected. Structs with date tags must not
+// embed other types. As both set the marshalers used, JSONTagLayouts can not be
+// combined with [JSONLayout].
+func JSONTagLayouts() json.Options {
+	return json.JoinOptions(
+		// For interface types, the functions are passed a pointer to the
+		// value.
+		json.WithMarshalers(json.MarshalToFunc(func(enc *jsontext.Encoder, v any) error {
+			rv := reflect.ValueOf(v).Elem()
+			st, err := tagLayouts(rv.Type())
+			if st == nil {
+				return cmp.Or(err, errors.ErrUnsupported)
+			}
+			sv := reflect.New(st.shadow).Elem()
+			st.toShadow(sv, rv)
+			return json.MarshalEncode(enc, sv.Interface(), enc.Options())
+		})),
+		json.WithUnmarshalers(json.UnmarshalFromFunc(func(dec *jsontext.Decoder, v any) error {
+			rv := reflect.ValueOf(v).Elem()
+			st, err := tagLayouts(rv.Type())
+			if st == nil {
+				return cmp.Or(err, errors.ErrUnsupported)
+			}
+			sv := reflect.New(st.shadow)
+			st.toShadow(sv.Elem(), rv)
+			if err := json.UnmarshalDecode(dec, sv.Interface(), dec.Options()); err != nil {
+				return err
+			}
+			st.fromShadow(rv, sv.Elem())
+			return nil
+		})),
+	)
+}
+
+// shadowType describes a struct type with date tags. shadow has the exported
+// fields of the struct, with tagged Date fields replaced by Formatted.
+type shadowType struct {
+	shadow  reflect.Type
+	fields  []int    // index of the i'th shadow field in the original struct
+	layouts []string // layout of the i'th shadow field, if it is tagged
+}
+
+var shadowTypes sync.Map // reflect.Type -> *shadowType or error
+
+// tagLayouts returns the shadowType of t. It returns nil, if t is not a struct
+// with date tags.
+func tagLayouts(t reflect.Type) (*shadowType, error) {
+	if v, ok := shadowTypes.Load(t); ok {
+		st, _ := v.(*shadowType)
+		err, _ := v.(error)
+		return st, err
+	}
+	st, err := newShadowType(t)
+	if err != nil {
+		shadowTypes.Store(t, err)
+		return nil, err
+	}
+	shadowTypes.Store(t, st)
+	return st, nil
+}
+
+func newShadowType(t reflect.Type) (*shadowType, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	var (
+		st     = new(shadowType)
+		fields []reflect.StructField
+		tagged bool
+		embeds bool
+	)
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if sf.Anonymous {
+			embeds = true
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		layout, ok := sf.Tag.Lookup("date")
+		if ok && layout != "" && sf.Type == reflect.TypeFor[Date]() {
+			tagged = true
+			sf.Type = reflect.TypeFor[Formatted]()
+		} else {
+			layout = ""
+		}
+		fields = append(fields, reflect.StructField{Name: sf.Name, Type: sf.Type, Tag: sf.Tag})
+		st.fields = append(st.fields, i)
+		st.layouts = append(st.layouts, layout)
+	}
+	if !tagged {
+		return nil, nil
+	}
+	if embeds {
+		return nil, fmt.Errorf("struct %v with date tags must not embed other types", t)
+	}
+	st.shadow = reflect.StructOf(fields)
+	return st, nil
+}
+
+func (st *shadowType) toShadow(dst, src reflect.Value) {
+	for i, j := range st.fields {
+		f := src.Field(j)
+		if l := st.layouts[i]; l != "" {
+			dst.Field(i).Set(reflect.ValueOf(Formatted{Date: f.Interface().(Date), Layout: l}))
+		} else {
+			dst.Field(i).Set(f)
+		}
+	}
+}
+
+func (st *shadowType) fromShadow(dst, src reflect.Value) {
+	for i, j := range st.fields {
+		f := src.Field(i)
+		if st.layouts[i] != "" {
+			dst.Field(j).Set(reflect.ValueOf(f.Interface().(Formatted).Date))
+		} else {
+			dst.Field(j).Set(f)
+		}
+	}
+}
+
+// writeJSONDate writes d as a JSON string formatted with layout, using the
+// buffer of enc.
+func writeJSONDate(enc *jsontext.Encoder, d Date, layout string) error {
+	b := enc.AvailableBuffer()
+	b = append(b, '"')
+	n := len(b)
+	b = d.AppendFormat(b, layout)
+	if bytes.ContainsAny(b[n:], "\"\\") || !isPrintASCII(b[n:]) {
+		// Layouts can contain arbitrary literals, so escape if necessary.
+		return enc.WriteToken(jsontext.String(string(b[n:])))
+	}
+	return enc.WriteValue(append(b, '"'))
+}
+
+// JSONTruncateTimestamps returns options for encoding/json/v2, which decode
+// all Dates from JSON strings containing either a date in ISO 8601 format or
+// an RFC 3339 timestamp, which is truncated to its date in loc, as by
+// [ParseTruncate]. Encoding is not affected.
+//
+// As both set the unmarshalers used, JSONTruncateTimestamps can not be
+// combined with [JSONLayout]. With encoding/json, use [Truncated] instead.
+func JSONTruncateTimestamps(loc *time.Location) json.Options {
+	return json.WithUnmarshalers(json.UnmarshalFromFunc(func(dec *jsontext.Decoder, d *Date) error {
+		return readJSON(dec, d, func(b []byte) (Date, error) {
+			return ParseTruncate(string(b), loc)
+		})
+	}))
+}
+
+// DecodeJSONDates decodes a JSON array of strings from r, parsing each using
+// layout. Elements which are JSON null or empty strings decode as the zero
+// Date.
+//
+// The array is decoded as a stream and every element is parsed directly from
+// the read buffer, so even arrays with millions of elements do not require
+// more memory than the result. If an error occurs, the dates decoded so far
+// are returned with it.
+func DecodeJSONDates(r io.Reader, layout string) ([]Date, error) {
+	dec := jsontext.NewDecoder(r)
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return nil, err
+	}
+	if tok.Kind() != '[' {
+		return nil, errors.New("dates must be a JSON array")
+	}
+	var ds []Date
+	for dec.PeekKind() != ']' {
+		var d Date
+		if err := readJSONDate(dec, &d, layout); err != nil {
+			return ds, fmt.Errorf("element %d: %w", len(ds), err)
+		}
+		ds = append(ds, d)
+	}
+	if _, err := dec.ReadToken(); err != nil {
+		return ds, err
+	}
+	if _, err := dec.ReadToken(); err != io.EOF {
+		return ds, errors.New("extra data after JSON array")
+	}
+	return ds, nil
+}
+
+// readJSONDate reads a JSON string from dec and parses it using layout.
+func readJSONDate(dec *jsontext.Decoder, d *Date, layout string) error {
+	return readJSON(dec, d, func(b []byte) (Date, error) {
+		// Avoid converting b to a string for the common layouts.
+		if d, ok := parseFast(layout, b); ok {
+			return d, nil
+		}
+		return Parse(layout, string(b))
+	})
+}
+
+// readJSON reads a JSON string from dec and parses it using parse. An empty
+// string is the zero Date and null is a no-op.
+func readJSON(dec *jsontext.Decoder, d *Date, parse func([]byte) (Date, error)) error {
+	v, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	switch v.Kind() {
+	case 'n':
+		return nil
+	case '"':
+	default:
+		return errors.New("date must be a JSON string")
+	}
+	var s []byte
+	if bytes.IndexByte(v, '\\') < 0 {
+		s = v[1 : len(v)-1]
+	} else if s, err = jsontext.AppendUnquote(nil, v); err != nil {
+		return err
+	}
+	if len(s) == 0 {
+		*d = 0
+		return nil
+	}
+	p, err := parse(s)
+	if err != nil {
+		return err
+	}
+	*d = p
+	return nil
+}
+
+// isPrintASCII reports whether b only consists of printable ASCII characters.
+func isPrintASCII(b []byte) bool {
+	for _, c := range b {
+		if c < ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,240 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.jsonv2 && go1.27
+
+package date
+
+import (
+	"bytes"
+	"encoding/json/v2"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONv2(t *testing.T) {
+	t.Parallel()
+	type S struct {
+		D Date `json:"d"`
+	}
+	tcs := []struct {
+		opts []json.Options
+		v    S
+		want string
+	}{
+		{nil, S{Of(2024, 5, 14)}, `{"d":"2024-05-14"}`},
+		{[]json.Options{JSONLayout("01/02/2006")}, S{Of(2024, 5, 14)}, `{"d":"05/14/2024"}`},
+		{[]json.Options{JSONLayout(`"Jan" 2`)}, S{Of(2024, 5, 14)}, `{"d":"\"May\" 14"}`},
+		{[]json.Options{JSONLayout("2. January")}, S{Of(2024, 5, 14)}, `{"d":"14. May"}`},
+	}
+	for _, tc := range tcs {
+		b, err := json.Marshal(tc.v, tc.opts...)
+		if err != nil {
+			t.Errorf("json.Marshal(%v) = _, %v", tc.v, err)
+			continue
+		}
+		if string(b) != tc.want {
+			t.Errorf("json.Marshal(%v) = %s, want %s", tc.v, b, tc.want)
+		}
+		var got S
+		if err := json.Unmarshal(b, &got, tc.opts...); err != nil || got.D.Day() != tc.v.D.Day() {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v, <nil>", b, got, err, tc.v)
+		}
+	}
+}
+
+func TestJSONv2Unmarshal(t *testing.T) {
+	t.Parallel()
+	type S struct {
+		D Date `json:"d"`
+	}
+	tcs := []struct {
+		in   string
+		want Date
+		err  bool
+	}{
+		{`{"d":"2024-05-14"}`, Of(2024, 5, 14), false},
+		{`{"d":"2024\u002d05-14"}`, Of(2024, 5, 14), false},
+		{`{"d":null}`, 42, false},
+		{`{"d":19857}`, 0, true},
+		{`{"d":"2024-13-14"}`, 0, true},
+	}
+	for _, tc := range tcs {
+		s := S{42}
+		err := json.Unmarshal([]byte(tc.in), &s)
+		if (err != nil) != tc.err {
+			t.Errorf("json.Unmarshal(%s) = %v, want error: %v", tc.in, err, tc.err)
+			continue
+		}
+		if err == nil && s.D != tc.want {
+			t.Errorf("json.Unmarshal(%s) = %v, want %v", tc.in, s.D, tc.want)
+		}
+	}
+}
+
+func TestJSONv2OmitZero(t *testing.T) {
+	t.Parallel()
+	type S struct {
+		D Date `json:"d,omitzero"`
+	}
+	for _, tc := range []struct {
+		v    S
+		want string
+	}{
+		{S{}, `{}`},
+		{S{Of(2024, 5, 14)}, `{"d":"2024-05-14"}`},
+	} {
+		b, err := json.Marshal(tc.v)
+		if err != nil || string(b) != tc.want {
+			t.Errorf("json.Marshal(%v) = %s, %v, want %s, <nil>", tc.v, b, err, tc.want)
+		}
+	}
+}
+
+func TestJSONTagLayouts(t *testing.T) {
+	t.Parallel()
+	type item struct {
+		Name  string `json:"name"`
+		Since Date   `json:"since" date:"20060102"`
+	}
+	type message struct {
+		Sent    Date   `json:"sent" date:"02.01.2006"`
+		Valid   Date   `json:"valid,omitzero" date:"January 2, 2006"`
+		Created Date   `json:"created"`
+		Items   []item `json:"items"`
+		hidden  int
+	}
+	in := message{
+		Sent:    Of(2024, 5, 14),
+		Created: Of(2024, 5, 1),
+		Items:   []item{{"a", Of(2023, 1, 2)}, {"b", Of(2024, 3, 4)}},
+		hidden:  42,
+	}
+	want := `{"sent":"14.05.2024","created":"2024-05-01","items":[{"name":"a","since":"20230102"},{"name":"b","since":"20240304"}]}`
+	opts := JSONTagLayouts()
+	b, err := json.Marshal(in, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != want {
+		t.Errorf("json.Marshal(%v) = %s, want %s", in, b, want)
+	}
+
+	got := message{Valid: Of(2024, 6, 1), hidden: 23}
+	if err := json.Unmarshal([]byte(want), &got, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got.Sent != in.Sent || got.Created != in.Created || got.Valid != Of(2024, 6, 1) || got.hidden != 23 || !slices.Equal(got.Items, in.Items) {
+		t.Errorf("json.Unmarshal(%s) = %+v", want, got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"sent":"2024-05-14"}`), &got, opts); err == nil {
+		t.Error("json.Unmarshal with wrong layout succeeded")
+	}
+
+	type embedded struct {
+		item
+		D Date `date:"20060102"`
+	}
+	if _, err := json.Marshal(embedded{}, JSONTagLayouts()); err == nil {
+		t.Error("json.Marshal of struct with embedded field succeeded")
+	}
+}
+
+func TestFormattedJSONv2(t *testing.T) {
+	t.Parallel()
+	f := Formatted{Date: Of(2024, 5, 14), Layout: `"Jan" 2`}
+	b, err := json.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `"\"May\" 14"`; got != want {
+		t.Errorf("json.Marshal(%v) = %s, want %s", f, got, want)
+	}
+	g := Formatted{Date: 42, Layout: EuropeanDotted}
+	if err := json.Unmarshal([]byte(`null`), &g); err != nil || g != (Formatted{42, EuropeanDotted}) {
+		t.Errorf("json.Unmarshal(null) = %v, %v, want %v, <nil>", g, err, Formatted{42, EuropeanDotted})
+	}
+}
+
+func TestJSONTruncateTimestamps(t *testing.T) {
+	t.Parallel()
+	type S struct {
+		D Date `json:"d"`
+	}
+	loc := time.FixedZone("CEST", 2*60*60)
+	tcs := []struct {
+		in   string
+		want Date
+		err  bool
+	}{
+		{`{"d":"2024-05-14"}`, Of(2024, 5, 14), false},
+		{`{"d":"2024-05-14T22:30:00Z"}`, Of(2024, 5, 15), false},
+		{`{"d":"2024-05-14T22:30:00+02:00"}`, Of(2024, 5, 14), false},
+		{`{"d":null}`, 42, false},
+		{`{"d":"2024-05-14T22:30"}`, 0, true},
+		{`{"d":1715724600}`, 0, true},
+	}
+	for _, tc := range tcs {
+		got := S{42}
+		err := json.Unmarshal([]byte(tc.in), &got, JSONTruncateTimestamps(loc))
+		if tc.err {
+			if err == nil {
+				t.Errorf("json.Unmarshal(%s) succeeded", tc.in)
+			}
+			continue
+		}
+		if err != nil || got.D != tc.want {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v, <nil>", tc.in, got.D, err, tc.want)
+		}
+	}
+}
+
+func TestDecodeJSONDates(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		in     string
+		layout string
+		want   []Date
+		err    bool
+	}{
+		{`[]`, RFC3339, nil, false},
+		{` ["2024-05-14", null, "2024-05-15"] `, RFC3339, []Date{Of(2024, 5, 14), 0, Of(2024, 5, 15)}, false},
+		{`["20240514","20240229"]`, ISOBasic, []Date{Of(2024, 5, 14), Of(2024, 2, 29)}, false},
+		{`["2024-05-14","2024-02-30"]`, RFC3339, []Date{Of(2024, 5, 14)}, true},
+		{`["2024-05-14",42]`, RFC3339, []Date{Of(2024, 5, 14)}, true},
+		{`["2024-05-14"`, RFC3339, []Date{Of(2024, 5, 14)}, true},
+		{`["2024-05-14"] []`, RFC3339, []Date{Of(2024, 5, 14)}, true},
+		{`"2024-05-14"`, RFC3339, nil, true},
+		{``, RFC3339, nil, true},
+	}
+	for _, tc := range tcs {
+		got, err := DecodeJSONDates(strings.NewReader(tc.in), tc.layout)
+		if !slices.Equal(got, tc.want) || (err != nil) != tc.err {
+			t.Errorf("DecodeJSONDates(%#q, %q) = %v, %v, want %v, <error: %v>", tc.in, tc.layout, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+func BenchmarkDecodeJSONDates(b *testing.B) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i := range 10000 {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, "%q", Date(738000+i))
+	}
+	buf.WriteByte(']')
+	b.SetBytes(int64(buf.Len()))
+	for b.Loop() {
+		if _, err := DecodeJSONDates(bytes.NewReader(buf.Bytes()), RFC3339); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
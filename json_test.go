@@ -0,0 +1,53 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDateJSON(t *testing.T) {
+	t.Parallel()
+	type S struct {
+		D Date      `json:"d"`
+		F Formatted `json:"f"`
+	}
+	in := S{Of(2024, 5, 14), Formatted{Of(2024, 6, 13), `"Jan" 2`}}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"d":"2024-05-14","f":"\"Jun\" 13"}`; got != want {
+		t.Errorf("json.Marshal(%v) = %s, want %s", in, got, want)
+	}
+
+	tcs := []struct {
+		in   string
+		want S
+		err  bool
+	}{
+		{`{"d":"2024-05-14","f":"05/14/2024"}`, S{Of(2024, 5, 14), Formatted{Of(2024, 5, 14), US}}, false},
+		{`{"d":null,"f":null}`, S{Of(2000, 1, 1), Formatted{Of(2000, 1, 1), US}}, false},
+		{`{"d":"","f":""}`, S{0, Formatted{0, US}}, false},
+		{`{"d":"2024-05-14"}`, S{Of(2024, 5, 14), Formatted{Of(2000, 1, 1), US}}, false},
+		{`{"d":"2024\u002d05-14"}`, S{Of(2024, 5, 14), Formatted{Of(2000, 1, 1), US}}, false},
+		{`{"d":20240514}`, S{}, true},
+		{`{"d":"May 14"}`, S{}, true},
+		{`{"f":"2024-05-14"}`, S{}, true},
+	}
+	for _, tc := range tcs {
+		s := S{Of(2000, 1, 1), Formatted{Of(2000, 1, 1), US}}
+		err := json.Unmarshal([]byte(tc.in), &s)
+		if (err != nil) != tc.err {
+			t.Errorf("json.Unmarshal(%s) = %v, want error: %v", tc.in, err, tc.err)
+			continue
+		}
+		if err == nil && s != tc.want {
+			t.Errorf("json.Unmarshal(%s) = %v, want %v", tc.in, s, tc.want)
+		}
+	}
+}
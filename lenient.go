@@ -0,0 +1,83 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A ParseIssue is a problem with a parsed value, which
+// [Parser.ParseLenient] worked around.
+type ParseIssue int
+
+// Issues worked around by [Parser.ParseLenient].
+const (
+	// WeekdayMismatch means the day of the week does not match the date.
+	// The day of the week is ignored, as by Parse.
+	WeekdayMismatch ParseIssue = iota + 1
+
+	// DayClamped means the day of the month is out of range, like
+	// "February 30". It is clamped to the nearest valid day of the month.
+	DayClamped
+
+	// ExtraText means the value contains text after the layout has been
+	// fully matched. The text is ignored.
+	ExtraText
+)
+
+var parseIssueDescriptions = [...]string{
+	WeekdayMismatch: "day of the week does not match date",
+	DayClamped:      "day out of range clamped",
+	ExtraText:       "extra text ignored",
+}
+
+// String returns a short description of i.
+func (i ParseIssue) String() string {
+	if i <= 0 || int(i) >= len(parseIssueDescriptions) {
+		return "ParseIssue(" + strconv.Itoa(int(i)) + ")"
+	}
+	return parseIssueDescriptions[i]
+}
+
+// A ParseWarning is an issue found by [Parser.ParseLenient].
+type ParseWarning struct {
+	Issue ParseIssue
+	// Text is the part of the value causing the issue.
+	Text string
+}
+
+// String returns a description of w, like `extra text ignored: " UTC"`.
+func (w ParseWarning) String() string {
+	return w.Issue.String() + ": " + strconv.Quote(w.Text)
+}
+
+// ParseLenient is like [Parser.Parse], but works around some problems with
+// value instead of failing. It returns the best reconstruction of the date and
+// a warning for every problem it worked around, in the order they were
+// found. This is useful for cleaning data, where an audit trail of repairs is
+// preferable to dropping records.
+//
+// The problems worked around are listed as the values of [ParseIssue]. All
+// other problems, like values not matching the layout or months out of range,
+// are still returned as errors.
+func (ps Parser) ParseLenient(layout, value string) (Date, []ParseWarning, error) {
+	if ps.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+	if d, ok := parseFast(layout, value); ok {
+		if err := ps.checkWithin(d, value); err != nil {
+			return 0, nil, err
+		}
+		return d, nil, nil
+	}
+	var warn []ParseWarning
+	d, err := parseProg(ps.compile(layout), layout, value, ps, &warn)
+	if err != nil {
+		return 0, nil, err
+	}
+	return d, warn, nil
+}
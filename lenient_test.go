@@ -0,0 +1,67 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseLenient(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		layout string
+		value  string
+		want   Date
+		warn   []ParseWarning
+	}{
+		{RFC3339, "2024-05-14", Of(2024, 5, 14), nil},
+		{"Mon, 2006-01-02", "Tue, 2024-05-14", Of(2024, 5, 14), nil},
+		{"Mon, 2006-01-02", "Fri, 2024-05-14", Of(2024, 5, 14), []ParseWarning{{WeekdayMismatch, "Fri"}}},
+		{"Monday, 2 January 2006", "friday, 14 May 2024", Of(2024, 5, 14), []ParseWarning{{WeekdayMismatch, "friday"}}},
+		{RFC3339, "2023-02-30", Of(2023, 2, 28), []ParseWarning{{DayClamped, "30"}}},
+		{RFC3339, "2024-02-30", Of(2024, 2, 29), []ParseWarning{{DayClamped, "30"}}},
+		{"Jan _2 2006", "Apr 31 2024", Of(2024, 4, 30), []ParseWarning{{DayClamped, "31"}}},
+		{RFC3339, "2024-05-00", Of(2024, 5, 1), []ParseWarning{{DayClamped, "00"}}},
+		{RFC3339, "2024-05-14T10:00:00Z", Of(2024, 5, 14), []ParseWarning{{ExtraText, "T10:00:00Z"}}},
+		{
+			"Mon 2006-01-02", "Mon 2024-04-31 junk", Of(2024, 4, 30),
+			[]ParseWarning{{ExtraText, " junk"}, {DayClamped, "31"}, {WeekdayMismatch, "Mon"}},
+		},
+	}
+	for _, tc := range tcs {
+		got, warn, err := Parser{}.ParseLenient(tc.layout, tc.value)
+		if err != nil || got != tc.want || !slices.Equal(warn, tc.warn) {
+			t.Errorf("ParseLenient(%q, %q) = %v, %v, %v, want %v, %v, <nil>", tc.layout, tc.value, got, warn, err, tc.want, tc.warn)
+		}
+	}
+
+	errs := []struct {
+		layout string
+		value  string
+	}{
+		{RFC3339, "2024-13-01"},
+		{RFC3339, "2024/05/14"},
+		{"Mon 2006-01-02", "Xyz 2024-05-14"},
+		{"2006 002", "2024 367"},
+	}
+	for _, tc := range errs {
+		if got, warn, err := (Parser{}).ParseLenient(tc.layout, tc.value); err == nil {
+			t.Errorf("ParseLenient(%q, %q) = %v, %v, <nil>, want error", tc.layout, tc.value, got, warn)
+		}
+	}
+}
+
+func TestParseWarningString(t *testing.T) {
+	t.Parallel()
+	w := ParseWarning{ExtraText, " UTC"}
+	if got, want := w.String(), `extra text ignored: " UTC"`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := ParseIssue(0).String(), "ParseIssue(0)"; got != want {
+		t.Errorf("ParseIssue(0).String() = %q, want %q", got, want)
+	}
+}
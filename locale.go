@@ -0,0 +1,260 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"strings"
+	"sync"
+)
+
+// A Locale provides the names of months and weekdays used when formatting and
+// parsing dates, as by [Date.FormatIn] and [ParseIn]. Names are matched
+// case-insensitively when parsing.
+//
+// Locales for a number of languages, derived from the Unicode CLDR, are
+// provided by the subpackages of [gonih.org/date/locale]. Locales can be
+// looked up by their language tag with [LookupLocale].
+type Locale struct {
+	// Months are the names of the months, starting with January, as
+	// formatted by "January".
+	Months [12]string
+
+	// ShortMonths are the abbreviated names of the months, as formatted by
+	// "Jan".
+	ShortMonths [12]string
+
+	// StandaloneMonths and ShortStandaloneMonths are the names of the months
+	// used when the layout contains no day of the month, as in "May 2024".
+	// Many languages, like Ukrainian, use a different grammatical form for
+	// a month with a day, as in "14 травня 2024", than for the month on its
+	// own, as in "травень 2024". Months and ShortMonths then contain the
+	// former and StandaloneMonths and ShortStandaloneMonths the latter.
+	//
+	// If they are empty, Months and ShortMonths are used for both. When
+	// parsing, both forms are accepted.
+	StandaloneMonths, ShortStandaloneMonths [12]string
+
+	// Weekdays are the names of the days of the week, indexed by
+	// [time.Weekday], as formatted by "Monday".
+	Weekdays [7]string
+
+	// ShortWeekdays are the abbreviated names of the days of the week, as
+	// formatted by "Mon".
+	ShortWeekdays [7]string
+
+	// MinWeekdays are the shortest names of the days of the week, usually
+	// two letters long, as used in the headers of calendars. They are
+	// formatted by the extended element "Mo". If they are empty, the first
+	// two letters of ShortWeekdays are used.
+	MinWeekdays [7]string
+
+	// NarrowMonths and NarrowWeekdays are the narrowest names of the months
+	// and days of the week, usually a single letter, as used in the headers
+	// of calendars. They are formatted by "J" and "M". If they are empty,
+	// the first letters of Months and Weekdays are used.
+	NarrowMonths   [12]string
+	NarrowWeekdays [7]string
+}
+
+// english is the Locale used by Format and Parse.
+var english = Locale{
+	Months: [12]string{
+		"January",
+		"February",
+		"March",
+		"April",
+		"May",
+		"June",
+		"July",
+		"August",
+		"September",
+		"October",
+		"November",
+		"December",
+	},
+	ShortMonths: [12]string{
+		"Jan",
+		"Feb",
+		"Mar",
+		"Apr",
+		"May",
+		"Jun",
+		"Jul",
+		"Aug",
+		"Sep",
+		"Oct",
+		"Nov",
+		"Dec",
+	},
+	Weekdays: [7]string{
+		"Sunday",
+		"Monday",
+		"Tuesday",
+		"Wednesday",
+		"Thursday",
+		"Friday",
+		"Saturday",
+	},
+	ShortWeekdays: [7]string{
+		"Sun",
+		"Mon",
+		"Tue",
+		"Wed",
+		"Thu",
+		"Fri",
+		"Sat",
+	},
+	MinWeekdays: [7]string{
+		"Su",
+		"Mo",
+		"Tu",
+		"We",
+		"Th",
+		"Fr",
+		"Sa",
+	},
+	NarrowMonths:   [12]string{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+	NarrowWeekdays: [7]string{"S", "M", "T", "W", "T", "F", "S"},
+}
+
+// FormatIn is like [Date.Format], but uses the names of months and weekdays
+// of loc. If loc is nil, English names are used. If the layout contains no day
+// of the month, the standalone forms of month names are used.
+func (d Date) FormatIn(layout string, loc *Locale) string {
+	return string(d.AppendFormatIn(nil, layout, loc))
+}
+
+// AppendFormatIn is like [Date.FormatIn] but appends the textual
+// representation to b and returns the extended buffer.
+func (d Date) AppendFormatIn(b []byte, layout string, loc *Locale) []byte {
+	if loc == nil {
+		return d.AppendFormat(b, layout)
+	}
+	return d.appendProg(b, memo.Get(layout, parseLayout), loc, KeepCase)
+}
+
+// ParseIn is like [Parse], but uses the names of months and weekdays of loc.
+// If loc is nil, English names are used.
+func ParseIn(layout, value string, loc *Locale) (Date, error) {
+	return Parser{Locale: loc}.Parse(layout, value)
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]*Locale{"en": &english}
+)
+
+// RegisterLocale registers loc under the given language tag, like "de" or
+// "pt-BR", so it can be found by [LookupLocale]. It panics, if loc is nil or
+// the tag is empty or already registered. The English locale is registered as
+// "en".
+//
+// The locales provided by the subpackages of [gonih.org/date/locale] are
+// registered when they are imported. Applications can register custom
+// locales, usually during initialization.
+func RegisterLocale(tag string, loc *Locale) {
+	if tag == "" || loc == nil {
+		panic("date: RegisterLocale with empty tag or nil locale")
+	}
+	key := localeKey(tag)
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	if _, ok := locales[key]; ok {
+		panic("date: locale " + tag + " already registered")
+	}
+	locales[key] = loc
+}
+
+// LookupLocale returns the locale registered under the given language tag.
+// Tags are matched case-insensitively and "_" is treated like "-". If no
+// locale is registered for the tag, its subtags are removed from the end,
+// until a locale is found, so "de-AT" falls back to "de".
+func LookupLocale(tag string) (*Locale, bool) {
+	key := localeKey(tag)
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	for key != "" {
+		if loc, ok := locales[key]; ok {
+			return loc, true
+		}
+		i := strings.LastIndexByte(key, '-')
+		if i < 0 {
+			break
+		}
+		key = key[:i]
+	}
+	return nil, false
+}
+
+// localeKey returns the normalized form of tag.
+func localeKey(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+}
+
+// standaloneMonths returns the standalone forms of the names of months in loc
+// or, if it has none, the ordinary names.
+func (loc *Locale) standaloneMonths(short bool) *[12]string {
+	switch {
+	case short && loc.ShortStandaloneMonths[0] != "":
+		return &loc.ShortStandaloneMonths
+	case !short && loc.StandaloneMonths[0] != "":
+		return &loc.StandaloneMonths
+	case short:
+		return &loc.ShortMonths
+	default:
+		return &loc.Months
+	}
+}
+
+// minWeekdays returns the MinWeekdays of loc or, if they are empty, the first
+// two letters of ShortWeekdays.
+func (loc *Locale) minWeekdays() [7]string {
+	if loc.MinWeekdays[0] != "" {
+		return loc.MinWeekdays
+	}
+	var out [7]string
+	for i, s := range loc.ShortWeekdays {
+		out[i] = prefixRunes(s, 2)
+	}
+	return out
+}
+
+// narrowMonths returns the NarrowMonths of loc or, if they are empty, the
+// first letters of Months.
+func (loc *Locale) narrowMonths() [12]string {
+	if loc.NarrowMonths[0] != "" {
+		return loc.NarrowMonths
+	}
+	var out [12]string
+	for i, s := range loc.Months {
+		out[i] = prefixRunes(s, 1)
+	}
+	return out
+}
+
+// narrowWeekdays returns the NarrowWeekdays of loc or, if they are empty, the
+// first letters of Weekdays.
+func (loc *Locale) narrowWeekdays() [7]string {
+	if loc.NarrowWeekdays[0] != "" {
+		return loc.NarrowWeekdays
+	}
+	var out [7]string
+	for i, s := range loc.Weekdays {
+		out[i] = prefixRunes(s, 1)
+	}
+	return out
+}
+
+// prefixRunes returns the first n runes of s.
+func prefixRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
@@ -0,0 +1,30 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package locale contains the names of months and weekdays for a number of
+// languages, for use with [gonih.org/date.Date.FormatIn] and
+// [gonih.org/date.ParseIn]. Every locale is provided by a subpackage, named
+// after its CLDR locale identifier, which exports a [gonih.org/date.Locale]
+// called Locale:
+//
+//	s := d.FormatIn("2. January 2006", de.Locale)
+//
+// Importing a subpackage also registers its locale, so it can be looked up
+// with [gonih.org/date.LookupLocale], for example using the language of a
+// request:
+//
+//	import _ "gonih.org/date/locale/de"
+//
+//	loc, ok := date.LookupLocale("de-AT")
+//
+// The subpackages are generated from the CLDR data in the cldr directory,
+// which is an excerpt of the cldr-dates-full package of the cldr-json project
+// (https://github.com/unicode-org/cldr-json). To update the data, replace the
+// files in the cldr directory with those of a newer release and run go
+// generate. To add a locale, add its ca-gregorian.json file and list it in the
+// go:generate directive below.
+package locale
+
+//go:generate go run gonih.org/date/internal/cldrgen -cldr cldr -out . de fr pl uk
@@ -0,0 +1,210 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"errors"
+	"testing"
+)
+
+// testLocale is a Locale with non-ASCII names of varying length, some of which
+// are prefixes of others.
+var testLocale = &Locale{
+	Months:        [12]string{"Ιαν", "Ιανουάριος", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"},
+	ShortMonths:   [12]string{"ia", "fe", "mä", "ap", "ma", "jn", "jl", "au", "se", "ok", "no", "de"},
+	Weekdays:      [7]string{"Sö", "Mö", "Tü", "Wö", "Dö", "Fö", "Sä"},
+	ShortWeekdays: [7]string{"S", "M", "T", "W", "D", "F", "SA"},
+}
+
+func TestFormatIn(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d      Date
+		layout string
+		loc    *Locale
+		want   string
+	}{
+		{Of(2024, 3, 14), "Mon Monday 2 Jan January 2006", nil, "Thu Thursday 14 Mar March 2024"},
+		{Of(2024, 3, 14), "Mon Monday 2 Jan January 2006", &english, "Thu Thursday 14 Mar March 2024"},
+		{Of(2024, 3, 16), "Mon Monday 2 Jan January 2006", testLocale, "SA Sä 16 mä c 2024"},
+		{Of(2024, 2, 4), "Mon Monday 2 Jan January 2006", testLocale, "S Sö 4 fe Ιανουάριος 2024"},
+		{Of(2024, 3, 14), ISOBasic, testLocale, "20240314"},
+	}
+	for _, tc := range tcs {
+		if got := tc.d.FormatIn(tc.layout, tc.loc); got != tc.want {
+			t.Errorf("%v.FormatIn(%q, _) = %q, want %q", tc.d, tc.layout, got, tc.want)
+		}
+		if tc.loc == nil {
+			continue
+		}
+		got, err := ParseIn(tc.layout, tc.want, tc.loc)
+		if err != nil || got != tc.d {
+			t.Errorf("ParseIn(%q, %q, _) = %v, %v, want %v, <nil>", tc.layout, tc.want, got, err, tc.d)
+		}
+	}
+}
+
+func TestParseIn(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		layout, value string
+		want          Date
+		err           bool
+	}{
+		{"January 2006", "ΙΑΝΟΥΆΡΙΟΣ 2024", Of(2024, 2, 1), false},
+		{"January 2006", "ιαν 2024", Of(2024, 1, 1), false},
+		{"Jan 2006", "MÄ 2024", Of(2024, 3, 1), false},
+		{"Mon 2006-01-02", "sa 2024-03-16", Of(2024, 3, 16), false},
+		{"Jan 2006", "Mar 2024", 0, true},
+	}
+	for _, tc := range tcs {
+		got, err := ParseIn(tc.layout, tc.value, testLocale)
+		if got != tc.want || (err != nil) != tc.err {
+			t.Errorf("ParseIn(%q, %q, _) = %v, %v, want %v, <error: %v>", tc.layout, tc.value, got, err, tc.want, tc.err)
+		}
+	}
+	if got, err := ParseIn("Jan 2006", "Mar 2024", nil); err != nil || got != Of(2024, 3, 1) {
+		t.Errorf("ParseIn(%q, %q, nil) = %v, %v, want %v, <nil>", "Jan 2006", "Mar 2024", got, err, Of(2024, 3, 1))
+	}
+}
+
+func TestStandaloneMonths(t *testing.T) {
+	t.Parallel()
+	loc := &Locale{
+		Months:           [12]string{"січня", "лютого", "березня", "квітня", "травня", "червня", "липня", "серпня", "вересня", "жовтня", "листопада", "грудня"},
+		ShortMonths:      [12]string{"січ.", "лют.", "бер.", "квіт.", "трав.", "черв.", "лип.", "серп.", "вер.", "жовт.", "лист.", "груд."},
+		StandaloneMonths: [12]string{"січень", "лютий", "березень", "квітень", "травень", "червень", "липень", "серпень", "вересень", "жовтень", "листопад", "грудень"},
+	}
+	d := Of(2024, 5, 14)
+	tcs := []struct {
+		layout string
+		want   string
+	}{
+		{"2 January 2006", "14 травня 2024"},
+		{"02 January", "14 травня"},
+		{"January 2", "травня 14"},
+		{"January 2006", "травень 2024"},
+		{"Jan 2006", "трав. 2024"},
+		{"2 Jan 2006", "14 трав. 2024"},
+	}
+	for _, tc := range tcs {
+		if got := d.FormatIn(tc.layout, loc); got != tc.want {
+			t.Errorf("%v.FormatIn(%q, _) = %q, want %q", d, tc.layout, got, tc.want)
+		}
+	}
+	// Both forms are accepted when parsing.
+	for _, s := range []string{"травень 2024", "травня 2024", "ТРАВЕНЬ 2024"} {
+		if got, err := ParseIn("January 2006", s, loc); err != nil || got != Of(2024, 5, 1) {
+			t.Errorf("ParseIn(%q, %q, _) = %v, %v, want %v, <nil>", "January 2006", s, got, err, Of(2024, 5, 1))
+		}
+	}
+}
+
+func TestMinWeekdays(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 14)
+	tcs := []struct {
+		layout string
+		loc    *Locale
+		want   string
+	}{
+		{"Mo 02.01.", nil, "Tu 14.05."},
+		{"Mo Mon Monday", nil, "Tu Tue Tuesday"},
+		{"Month Mo", nil, "Month Tu"},
+		{"Mo", testLocale, "T"},
+		{"Mo", &Locale{ShortWeekdays: [7]string{"", "", "Üte"}}, "Üt"},
+	}
+	for _, tc := range tcs {
+		if got := NewFormatter(tc.layout).SetLocale(tc.loc).SetExtended(true).Format(d); got != tc.want {
+			t.Errorf("NewFormatter(%q).SetLocale(_).SetExtended(true).Format(%v) = %q, want %q", tc.layout, d, got, tc.want)
+		}
+	}
+	ps := Parser{Extended: true}
+	for _, s := range []string{"Tu 2024-05-14", "tu 2024-05-14", "Mo 2024-05-14"} {
+		if got, err := ps.Parse("Mo 2006-01-02", s); err != nil || got != d {
+			t.Errorf("Parser{Extended: true}.Parse(%q, %q) = %v, %v, want %v, <nil>", "Mo 2006-01-02", s, got, err, d)
+		}
+	}
+	if _, err := ps.Parse("Mo 2006-01-02", "Tue 2024-05-14"); err == nil {
+		t.Errorf("Parser{Extended: true}.Parse(%q, %q) succeeded, want error", "Mo 2006-01-02", "Tue 2024-05-14")
+	}
+}
+
+func TestNarrowNames(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 14)
+	tcs := []struct {
+		layout string
+		loc    *Locale
+		want   string
+	}{
+		{"M J", nil, "T M"},
+		{"J/M 2", nil, "M/T 14"},
+		{"Jan Mon JAN MJ", nil, "May Tue JAN MJ"},
+		{"M", &Locale{Weekdays: [7]string{"", "", "Übermorgen"}}, "Ü"},
+		{"J", &Locale{Months: [12]string{"", "", "", "", "mai"}}, "m"},
+	}
+	for _, tc := range tcs {
+		if got := NewFormatter(tc.layout).SetLocale(tc.loc).SetExtended(true).Format(d); got != tc.want {
+			t.Errorf("NewFormatter(%q).SetLocale(_).SetExtended(true).Format(%v) = %q, want %q", tc.layout, d, got, tc.want)
+		}
+	}
+	ps := Parser{Extended: true}
+	for _, layout := range []string{"M 2006-01-02", "J 2006-01-02"} {
+		value := NewFormatter(layout).SetExtended(true).Format(d)
+		_, err := ps.Parse(layout, value)
+		if pe, ok := err.(*ParseError); !ok || pe.Message == "" || !errors.Is(err, ErrLayout) {
+			t.Errorf("Parser{Extended: true}.Parse(%q, %q) = _, %v, want *ParseError wrapping ErrLayout", layout, value, err)
+		}
+	}
+	// Literals adjacent to letters are not affected.
+	if got, err := ps.Parse("PM 2006-01-02", "PM 2024-05-14"); err != nil || got != d {
+		t.Errorf("Parser{Extended: true}.Parse(%q, %q) = %v, %v, want %v, <nil>", "PM 2006-01-02", "PM 2024-05-14", got, err, d)
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	t.Parallel()
+	RegisterLocale("x-Test", testLocale)
+	t.Cleanup(func() {
+		// Allow registering again, when the test is run repeatedly.
+		localesMu.Lock()
+		defer localesMu.Unlock()
+		delete(locales, localeKey("x-Test"))
+	})
+	tcs := []struct {
+		tag  string
+		want *Locale
+	}{
+		{"en", &english},
+		{"en-US", &english},
+		{"x-test", testLocale},
+		{"X_TEST_Latn", testLocale},
+		{"x", nil},
+		{"", nil},
+		{"xx-test", nil},
+	}
+	for _, tc := range tcs {
+		got, ok := LookupLocale(tc.tag)
+		if got != tc.want || ok != (tc.want != nil) {
+			t.Errorf("LookupLocale(%q) = %p, %v, want %p, %v", tc.tag, got, ok, tc.want, tc.want != nil)
+		}
+	}
+
+	for _, tc := range []struct {
+		tag string
+		loc *Locale
+	}{{"", testLocale}, {"y-test", nil}, {"X_test", testLocale}, {"EN", &english}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterLocale(%q, %p) did not panic", tc.tag, tc.loc)
+				}
+			}()
+			RegisterLocale(tc.tag, tc.loc)
+		}()
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "time"
+
+// A MonthPolicy determines the result of adding months to a date, if the
+// resulting month is too short to contain its day of the month.
+type MonthPolicy int
+
+const (
+	// Clamp uses the last day of the resulting month instead. One month
+	// after January 31st is the last day of February.
+	Clamp MonthPolicy = iota
+
+	// Overflow normalizes the result into the following month, like
+	// [Date.AddDate]. One month after January 31st is March 2nd or 3rd.
+	Overflow
+
+	// EndOfMonth is like Clamp, but also maps the last day of a month to the
+	// last day of the resulting month. One month after February 29th is
+	// March 31st. This is the end-of-month rule used for financial
+	// instruments.
+	EndOfMonth
+)
+
+// AddMonths returns the date n months after d, or before d if n is negative,
+// applying the policy p.
+func (p MonthPolicy) AddMonths(d Date, n int) Date {
+	switch p {
+	case Overflow:
+		return d.AddDate(0, n, 0)
+	case EndOfMonth:
+		year, month, day := d.Date()
+		if day == daysIn(month, year) {
+			return Of(year, month+time.Month(n)+1, 0)
+		}
+	}
+	return d.addMonthsClamped(n)
+}
+
+// AddQuarters returns the date n quarters after d, or before d if n is
+// negative, applying the policy p.
+func (p MonthPolicy) AddQuarters(d Date, n int) Date {
+	return p.AddMonths(d, 3*n)
+}
+
+// Anniversary returns the n-th anniversary of start, or an earlier one if n is
+// negative. The policy p determines the anniversaries of the last day of
+// February: With Clamp, the first anniversary of 2000-02-29 is 2001-02-28,
+// with Overflow it is 2001-03-01.
+func Anniversary(start Date, n int, p MonthPolicy) Date {
+	return p.AddMonths(start, 12*n)
+}
+
+// AddMonths returns the date n months after d, or before d if n is negative.
+// If the resulting month has fewer days than the day of the month of d, the
+// last day of that month is used instead. Use a [MonthPolicy] for other
+// behavior.
+func (d Date) AddMonths(n int) Date {
+	return Clamp.AddMonths(d, n)
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "testing"
+
+func TestMonthPolicy(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d                         Date
+		n                         int
+		clamp, overflow, endMonth Date
+	}{
+		{Of(2024, 5, 14), 1, Of(2024, 6, 14), Of(2024, 6, 14), Of(2024, 6, 14)},
+		{Of(2024, 1, 31), 1, Of(2024, 2, 29), Of(2024, 3, 2), Of(2024, 2, 29)},
+		{Of(2023, 1, 31), 1, Of(2023, 2, 28), Of(2023, 3, 3), Of(2023, 2, 28)},
+		{Of(2024, 2, 29), 1, Of(2024, 3, 29), Of(2024, 3, 29), Of(2024, 3, 31)},
+		{Of(2024, 4, 30), -2, Of(2024, 2, 29), Of(2024, 3, 1), Of(2024, 2, 29)},
+		{Of(2024, 4, 30), 1, Of(2024, 5, 30), Of(2024, 5, 30), Of(2024, 5, 31)},
+		{Of(2024, 3, 31), -1, Of(2024, 2, 29), Of(2024, 3, 2), Of(2024, 2, 29)},
+		{Of(2024, 11, 30), 3, Of(2025, 2, 28), Of(2025, 3, 2), Of(2025, 2, 28)},
+		{Of(2024, 12, 31), 14, Of(2026, 2, 28), Of(2026, 3, 3), Of(2026, 2, 28)},
+	}
+	for _, tc := range tcs {
+		for _, c := range []struct {
+			p    MonthPolicy
+			want Date
+		}{
+			{Clamp, tc.clamp},
+			{Overflow, tc.overflow},
+			{EndOfMonth, tc.endMonth},
+		} {
+			if got := c.p.AddMonths(tc.d, tc.n); got != c.want {
+				t.Errorf("MonthPolicy(%d).AddMonths(%v, %d) = %v, want %v", c.p, tc.d, tc.n, got, c.want)
+			}
+			if tc.n%3 == 0 {
+				if got := c.p.AddQuarters(tc.d, tc.n/3); got != c.want {
+					t.Errorf("MonthPolicy(%d).AddQuarters(%v, %d) = %v, want %v", c.p, tc.d, tc.n/3, got, c.want)
+				}
+			}
+		}
+		if got := tc.d.AddMonths(tc.n); got != tc.clamp {
+			t.Errorf("%v.AddMonths(%d) = %v, want %v", tc.d, tc.n, got, tc.clamp)
+		}
+	}
+}
+
+func TestAnniversary(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		start                     Date
+		n                         int
+		clamp, overflow, endMonth Date
+	}{
+		{Of(2000, 5, 14), 24, Of(2024, 5, 14), Of(2024, 5, 14), Of(2024, 5, 14)},
+		{Of(2000, 2, 29), 1, Of(2001, 2, 28), Of(2001, 3, 1), Of(2001, 2, 28)},
+		{Of(2000, 2, 29), 4, Of(2004, 2, 29), Of(2004, 2, 29), Of(2004, 2, 29)},
+		{Of(2023, 2, 28), 1, Of(2024, 2, 28), Of(2024, 2, 28), Of(2024, 2, 29)},
+		{Of(2024, 2, 29), -1, Of(2023, 2, 28), Of(2023, 3, 1), Of(2023, 2, 28)},
+	}
+	for _, tc := range tcs {
+		for _, c := range []struct {
+			p    MonthPolicy
+			want Date
+		}{
+			{Clamp, tc.clamp},
+			{Overflow, tc.overflow},
+			{EndOfMonth, tc.endMonth},
+		} {
+			if got := Anniversary(tc.start, tc.n, c.p); got != c.want {
+				t.Errorf("Anniversary(%v, %d, %d) = %v, want %v", tc.start, tc.n, c.p, got, c.want)
+			}
+		}
+	}
+}
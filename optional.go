@@ -0,0 +1,111 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+// Optional is a Date, which might be absent. Unlike a *Date, it does not need
+// to be allocated, and unlike the zero Date, an absent date can not be
+// confused with 0001-01-01. It is meant for fields of request and response
+// types:
+//
+//	type Patch struct {
+//		Due date.Optional `json:"due,omitzero"`
+//	}
+//
+// An absent date is represented in JSON as null and as empty text.
+type Optional struct {
+	Date Date
+
+	// Valid is whether the date is present.
+	Valid bool
+}
+
+// OptionalOf returns the Optional with the date d present.
+func OptionalOf(d Date) Optional {
+	return Optional{d, true}
+}
+
+// Get returns the date and whether it is present.
+func (o Optional) Get() (Date, bool) {
+	return o.Date, o.Valid
+}
+
+// Or returns the date, if it is present, and def otherwise.
+func (o Optional) Or(def Date) Date {
+	if o.Valid {
+		return o.Date
+	}
+	return def
+}
+
+// IsZero reports whether the date is absent. A present zero Date is not zero.
+// IsZero is used by the omitzero option of encoding/json, so absent dates are
+// omitted.
+func (o Optional) IsZero() bool {
+	return !o.Valid
+}
+
+// String returns the date formatted as ISO 8601, or "absent".
+func (o Optional) String() string {
+	if !o.Valid {
+		return "absent"
+	}
+	return o.Date.String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. A present date
+// is formatted in ISO 8601 format, an absent one as empty text.
+func (o Optional) MarshalText() ([]byte, error) {
+	if !o.Valid {
+		return []byte{}, nil
+	}
+	return o.Date.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Empty text
+// is an absent date, otherwise the date must be in ISO 8601 format.
+func (o *Optional) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*o = Optional{}
+		return nil
+	}
+	v, err := Parse(RFC3339, string(b))
+	if err == nil {
+		*o = OptionalOf(v)
+	}
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler interface. A present date is
+// represented as a string in ISO 8601 format, an absent one as null.
+func (o Optional) MarshalJSON() ([]byte, error) {
+	if !o.Valid {
+		return []byte("null"), nil
+	}
+	b := append(make([]byte, 0, len(RFC3339)+2), '"')
+	b = o.Date.AppendFormat(b, RFC3339)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Unlike the
+// convention, null is not a no-op, but makes the date absent.
+func (o *Optional) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*o = Optional{}
+		return nil
+	}
+	if string(b) == `""` {
+		// unmarshalJSON decodes the empty string as the zero Date, which
+		// is not what it means for an Optional.
+		_, err := Parse(RFC3339, "")
+		return err
+	}
+	var v Date
+	if err := unmarshalJSON(b, &v, RFC3339); err != nil {
+		return err
+	}
+	*o = OptionalOf(v)
+	return nil
+}
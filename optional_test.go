@@ -0,0 +1,107 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 14)
+	tcs := []struct {
+		o     Optional
+		valid bool
+		or    Date
+		str   string
+	}{
+		{Optional{}, false, d, "absent"},
+		{Optional{Date: d}, false, d, "absent"},
+		{OptionalOf(0), true, 0, "0001-01-01"},
+		{OptionalOf(d + 1), true, d + 1, "2024-05-15"},
+	}
+	for _, tc := range tcs {
+		if _, ok := tc.o.Get(); ok != tc.valid {
+			t.Errorf("%v.Get() = _, %v, want _, %v", tc.o, ok, tc.valid)
+		}
+		if got := tc.o.IsZero(); got != !tc.valid {
+			t.Errorf("%v.IsZero() = %v, want %v", tc.o, got, !tc.valid)
+		}
+		if got := tc.o.Or(d); got != tc.or {
+			t.Errorf("%v.Or(%v) = %v, want %v", tc.o, d, got, tc.or)
+		}
+		if got := tc.o.String(); got != tc.str {
+			t.Errorf("%v.String() = %q, want %q", tc.o, got, tc.str)
+		}
+	}
+}
+
+func TestOptionalJSON(t *testing.T) {
+	t.Parallel()
+	type S struct {
+		D Optional `json:"d"`
+		O Optional `json:"o,omitzero"`
+	}
+	marshals := []struct {
+		s    S
+		want string
+	}{
+		{S{}, `{"d":null}`},
+		{S{OptionalOf(0), OptionalOf(0)}, `{"d":"0001-01-01","o":"0001-01-01"}`},
+		{S{OptionalOf(Of(2024, 5, 14)), Optional{}}, `{"d":"2024-05-14"}`},
+	}
+	for _, tc := range marshals {
+		b, err := json.Marshal(tc.s)
+		if err != nil || string(b) != tc.want {
+			t.Errorf("json.Marshal(%v) = %s, %v, want %s, <nil>", tc.s, b, err, tc.want)
+		}
+	}
+
+	unmarshals := []struct {
+		in   string
+		want S
+		err  bool
+	}{
+		{`{"d":"2024-05-14"}`, S{OptionalOf(Of(2024, 5, 14)), OptionalOf(1)}, false},
+		{`{"d":null,"o":"0001-01-01"}`, S{Optional{}, OptionalOf(0)}, false},
+		{`{"o":null}`, S{OptionalOf(1), Optional{}}, false},
+		{`{"d":"\u0032024-05-14"}`, S{OptionalOf(Of(2024, 5, 14)), OptionalOf(1)}, false},
+		{`{"d":""}`, S{}, true},
+		{`{"d":20240514}`, S{}, true},
+		{`{"d":"May 14"}`, S{}, true},
+	}
+	for _, tc := range unmarshals {
+		s := S{OptionalOf(1), OptionalOf(1)}
+		err := json.Unmarshal([]byte(tc.in), &s)
+		if (err != nil) != tc.err {
+			t.Errorf("json.Unmarshal(%s) = %v, want error: %v", tc.in, err, tc.err)
+			continue
+		}
+		if err == nil && s != tc.want {
+			t.Errorf("json.Unmarshal(%s) = %v, want %v", tc.in, s, tc.want)
+		}
+	}
+}
+
+func TestOptionalText(t *testing.T) {
+	t.Parallel()
+	for _, o := range []Optional{{}, OptionalOf(0), OptionalOf(Of(2024, 5, 14))} {
+		b, err := o.MarshalText()
+		if err != nil {
+			t.Errorf("%v.MarshalText() = _, %v", o, err)
+			continue
+		}
+		got := OptionalOf(42)
+		if err := got.UnmarshalText(b); err != nil || got != o {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want %v, <nil>", b, got, err, o)
+		}
+	}
+	var o Optional
+	if err := o.UnmarshalText([]byte("May 14")); err == nil {
+		t.Errorf("UnmarshalText(%q) = %v, <nil>, want error", "May 14", o)
+	}
+}
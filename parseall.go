@@ -0,0 +1,86 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// minShard is the minimum number of values parsed by a single goroutine in
+// ParseAllParallel. Smaller shards cost more in synchronization than they
+// gain.
+const minShard = 1024
+
+// ParseAll parses all values using layout, as by [Parse]. The layout is
+// compiled only once, which makes ParseAll faster than calling Parse in a
+// loop.
+//
+// The returned slice has the same length as values. If a value can not be
+// parsed, its date is zero and the returned error contains the error for that
+// value, annotated with its index. Errors for multiple values are joined in
+// the order of values.
+func ParseAll(layout string, values []string) ([]Date, error) {
+	ds := make([]Date, len(values))
+	return ds, errors.Join(parseInto(ds, layout, parseLayout(layout), values, 0)...)
+}
+
+// ParseAllParallel is like [ParseAll], but splits values into contiguous
+// shards which are parsed by up to workers goroutines. If workers is not
+// positive, runtime.GOMAXPROCS(0) is used.
+//
+// The result, including the error, is the same as that of ParseAll.
+func ParseAllParallel(layout string, values []string, workers int) ([]Date, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, (len(values)+minShard-1)/minShard)
+	if workers <= 1 {
+		return ParseAll(layout, values)
+	}
+	var (
+		ds   = make([]Date, len(values))
+		prog = parseLayout(layout)
+		errs = make([][]error, workers)
+		wg   sync.WaitGroup
+	)
+	for w := range workers {
+		lo, hi := w*len(values)/workers, (w+1)*len(values)/workers
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[w] = parseInto(ds[lo:hi], layout, prog, values[lo:hi], lo)
+		}()
+	}
+	wg.Wait()
+	var all []error
+	for _, e := range errs {
+		all = append(all, e...)
+	}
+	return ds, errors.Join(all...)
+}
+
+// parseInto parses values into ds using prog, the compiled layout. It returns
+// the errors for all values which can not be parsed, with their index offset
+// by off.
+func parseInto(ds []Date, layout string, prog []inst, values []string, off int) []error {
+	var errs []error
+	for i, v := range values {
+		if d, ok := parseFast(layout, v); ok {
+			ds[i] = d
+			continue
+		}
+		d, err := parseProg(prog, layout, v, Parser{}, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("value %d: %w", off+i, err))
+			continue
+		}
+		ds[i] = d
+	}
+	return errs
+}
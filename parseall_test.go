@@ -0,0 +1,75 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseAll(t *testing.T) {
+	t.Parallel()
+	for _, layout := range []string{RFC3339, ISOBasic, "02.01.2006"} {
+		values := make([]string, 5000)
+		want := make([]Date, len(values))
+		for i := range values {
+			want[i] = Of(2024, 1, 1) + Date(i)
+			values[i] = want[i].Format(layout)
+		}
+		bad := []int{3, 1500, 1501, 4999}
+		for _, i := range bad {
+			values[i] = "invalid"
+			want[i] = 0
+		}
+		got, wantErr := ParseAll(layout, values)
+		if !slices.Equal(got, want) {
+			t.Errorf("ParseAll(%q, _) returned wrong dates", layout)
+		}
+		if n := strings.Count(wantErr.Error(), "\n") + 1; n != len(bad) {
+			t.Errorf("ParseAll(%q, _) returned %d errors, want %d", layout, n, len(bad))
+		}
+		if !strings.HasPrefix(wantErr.Error(), "value 3: ") {
+			t.Errorf("ParseAll(%q, _) = _, %q, want error for value 3 first", layout, wantErr)
+		}
+		for _, workers := range []int{-1, 0, 1, 2, 3, 7, 100} {
+			got, err := ParseAllParallel(layout, values, workers)
+			if !slices.Equal(got, want) {
+				t.Errorf("ParseAllParallel(%q, _, %d) returned wrong dates", layout, workers)
+			}
+			if err == nil || err.Error() != wantErr.Error() {
+				t.Errorf("ParseAllParallel(%q, _, %d) = _, %v, want %v", layout, workers, err, wantErr)
+			}
+		}
+	}
+	if got, err := ParseAllParallel(RFC3339, nil, 4); len(got) != 0 || err != nil {
+		t.Errorf("ParseAllParallel(RFC3339, nil, 4) = %v, %v, want [], <nil>", got, err)
+	}
+}
+
+func BenchmarkParseAll(b *testing.B) {
+	values := make([]string, 100000)
+	for i := range values {
+		values[i] = (Of(2000, 1, 1) + Date(i%10000)).Format("02.01.2006")
+	}
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				Parse("02.01.2006", v)
+			}
+		}
+	})
+	b.Run("ParseAll", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ParseAll("02.01.2006", values)
+		}
+	})
+	b.Run("ParseAllParallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ParseAllParallel("02.01.2006", values, 0)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// A PartialDate is a date, some components of which may be unknown, like a
+// birthday without a year. It corresponds to the truncated date
+// representations of ISO 8601, as used by vCard (RFC 6350).
+//
+// Valid PartialDates are a year, a year and month, a full date, a month and
+// day, a month or a day. A year and day without a month is not valid.
+type PartialDate struct {
+	// Year is the year. It is only meaningful if HasYear is true.
+	Year    int
+	HasYear bool
+	// Month is the month, or zero if it is unknown.
+	Month time.Month
+	// Day is the day of the month, or zero if it is unknown.
+	Day int
+}
+
+// Date returns the Date represented by p, if all of its components are known.
+func (p PartialDate) Date() (d Date, ok bool) {
+	if !p.HasYear || p.Month == 0 || p.Day == 0 {
+		return 0, false
+	}
+	return Of(p.Year, p.Month, p.Day), true
+}
+
+// ParseVCard parses a date in one of the forms allowed by vCard (RFC 6350,
+// section 4.3.1), in either the basic or the extended format of ISO 8601:
+//
+//	2024-05-14, 20240514 (full date)
+//	2024-05             (year and month)
+//	2024                (year)
+//	--05-14, --0514     (month and day)
+//	--05                (month)
+//	---14               (day)
+//
+// Years must be in the range 0000…9999. Components are validated, taking the
+// year into account, if it is known. February 29th is accepted without a year.
+func ParseVCard(s string) (PartialDate, error) {
+	var (
+		p   PartialDate
+		v   = s
+		err = func(kind error, msg string) (PartialDate, error) {
+			return PartialDate{}, &ParseError{Value: s, Message: msg, Err: kind}
+		}
+	)
+	switch {
+	case len(v) >= 3 && v[:3] == "---":
+		p.Day, v = digits(v[3:], 2)
+	case len(v) >= 2 && v[:2] == "--":
+		p.Month, v = monthDigits(v[2:])
+		if len(v) > 0 && v[0] == '-' {
+			v = v[1:]
+			if len(v) == 0 {
+				return err(ErrSyntax, "missing day")
+			}
+		}
+		if len(v) > 0 {
+			p.Day, v = digits(v, 2)
+		}
+	default:
+		p.Year, v = digits(v, 4)
+		p.HasYear = p.Year >= 0
+		switch {
+		case len(v) > 0 && v[0] == '-':
+			p.Month, v = monthDigits(v[1:])
+			if len(v) > 0 && v[0] == '-' {
+				p.Day, v = digits(v[1:], 2)
+			}
+		case len(v) > 0:
+			// basic format requires a full date.
+			p.Month, v = monthDigits(v)
+			p.Day, v = digits(v, 2)
+		}
+	}
+	if p.Year < 0 || p.Month < 0 || p.Day < 0 || len(v) > 0 {
+		return err(ErrSyntax, "invalid partial date syntax")
+	}
+	if p.Month > 12 {
+		return err(ErrRange, "month out of range")
+	}
+	max := 31
+	if p.Month > 0 {
+		max = daysInMonth[p.Month]
+		if p.HasYear {
+			max = daysIn(p.Month, p.Year)
+		}
+	}
+	if p.Day > max {
+		return err(ErrRange, "day out of range")
+	}
+	return p, nil
+}
+
+// digits parses exactly n decimal digits from the start of s. It returns -1
+// if s does not start with n digits.
+func digits(s string, n int) (int, string) {
+	if len(s) < n {
+		return -1, s
+	}
+	v := 0
+	for i := 0; i < n; i++ {
+		if !isDigit(s, i) {
+			return -1, s
+		}
+		v = 10*v + int(s[i]-'0')
+	}
+	return v, s[n:]
+}
+
+// monthDigits is like digits(s, 2), but rejects a zero month.
+func monthDigits(s string) (time.Month, string) {
+	m, s := digits(s, 2)
+	if m == 0 {
+		m = -1
+	}
+	return time.Month(m), s
+}
+
+// String returns p in the basic format used by vCard (RFC 6350), like
+// "20240514", "--0514", "2024-05" or "---14". A year and day without a month,
+// which is not valid, is returned like "2024---14", which [ParseVCard] rejects.
+func (p PartialDate) String() string {
+	return string(p.appendVCard(nil))
+}
+
+func (p PartialDate) appendVCard(b []byte) []byte {
+	if p.HasYear {
+		b = appendInt(b, p.Year, 4)
+	} else {
+		b = append(b, "--"...)
+	}
+	switch {
+	case p.Month == 0 && p.Day == 0:
+	case p.Month == 0:
+		if p.HasYear {
+			b = append(b, "--"...)
+		}
+		b = append(b, '-')
+		b = appendInt(b, p.Day, 2)
+	case p.Day == 0:
+		if p.HasYear {
+			b = append(b, '-')
+		}
+		b = appendInt(b, int(p.Month), 2)
+	default:
+		b = appendInt(b, int(p.Month), 2)
+		b = appendInt(b, p.Day, 2)
+	}
+	return b
+}
+
+// appendInt appends v, zero-padded to width digits.
+func appendInt(b []byte, v, width int) []byte {
+	if v < 0 {
+		b = append(b, '-')
+		v = -v
+	}
+	for n, w := v, 1; w < width; w++ {
+		if n /= 10; n == 0 {
+			b = append(b, '0')
+		}
+	}
+	return strconv.AppendInt(b, int64(v), 10)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The date is
+// formatted as by String. A year and day without a month is rejected.
+func (p PartialDate) MarshalText() ([]byte, error) {
+	if p.HasYear && p.Month == 0 && p.Day != 0 {
+		return nil, errors.New("partial date has a year and day, but no month")
+	}
+	return p.appendVCard(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The date is
+// parsed as by ParseVCard.
+func (p *PartialDate) UnmarshalText(b []byte) error {
+	v, err := ParseVCard(string(b))
+	if err == nil {
+		*p = v
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "testing"
+
+func TestParseVCard(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		value string
+		want  PartialDate
+		str   string
+		err   bool
+	}{
+		{"2024-05-14", PartialDate{2024, true, 5, 14}, "20240514", false},
+		{"20240514", PartialDate{2024, true, 5, 14}, "20240514", false},
+		{"2024-05", PartialDate{2024, true, 5, 0}, "2024-05", false},
+		{"2024", PartialDate{2024, true, 0, 0}, "2024", false},
+		{"0042", PartialDate{42, true, 0, 0}, "0042", false},
+		{"--05-14", PartialDate{0, false, 5, 14}, "--0514", false},
+		{"--0514", PartialDate{0, false, 5, 14}, "--0514", false},
+		{"--0229", PartialDate{0, false, 2, 29}, "--0229", false},
+		{"--05", PartialDate{0, false, 5, 0}, "--05", false},
+		{"---14", PartialDate{0, false, 0, 14}, "---14", false},
+		{"", PartialDate{}, "", true},
+		{"24", PartialDate{}, "", true},
+		{"202405", PartialDate{}, "", true},
+		{"2024-0514", PartialDate{}, "", true},
+		{"2024-13", PartialDate{}, "", true},
+		{"2024-00", PartialDate{}, "", true},
+		{"2023-02-29", PartialDate{}, "", true},
+		{"--0230", PartialDate{}, "", true},
+		{"--05-", PartialDate{}, "", true},
+		{"---32", PartialDate{}, "", true},
+		{"---1", PartialDate{}, "", true},
+		{"2024-05-14x", PartialDate{}, "", true},
+	}
+	for _, tc := range tcs {
+		got, err := ParseVCard(tc.value)
+		if (err != nil) != tc.err {
+			t.Errorf("ParseVCard(%q) = _, %v, want error: %v", tc.value, err, tc.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseVCard(%q) = %+v, want %+v", tc.value, got, tc.want)
+		}
+		if s := got.String(); s != tc.str {
+			t.Errorf("%+v.String() = %q, want %q", got, s, tc.str)
+		}
+		var rt PartialDate
+		if err := rt.UnmarshalText([]byte(tc.str)); err != nil || rt != got {
+			t.Errorf("UnmarshalText(%q) = %+v, %v, want %+v, <nil>", tc.str, rt, err, got)
+		}
+	}
+}
+
+func TestPartialDateYearAndDay(t *testing.T) {
+	t.Parallel()
+	p := PartialDate{Year: 2024, HasYear: true, Day: 14}
+	if got, want := p.String(), "2024---14"; got != want {
+		t.Errorf("%+v.String() = %q, want %q", p, got, want)
+	}
+	if _, err := ParseVCard(p.String()); err == nil {
+		t.Errorf("ParseVCard(%q) succeeded, want error", p.String())
+	}
+	if b, err := p.MarshalText(); err == nil {
+		t.Errorf("%+v.MarshalText() = %q, <nil>, want error", p, b)
+	}
+}
+
+func TestPartialDateDate(t *testing.T) {
+	t.Parallel()
+	if d, ok := (PartialDate{2024, true, 5, 14}).Date(); !ok || d != Of(2024, 5, 14) {
+		t.Errorf("Date() = %v, %v, want %v, true", d, ok, Of(2024, 5, 14))
+	}
+	for _, p := range []PartialDate{{0, false, 5, 14}, {2024, true, 5, 0}, {2024, true, 0, 0}} {
+		if _, ok := p.Date(); ok {
+			t.Errorf("%+v.Date() = _, true, want false", p)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// A Period is an amount of calendar time, expressed as a number of years,
+// months and days.
+//
+// Unlike a number of days, a Period keeps its calendar meaning: one month
+// after January 15th is February 15th, whereas 31 days after it is February
+// 15th only by coincidence.
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// Between returns the Period from a to b. If b is before a, the result is the
+// negation of Between(b, a).
+//
+// The Period consists of the largest number of whole months that can be added
+// to a without going past b, split into years and months, and the remaining
+// number of days. When adding months, a day of the month which does not exist
+// in the resulting month is clamped to its last day. So the Period between
+// January 31st and February 28th is one month in 2023, but 28 days in 2024.
+func Between(a, b Date) Period {
+	if b < a {
+		p := Between(b, a)
+		return p.Neg()
+	}
+	m := monthsBetween(a, b)
+	return Period{
+		Years:  m / 12,
+		Months: m % 12,
+		Days:   int(b - a.addMonthsClamped(m)),
+	}
+}
+
+// Since returns the Period from o to d. It is equivalent to Between(o, d).
+func (d Date) Since(o Date) Period {
+	return Between(o, d)
+}
+
+// Add returns d moved by p. The years and months are added first, clamping
+// the day to the last day of the resulting month, if necessary. Then the days
+// are added. So if b is not before a, a.Add(Between(a, b)) is b.
+func (d Date) Add(p Period) Date {
+	return d.addMonthsClamped(12*p.Years+p.Months) + Date(p.Days)
+}
+
+// IsZero reports whether p is the zero Period.
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// Add returns the sum of p and o, field by field. The result is not
+// normalized.
+func (p Period) Add(o Period) Period {
+	return Period{p.Years + o.Years, p.Months + o.Months, p.Days + o.Days}
+}
+
+// Sub returns the difference of p and o, field by field. The result is not
+// normalized.
+func (p Period) Sub(o Period) Period {
+	return p.Add(o.Neg())
+}
+
+// Neg returns the negation of p.
+func (p Period) Neg() Period {
+	return Period{-p.Years, -p.Months, -p.Days}
+}
+
+// Mul returns p with all fields multiplied by n.
+func (p Period) Mul(n int) Period {
+	return Period{n * p.Years, n * p.Months, n * p.Days}
+}
+
+// Normalize returns p with whole years moved from the months to the years, so
+// the months are in the range -11…11 and have the same sign as the years. The
+// days are unchanged, as the number of days in a month depends on the date
+// the Period is added to.
+func (p Period) Normalize() Period {
+	m := 12*p.Years + p.Months
+	return Period{m / 12, m % 12, p.Days}
+}
+
+// String returns p in ISO 8601 duration format, like "P1Y2M3D". Zero fields
+// are omitted, except for the zero Period, which is "P0D". If no field is
+// positive, the negated Period is prefixed with a minus sign, like "-P1M".
+// Otherwise, negative fields are written with a sign, like "P1Y-2M".
+func (p Period) String() string {
+	return string(p.appendISO(nil))
+}
+
+func (p Period) appendISO(b []byte) []byte {
+	if p.IsZero() {
+		return append(b, "P0D"...)
+	}
+	if p.Years <= 0 && p.Months <= 0 && p.Days <= 0 {
+		b = append(b, '-')
+		p = p.Neg()
+	}
+	b = append(b, 'P')
+	for _, f := range [...]struct {
+		n    int
+		unit byte
+	}{{p.Years, 'Y'}, {p.Months, 'M'}, {p.Days, 'D'}} {
+		if f.n != 0 {
+			b = append(strconv.AppendInt(b, int64(f.n), 10), f.unit)
+		}
+	}
+	return b
+}
+
+// ParsePeriod parses an ISO 8601 duration of years, months, weeks and days,
+// like "P1Y2M3D" or "P2W". Weeks are converted to days. A leading sign negates
+// the Period and the numbers may have signs as well, as formatted by
+// [Period.String]. Durations with a time part, like "PT1H", are rejected.
+func ParsePeriod(s string) (Period, error) {
+	fail := func(kind error, msg string) (Period, error) {
+		return Period{}, &ParseError{Value: s, Message: msg, Err: kind}
+	}
+	v := s
+	neg := false
+	if len(v) > 0 && (v[0] == '-' || v[0] == '+') {
+		neg, v = v[0] == '-', v[1:]
+	}
+	v, ok := strings.CutPrefix(v, "P")
+	if !ok || v == "" {
+		return fail(ErrSyntax, "period must start with P")
+	}
+	var p Period
+	const units = "YMWD"
+	next := 0 // index of the next allowed unit
+	for v != "" {
+		i := 0
+		if v[0] == '-' || v[0] == '+' {
+			i++
+		}
+		for i < len(v) && '0' <= v[i] && v[i] <= '9' {
+			i++
+		}
+		if i == len(v) {
+			return fail(ErrSyntax, "missing unit")
+		}
+		u := strings.IndexByte(units[next:], v[i])
+		if u < 0 {
+			if v[i] == 'T' {
+				return fail(ErrSyntax, "period must not have a time part")
+			}
+			return fail(ErrSyntax, "invalid unit "+strconv.Quote(v[i:i+1]))
+		}
+		n, err := strconv.Atoi(v[:i])
+		if err != nil {
+			kind := ErrSyntax
+			if errors.Is(err, strconv.ErrRange) {
+				kind = ErrRange
+			}
+			return fail(kind, "invalid number "+strconv.Quote(v[:i]))
+		}
+		switch units[next+u] {
+		case 'Y':
+			p.Years = n
+		case 'M':
+			p.Months = n
+		case 'W':
+			p.Days += 7 * n
+		case 'D':
+			p.Days += n
+		}
+		next += u + 1
+		v = v[i+1:]
+	}
+	if neg {
+		p = p.Neg()
+	}
+	return p, nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The Period is
+// formatted as by String.
+func (p Period) MarshalText() ([]byte, error) {
+	return p.appendISO(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The Period
+// is parsed as by ParsePeriod.
+func (p *Period) UnmarshalText(b []byte) error {
+	v, err := ParsePeriod(string(b))
+	if err == nil {
+		*p = v
+	}
+	return err
+}
+
+// monthsBetween returns the number of whole months from a to b, which must not
+// be before a.
+func monthsBetween(a, b Date) int {
+	ay, am, _ := a.Date()
+	by, bm, _ := b.Date()
+	m := 12*(by-ay) + int(bm-am)
+	if a.addMonthsClamped(m) > b {
+		m--
+	}
+	return m
+}
+
+// MonthsBetween returns the number of whole months from a to b. Months are
+// counted as in [Between], so one month after January 31st is the last day of
+// February. If b is before a, the result is negative.
+func MonthsBetween(a, b Date) int {
+	if b < a {
+		return -MonthsBetween(b, a)
+	}
+	return monthsBetween(a, b)
+}
+
+// YearsBetween returns the number of whole years from a to b, like the age on
+// b of somebody born on a. Years are counted as in [Between], so somebody born
+// on February 29th has their birthday on February 28th in common years. Use
+// [Anniversary] for other conventions. If b is before a, the result is
+// negative.
+func YearsBetween(a, b Date) int {
+	return MonthsBetween(a, b) / 12
+}
+
+// AddWeeks returns the date n weeks after d, or before d if n is negative.
+func (d Date) AddWeeks(n int) Date {
+	return d + Date(7*n)
+}
+
+// WeeksBetween returns the number of whole weeks from a to b. It is the number
+// of days from a to b divided by seven, rounded down. So if b is before a, the
+// result is negative and WeeksBetween(a, a-1) is -1.
+func WeeksBetween(a, b Date) int {
+	n := int(b - a)
+	if n < 0 {
+		return -((-n + 6) / 7)
+	}
+	return n / 7
+}
+
+// A Unit is a unit of calendar time.
+type Unit int
+
+// Units of calendar time.
+const (
+	Days Unit = iota
+	Weeks
+	Months
+	Quarters
+	Years
+)
+
+var unitNames = [...][2]string{
+	Days:     {"day", "days"},
+	Weeks:    {"week", "weeks"},
+	Months:   {"month", "months"},
+	Quarters: {"quarter", "quarters"},
+	Years:    {"year", "years"},
+}
+
+// String implements fmt.Stringer. It returns the plural name of u.
+func (u Unit) String() string {
+	if !u.valid() {
+		return "Unit(" + strconv.Itoa(int(u)) + ")"
+	}
+	return unitNames[u][1]
+}
+
+// valid reports whether u is one of the defined units.
+func (u Unit) valid() bool {
+	return 0 <= u && int(u) < len(unitNames)
+}
+
+// months returns the number of months in u, or 0 if u is shorter than a month.
+func (u Unit) months() int {
+	switch u {
+	case Months:
+		return 1
+	case Quarters:
+		return 3
+	case Years:
+		return 12
+	}
+	return 0
+}
+
+// days returns the number of days in u, or 0 if u is longer than a week.
+func (u Unit) days() int {
+	switch u {
+	case Days:
+		return 1
+	case Weeks:
+		return 7
+	}
+	return 0
+}
+
+// add returns d moved by n of the unit. Month-based units clamp the day of the
+// month, if necessary.
+func (u Unit) add(d Date, n int) Date {
+	if m := u.months(); m > 0 {
+		return d.addMonthsClamped(m * n)
+	}
+	return d + Date(n*u.days())
+}
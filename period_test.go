@@ -0,0 +1,215 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "testing"
+
+func TestBetween(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		a, b Date
+		want Period
+	}{
+		{Of(2024, 5, 14), Of(2024, 5, 14), Period{}},
+		{Of(2024, 5, 14), Of(2024, 5, 15), Period{0, 0, 1}},
+		{Of(2024, 5, 14), Of(2024, 7, 19), Period{0, 2, 5}},
+		{Of(2024, 5, 14), Of(2025, 5, 13), Period{0, 11, 29}},
+		{Of(2024, 5, 14), Of(2026, 6, 14), Period{2, 1, 0}},
+		{Of(2024, 7, 19), Of(2024, 5, 14), Period{0, -2, -5}},
+		{Of(2023, 1, 31), Of(2023, 2, 28), Period{0, 1, 0}},
+		{Of(2024, 1, 31), Of(2024, 2, 28), Period{0, 0, 28}},
+		{Of(2024, 1, 31), Of(2024, 2, 29), Period{0, 1, 0}},
+		{Of(2024, 1, 31), Of(2024, 3, 1), Period{0, 1, 1}},
+		{Of(2024, 2, 29), Of(2025, 2, 28), Period{1, 0, 0}},
+		{Of(-1, 12, 31), Of(1, 1, 1), Period{1, 0, 1}},
+	}
+	for _, tc := range tcs {
+		if got := Between(tc.a, tc.b); got != tc.want {
+			t.Errorf("Between(%v, %v) = %+v, want %+v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func FuzzBetween(f *testing.F) {
+	f.Add(int(Of(2024, 1, 31)), int(Of(2024, 3, 1)))
+	f.Add(int(Of(2024, 5, 14)), int(Of(2023, 2, 28)))
+	f.Fuzz(func(t *testing.T, a, b int) {
+		if a < 0 || b < 0 || a > 1e7 || b > 1e7 {
+			return
+		}
+		p := Between(Date(a), Date(b))
+		if p.Years*p.Months < 0 || p.Years*p.Days < 0 || p.Months*p.Days < 0 {
+			t.Fatalf("Between(%v, %v) = %+v, want all fields with the same sign", Date(a), Date(b), p)
+		}
+		from, to := Date(a), Date(b)
+		if p.Years < 0 || p.Months < 0 || p.Days < 0 {
+			from, to, p = to, from, Period{-p.Years, -p.Months, -p.Days}
+		}
+		if got := from.addMonthsClamped(12*p.Years+p.Months) + Date(p.Days); got != to {
+			t.Fatalf("Between(%v, %v) = %+v, which adds up to %v", Date(a), Date(b), p, got)
+		}
+	})
+}
+
+func TestWeeksBetween(t *testing.T) {
+	t.Parallel()
+	a := Of(2024, 5, 14)
+	tcs := []struct {
+		b    Date
+		want int
+	}{
+		{a, 0},
+		{a + 6, 0},
+		{a + 7, 1},
+		{a + 20, 2},
+		{a - 1, -1},
+		{a - 7, -1},
+		{a - 8, -2},
+	}
+	for _, tc := range tcs {
+		if got := WeeksBetween(a, tc.b); got != tc.want {
+			t.Errorf("WeeksBetween(%v, %v) = %d, want %d", a, tc.b, got, tc.want)
+		}
+		if got := a.AddWeeks(tc.want); got > tc.b || got.AddWeeks(1) <= tc.b {
+			t.Errorf("%v.AddWeeks(%d) = %v, want the last week start not after %v", a, tc.want, got, tc.b)
+		}
+	}
+}
+
+func TestMonthsBetween(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		a, b          Date
+		months, years int
+	}{
+		{Of(2024, 5, 14), Of(2024, 5, 14), 0, 0},
+		{Of(2024, 5, 14), Of(2024, 6, 13), 0, 0},
+		{Of(2024, 5, 14), Of(2024, 6, 14), 1, 0},
+		{Of(2024, 1, 31), Of(2024, 2, 29), 1, 0},
+		{Of(2023, 1, 31), Of(2023, 2, 28), 1, 0},
+		{Of(2000, 2, 29), Of(2001, 2, 28), 12, 1},
+		{Of(2000, 2, 29), Of(2024, 2, 28), 287, 23},
+		{Of(1990, 7, 1), Of(2024, 6, 30), 407, 33},
+		{Of(1990, 7, 1), Of(2024, 7, 1), 408, 34},
+		{Of(2024, 6, 14), Of(2024, 5, 14), -1, 0},
+		{Of(2025, 5, 14), Of(2024, 5, 15), -11, 0},
+		{Of(2025, 5, 14), Of(2024, 5, 14), -12, -1},
+	}
+	for _, tc := range tcs {
+		if got := MonthsBetween(tc.a, tc.b); got != tc.months {
+			t.Errorf("MonthsBetween(%v, %v) = %d, want %d", tc.a, tc.b, got, tc.months)
+		}
+		if got := YearsBetween(tc.a, tc.b); got != tc.years {
+			t.Errorf("YearsBetween(%v, %v) = %d, want %d", tc.a, tc.b, got, tc.years)
+		}
+	}
+}
+
+func TestPeriodAdd(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d    Date
+		p    Period
+		want Date
+	}{
+		{Of(2024, 5, 14), Period{}, Of(2024, 5, 14)},
+		{Of(2024, 5, 14), Period{1, 2, 3}, Of(2025, 7, 17)},
+		{Of(2024, 1, 31), Period{0, 1, 0}, Of(2024, 2, 29)},
+		{Of(2024, 1, 31), Period{0, 1, 1}, Of(2024, 3, 1)},
+		{Of(2024, 2, 29), Period{1, 0, 0}, Of(2025, 2, 28)},
+		{Of(2024, 5, 14), Period{0, -14, -14}, Of(2023, 2, 28)},
+		{Of(2024, 5, 14), Period{0, 0, 365}, Of(2025, 5, 14)},
+	}
+	for _, tc := range tcs {
+		if got := tc.d.Add(tc.p); got != tc.want {
+			t.Errorf("%v.Add(%v) = %v, want %v", tc.d, tc.p, got, tc.want)
+		}
+	}
+	a, b := Of(2024, 1, 31), Of(2025, 3, 1)
+	if got := b.Since(a); got != Between(a, b) {
+		t.Errorf("%v.Since(%v) = %v, want %v", b, a, got, Between(a, b))
+	}
+	if got := a.Add(b.Since(a)); got != b {
+		t.Errorf("%v.Add(%v) = %v, want %v", a, b.Since(a), got, b)
+	}
+}
+
+func TestPeriodArithmetic(t *testing.T) {
+	t.Parallel()
+	p, o := Period{1, 2, 3}, Period{0, 11, 30}
+	if got, want := p.Add(o), (Period{1, 13, 33}); got != want {
+		t.Errorf("%v.Add(%v) = %v, want %v", p, o, got, want)
+	}
+	if got, want := p.Sub(o), (Period{1, -9, -27}); got != want {
+		t.Errorf("%v.Sub(%v) = %v, want %v", p, o, got, want)
+	}
+	if got, want := p.Mul(-2), (Period{-2, -4, -6}); got != want {
+		t.Errorf("%v.Mul(-2) = %v, want %v", p, got, want)
+	}
+	if !(Period{}).IsZero() || p.IsZero() {
+		t.Errorf("IsZero is wrong")
+	}
+
+	norms := []struct {
+		p, want Period
+	}{
+		{Period{1, 13, 33}, Period{2, 1, 33}},
+		{Period{1, -9, -27}, Period{0, 3, -27}},
+		{Period{0, -25, 0}, Period{-2, -1, 0}},
+		{Period{-1, 14, 0}, Period{0, 2, 0}},
+		{Period{0, 12, 0}, Period{1, 0, 0}},
+	}
+	for _, tc := range norms {
+		if got := tc.p.Normalize(); got != tc.want {
+			t.Errorf("%v.Normalize() = %v, want %v", tc.p, got, tc.want)
+		}
+	}
+}
+
+func TestPeriodString(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		p Period
+		s string
+	}{
+		{Period{}, "P0D"},
+		{Period{1, 2, 3}, "P1Y2M3D"},
+		{Period{0, 1, 0}, "P1M"},
+		{Period{0, 0, 14}, "P14D"},
+		{Period{-1, 0, -3}, "-P1Y3D"},
+		{Period{1, -2, 0}, "P1Y-2M"},
+	}
+	for _, tc := range tcs {
+		if got := tc.p.String(); got != tc.s {
+			t.Errorf("%#v.String() = %q, want %q", tc.p, got, tc.s)
+		}
+		var got Period
+		if err := got.UnmarshalText([]byte(tc.s)); err != nil || got != tc.p {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want %v, <nil>", tc.s, got, err, tc.p)
+		}
+	}
+
+	parses := []struct {
+		s    string
+		want Period
+	}{
+		{"P2W", Period{0, 0, 14}},
+		{"P1Y1W1D", Period{1, 0, 8}},
+		{"+P1M", Period{0, 1, 0}},
+		{"-P1Y-2M", Period{-1, 2, 0}},
+		{"P0Y", Period{}},
+	}
+	for _, tc := range parses {
+		if got, err := ParsePeriod(tc.s); err != nil || got != tc.want {
+			t.Errorf("ParsePeriod(%q) = %v, %v, want %v, <nil>", tc.s, got, err, tc.want)
+		}
+	}
+	for _, s := range []string{"", "P", "1Y", "P1", "PY", "P1D1M", "P1Y1Y", "PT1H", "P1DT1H", "P1.5Y", "P1X", "--P1D"} {
+		if got, err := ParsePeriod(s); err == nil {
+			t.Errorf("ParsePeriod(%q) = %v, <nil>, want error", s, got)
+		}
+	}
+}
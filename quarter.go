@@ -0,0 +1,208 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+)
+
+// OfQuarter returns the first day of quarter q of the given year. Quarter 1
+// starts on January 1st, quarter 4 on October 1st.
+//
+// Like [Of], OfQuarter normalizes q, so quarter 5 of 2024 is the first quarter
+// of 2025 and quarter 0 is the last quarter of the previous year.
+func OfQuarter(year, q int) Date {
+	return Of(year, time.Month(3*(q-1)+1), 1)
+}
+
+// Quarter returns the quarter of the year in which d occurs, from 1 to 4.
+func (d Date) Quarter() int {
+	return (int(d.Month()) + 2) / 3
+}
+
+// Half returns the half of the year in which d occurs, 1 for January to June
+// and 2 for July to December.
+func (d Date) Half() int {
+	return (int(d.Month()) + 5) / 6
+}
+
+// HalfRange returns the Range containing all dates of half h of the given
+// year. Like quarters, h is normalized, so half 3 of 2024 is the first half of
+// 2025.
+func HalfRange(year, h int) Range {
+	return Range{Of(year, time.Month(6*(h-1)+1), 1), Of(year, time.Month(6*h+1), 1)}
+}
+
+// QuarterRange returns the Range containing all dates of quarter q of the given
+// year. q is normalized as by [OfQuarter].
+func QuarterRange(year, q int) Range {
+	return Range{OfQuarter(year, q), OfQuarter(year, q+1)}
+}
+
+// AddQuarters returns the date n quarters after d, or before d if n is
+// negative. If the resulting month has fewer days than the day of the month
+// of d, the last day of that month is used instead. So one quarter after
+// November 30th is the last day of February. Use a [MonthPolicy] for other
+// behavior.
+func (d Date) AddQuarters(n int) Date {
+	return Clamp.AddQuarters(d, n)
+}
+
+// QuartersBetween returns the number of whole quarters from a to b. Quarters
+// are counted as in [Between], so the result is the number of whole months
+// divided by three. If b is before a, the result is negative.
+func QuartersBetween(a, b Date) int {
+	if b < a {
+		return -QuartersBetween(b, a)
+	}
+	return monthsBetween(a, b) / 3
+}
+
+// A YearQuarter is a quarter of a specific year, like the third quarter of
+// 2024. It is the quarterly counterpart of [YearMonth].
+//
+// A YearQuarter is represented as the number of quarters since the first
+// quarter of the year 1, so the zero value is 0001-Q1. YearQuarter values can
+// be compared using Go's arithmetic operators, adding n to a YearQuarter
+// moves it n quarters forward and subtracting two YearQuarter values returns
+// the number of quarters between them.
+type YearQuarter int
+
+// OfYearQuarter returns the YearQuarter of quarter q of the given year. q is
+// normalized as by [OfQuarter].
+func OfYearQuarter(year, q int) YearQuarter {
+	return YearQuarter((year-1)*4 + q - 1)
+}
+
+// YearQuarter returns the quarter of the year in which d occurs.
+func (d Date) YearQuarter() YearQuarter {
+	year, month, _ := d.Date()
+	return OfYearQuarter(year, (int(month)+2)/3)
+}
+
+// Date returns the year and quarter of yq. The quarter is in the range 1…4.
+func (yq YearQuarter) Date() (year, q int) {
+	y, n := norm(0, int(yq), 4)
+	return y + 1, n + 1
+}
+
+// Year returns the year of yq.
+func (yq YearQuarter) Year() int {
+	year, _ := yq.Date()
+	return year
+}
+
+// Quarter returns the quarter of the year of yq, from 1 to 4.
+func (yq YearQuarter) Quarter() int {
+	_, q := yq.Date()
+	return q
+}
+
+// First returns the first day of yq.
+func (yq YearQuarter) First() Date {
+	return OfQuarter(yq.Date())
+}
+
+// Last returns the last day of yq.
+func (yq YearQuarter) Last() Date {
+	return (yq + 1).First() - 1
+}
+
+// Days returns the number of days in yq.
+func (yq YearQuarter) Days() int {
+	return int((yq + 1).First() - yq.First())
+}
+
+// Range returns the Range containing all dates of yq.
+func (yq YearQuarter) Range() Range {
+	return Range{yq.First(), (yq + 1).First()}
+}
+
+// Contains reports whether d is in yq.
+func (yq YearQuarter) Contains(d Date) bool {
+	return d.YearQuarter() == yq
+}
+
+// AddYears returns the YearQuarter n years after yq, or before yq if n is
+// negative.
+func (yq YearQuarter) AddYears(n int) YearQuarter {
+	return yq + YearQuarter(4*n)
+}
+
+// String returns yq in the form "2024-Q3".
+func (yq YearQuarter) String() string {
+	return string(yq.AppendFormat(nil))
+}
+
+// AppendFormat appends yq in the form "2024-Q3" to b and returns the extended
+// buffer.
+func (yq YearQuarter) AppendFormat(b []byte) []byte {
+	b = yq.First().AppendFormat(b, "2006")
+	return append(b, '-', 'Q', byte('0'+yq.Quarter()))
+}
+
+// ParseYearQuarter parses a quarter in the form "2024-Q3" or "Q3 2024". The
+// year must be in the range 0000…9999.
+func ParseYearQuarter(s string) (YearQuarter, error) {
+	var year, q string
+	if strings.HasPrefix(s, "Q") && len(s) > 3 && s[2] == ' ' {
+		q, year = s[1:2], s[3:]
+	} else if y, rest, ok := strings.Cut(s, "-Q"); ok && len(rest) == 1 {
+		year, q = y, rest
+	} else {
+		return 0, &ParseError{Value: s, Message: "invalid quarter syntax", Err: ErrSyntax}
+	}
+	if q < "1" || q > "4" {
+		return 0, &ParseError{Value: s, Message: "quarter out of range", Err: ErrRange}
+	}
+	d, err := Parse("2006", year)
+	if err != nil {
+		return 0, &ParseError{Value: s, Message: "invalid year", Err: ErrSyntax}
+	}
+	return OfYearQuarter(d.Year(), int(q[0]-'0')), nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The
+// quarter is represented as a [binary.Varint] representing the number of
+// quarters since 0001-Q1.
+func (yq YearQuarter) MarshalBinary() ([]byte, error) {
+	b := make([]byte, binary.MaxVarintLen64)
+	return b[:binary.PutVarint(b, int64(yq))], nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (yq *YearQuarter) UnmarshalBinary(b []byte) error {
+	v, i := binary.Varint(b)
+	switch {
+	case i == 0:
+		return errors.New("encoded quarter truncated")
+	case i < 0 || int64(int(v)) != v:
+		return errors.New("encoded quarter overflows int")
+	case i != len(b):
+		return errors.New("extra data after quarter")
+	}
+	*yq = YearQuarter(v)
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The quarter is
+// formatted like "2024-Q3".
+func (yq YearQuarter) MarshalText() ([]byte, error) {
+	return yq.AppendFormat(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The quarter
+// must be in one of the forms accepted by [ParseYearQuarter].
+func (yq *YearQuarter) UnmarshalText(b []byte) error {
+	v, err := ParseYearQuarter(string(b))
+	if err == nil {
+		*yq = v
+	}
+	return err
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "testing"
+
+func TestOfQuarter(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		year, q int
+		want    Range
+	}{
+		{2024, 1, Range{Of(2024, 1, 1), Of(2024, 4, 1)}},
+		{2024, 2, Range{Of(2024, 4, 1), Of(2024, 7, 1)}},
+		{2024, 3, Range{Of(2024, 7, 1), Of(2024, 10, 1)}},
+		{2024, 4, Range{Of(2024, 10, 1), Of(2025, 1, 1)}},
+		{2024, 5, Range{Of(2025, 1, 1), Of(2025, 4, 1)}},
+		{2024, 0, Range{Of(2023, 10, 1), Of(2024, 1, 1)}},
+		{2024, -3, Range{Of(2023, 1, 1), Of(2023, 4, 1)}},
+	}
+	for _, tc := range tcs {
+		if got := OfQuarter(tc.year, tc.q); got != tc.want.Start {
+			t.Errorf("OfQuarter(%d, %d) = %v, want %v", tc.year, tc.q, got, tc.want.Start)
+		}
+		if got := QuarterRange(tc.year, tc.q); got != tc.want {
+			t.Errorf("QuarterRange(%d, %d) = %v, want %v", tc.year, tc.q, got, tc.want)
+		}
+	}
+}
+
+func TestAddQuarters(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d    Date
+		n    int
+		want Date
+	}{
+		{Of(2024, 5, 14), 0, Of(2024, 5, 14)},
+		{Of(2024, 5, 14), 1, Of(2024, 8, 14)},
+		{Of(2024, 5, 14), -2, Of(2023, 11, 14)},
+		{Of(2024, 11, 30), 1, Of(2025, 2, 28)},
+		{Of(2023, 11, 30), 1, Of(2024, 2, 29)},
+		{Of(2024, 5, 31), 1, Of(2024, 8, 31)},
+		{Of(2024, 3, 31), 1, Of(2024, 6, 30)},
+		{Of(2024, 3, 31), 8, Of(2026, 3, 31)},
+	}
+	for _, tc := range tcs {
+		if got := tc.d.AddQuarters(tc.n); got != tc.want {
+			t.Errorf("%v.AddQuarters(%d) = %v, want %v", tc.d, tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestQuartersBetween(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		a, b Date
+		want int
+	}{
+		{Of(2024, 1, 1), Of(2024, 1, 1), 0},
+		{Of(2024, 1, 1), Of(2024, 3, 31), 0},
+		{Of(2024, 1, 1), Of(2024, 4, 1), 1},
+		{Of(2024, 1, 15), Of(2024, 4, 14), 0},
+		{Of(2024, 1, 15), Of(2025, 1, 15), 4},
+		{Of(2023, 11, 30), Of(2024, 2, 29), 1},
+		{Of(2024, 4, 1), Of(2024, 1, 1), -1},
+		{Of(2025, 1, 15), Of(2024, 1, 16), -3},
+	}
+	for _, tc := range tcs {
+		if got := QuartersBetween(tc.a, tc.b); got != tc.want {
+			t.Errorf("QuartersBetween(%v, %v) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestQuarterHalf(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d       Date
+		q, half int
+	}{
+		{Of(2024, 1, 1), 1, 1},
+		{Of(2024, 3, 31), 1, 1},
+		{Of(2024, 4, 1), 2, 1},
+		{Of(2024, 6, 30), 2, 1},
+		{Of(2024, 7, 1), 3, 2},
+		{Of(2024, 12, 31), 4, 2},
+	}
+	for _, tc := range tcs {
+		if got := tc.d.Quarter(); got != tc.q {
+			t.Errorf("%v.Quarter() = %d, want %d", tc.d, got, tc.q)
+		}
+		if got := tc.d.Half(); got != tc.half {
+			t.Errorf("%v.Half() = %d, want %d", tc.d, got, tc.half)
+		}
+		if r := HalfRange(tc.d.Year(), tc.half); !r.Contains(tc.d) || r.Days() < 181 || r.Days() > 184 {
+			t.Errorf("HalfRange(%d, %d) = %v, want range of half a year containing %v", tc.d.Year(), tc.half, r, tc.d)
+		}
+	}
+	if got, want := HalfRange(2024, 3), (Range{Of(2025, 1, 1), Of(2025, 7, 1)}); got != want {
+		t.Errorf("HalfRange(2024, 3) = %v, want %v", got, want)
+	}
+}
+
+func TestYearQuarter(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		year, q int
+		first   Date
+		last    Date
+		days    int
+		str     string
+	}{
+		{1, 1, 0, Of(1, 3, 31), 90, "0001-Q1"},
+		{2024, 1, Of(2024, 1, 1), Of(2024, 3, 31), 91, "2024-Q1"},
+		{2024, 3, Of(2024, 7, 1), Of(2024, 9, 30), 92, "2024-Q3"},
+		{2024, 4, Of(2024, 10, 1), Of(2024, 12, 31), 92, "2024-Q4"},
+		{0, 2, Of(0, 4, 1), Of(0, 6, 30), 91, "0000-Q2"},
+	}
+	for _, tc := range tcs {
+		yq := OfYearQuarter(tc.year, tc.q)
+		if year, q := yq.Date(); year != tc.year || q != tc.q {
+			t.Errorf("OfYearQuarter(%d, %d).Date() = %d, %d, want %d, %d", tc.year, tc.q, year, q, tc.year, tc.q)
+		}
+		if got := yq.First(); got != tc.first {
+			t.Errorf("%v.First() = %v, want %v", yq, got, tc.first)
+		}
+		if got := yq.Last(); got != tc.last {
+			t.Errorf("%v.Last() = %v, want %v", yq, got, tc.last)
+		}
+		if got := yq.Days(); got != tc.days {
+			t.Errorf("%v.Days() = %d, want %d", yq, got, tc.days)
+		}
+		if got := yq.String(); got != tc.str {
+			t.Errorf("%v.String() = %q, want %q", yq, got, tc.str)
+		}
+		if !yq.Contains(tc.first) || !yq.Contains(tc.last) || yq.Contains(tc.last+1) || yq.Contains(tc.first-1) {
+			t.Errorf("%v.Contains does not match [%v, %v]", yq, tc.first, tc.last)
+		}
+	}
+
+	yq := OfYearQuarter(2024, 3)
+	if got, want := yq+2, OfYearQuarter(2025, 1); got != want {
+		t.Errorf("%v+2 = %v, want %v", yq, got, want)
+	}
+	if got, want := yq.AddYears(-1), OfYearQuarter(2023, 3); got != want {
+		t.Errorf("%v.AddYears(-1) = %v, want %v", yq, got, want)
+	}
+	if got, want := OfYearQuarter(2024, 0), OfYearQuarter(2023, 4); got != want {
+		t.Errorf("OfYearQuarter(2024, 0) = %v, want %v", got, want)
+	}
+}
+
+func TestParseYearQuarter(t *testing.T) {
+	t.Parallel()
+	for _, s := range []string{"2024-Q3", "Q3 2024"} {
+		if got, err := ParseYearQuarter(s); err != nil || got != OfYearQuarter(2024, 3) {
+			t.Errorf("ParseYearQuarter(%q) = %v, %v, want 2024-Q3, <nil>", s, got, err)
+		}
+	}
+	for _, s := range []string{"", "2024", "2024-Q5", "2024-Q0", "Q3-2024", "24-Q3", "2024-Q12", "Q3 24", "2024-q3"} {
+		if got, err := ParseYearQuarter(s); err == nil {
+			t.Errorf("ParseYearQuarter(%q) = %v, <nil>, want error", s, got)
+		}
+	}
+
+	var got YearQuarter
+	if err := got.UnmarshalText([]byte("2024-Q3")); err != nil || got != OfYearQuarter(2024, 3) {
+		t.Errorf("UnmarshalText(%q) = %v, %v, want 2024-Q3, <nil>", "2024-Q3", got, err)
+	}
+	for _, yq := range []YearQuarter{0, -1, OfYearQuarter(2024, 3)} {
+		b, _ := yq.MarshalBinary()
+		var got YearQuarter
+		if err := got.UnmarshalBinary(b); err != nil || got != yq {
+			t.Errorf("UnmarshalBinary(MarshalBinary(%d)) = %d, %v, want %d, <nil>", yq, got, err, yq)
+		}
+	}
+}
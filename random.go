@@ -0,0 +1,48 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"math/rand/v2"
+)
+
+// RandomIn returns a date chosen uniformly at random from rng, using r as the
+// source of randomness. As rng is half-open, rng.End is never returned. If r
+// is nil, the global source of math/rand/v2 is used.
+//
+// RandomIn panics, if rng is empty.
+func RandomIn(r *rand.Rand, rng Range) Date {
+	n := rng.count()
+	if r == nil {
+		return rng.Start + Date(rand.Uint64N(n))
+	}
+	return rng.Start + Date(r.Uint64N(n))
+}
+
+// CryptoRandomIn is like [RandomIn], but uses the cryptographically secure
+// random number generator of crypto/rand. It is meant for things like
+// anonymizing data, where the dates must not be predictable.
+//
+// CryptoRandomIn panics, if rng is empty.
+func CryptoRandomIn(rng Range) (Date, error) {
+	n := rng.count()
+	x, err := crand.Int(crand.Reader, new(big.Int).SetUint64(n))
+	if err != nil {
+		return 0, err
+	}
+	return rng.Start + Date(x.Uint64()), nil
+}
+
+// count returns the number of dates in r, which does not overflow for large
+// ranges, unlike Days. It panics, if r is empty.
+func (r Range) count() uint64 {
+	if r.IsEmpty() {
+		panic("date: random date in empty range " + r.String())
+	}
+	return uint64(r.End) - uint64(r.Start)
+}
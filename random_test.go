@@ -0,0 +1,61 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestRandomIn(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewPCG(1, 2))
+	ranges := []Range{
+		ClosedRange(Of(2024, 5, 22), Of(2024, 5, 22)),
+		MonthRange(2024, time.February),
+		YearRange(2024),
+		{math.MinInt, math.MaxInt},
+	}
+	for _, rng := range ranges {
+		for range 1000 {
+			if d := RandomIn(r, rng); !rng.Contains(d) {
+				t.Fatalf("RandomIn(_, %v) = %v, not in range", rng, d)
+			}
+			if d := RandomIn(nil, rng); !rng.Contains(d) {
+				t.Fatalf("RandomIn(nil, %v) = %v, not in range", rng, d)
+			}
+			d, err := CryptoRandomIn(rng)
+			if err != nil || !rng.Contains(d) {
+				t.Fatalf("CryptoRandomIn(%v) = %v, %v, want date in range", rng, d, err)
+			}
+		}
+	}
+
+	// Every day of a week should be chosen about equally often.
+	week := Range{Of(2024, 5, 20), Of(2024, 5, 27)}
+	var counts [7]int
+	const n = 70000
+	for range n {
+		counts[RandomIn(r, week)-week.Start]++
+	}
+	for i, c := range counts {
+		if c < n/7*9/10 || c > n/7*11/10 {
+			t.Errorf("RandomIn(_, %v) returned %v %d times out of %d", week, week.Start+Date(i), c, n)
+		}
+	}
+}
+
+func TestRandomInEmpty(t *testing.T) {
+	t.Parallel()
+	defer func() {
+		if recover() == nil {
+			t.Error("RandomIn with empty range did not panic")
+		}
+	}()
+	RandomIn(nil, Range{Of(2024, 5, 22), Of(2024, 5, 22)})
+}
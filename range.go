@@ -0,0 +1,375 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/json"
+	"errors"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+)
+
+// A Range is a half-open interval of dates, containing all dates from Start
+// up to, but not including, End. A Range with End <= Start is empty.
+//
+// Using half-open intervals means that adjacent ranges share their boundary
+// and that the number of days in a Range is End-Start. To construct a Range
+// from its first and last day, use [ClosedRange].
+type Range struct {
+	Start Date
+	End   Date
+}
+
+// ClosedRange returns the Range containing all dates from first to last,
+// inclusive.
+func ClosedRange(first, last Date) Range {
+	return Range{first, last + 1}
+}
+
+// MonthRange returns the Range containing all dates of month m in the given
+// year. Like [Of], MonthRange normalizes m, so month 13 of 2024 is January
+// 2025.
+func MonthRange(year int, m time.Month) Range {
+	return Range{Of(year, m, 1), Of(year, m+1, 1)}
+}
+
+// YearRange returns the Range containing all dates of the given year.
+func YearRange(year int) Range {
+	return Range{Of(year, time.January, 1), Of(year+1, time.January, 1)}
+}
+
+// IsEmpty reports whether r contains no dates.
+func (r Range) IsEmpty() bool {
+	return r.End <= r.Start
+}
+
+// Days returns the number of dates in r.
+func (r Range) Days() int {
+	if r.IsEmpty() {
+		return 0
+	}
+	return int(r.End - r.Start)
+}
+
+// Last returns the last date contained in r. If r is empty, the result is
+// before r.Start.
+func (r Range) Last() Date {
+	return r.End - 1
+}
+
+// Contains reports whether d is in r.
+func (r Range) Contains(d Date) bool {
+	return r.Start <= d && d < r.End
+}
+
+// Intersect returns the dates contained in both r and o. If they do not
+// overlap, Intersect returns the zero Range and false. Ranges that merely
+// touch, like [a,b) and [b,c), do not overlap.
+func (r Range) Intersect(o Range) (Range, bool) {
+	x := Range{max(r.Start, o.Start), min(r.End, o.End)}
+	if x.IsEmpty() {
+		return Range{}, false
+	}
+	return x, true
+}
+
+// Overlaps reports whether r and o contain a common date. Ranges that merely
+// touch, like [a,b) and [b,c), do not overlap.
+func (r Range) Overlaps(o Range) bool {
+	_, ok := r.Intersect(o)
+	return ok
+}
+
+// Union returns the range containing the dates of both r and o. If they
+// neither overlap nor touch, the dates in between would have to be included,
+// so Union returns the zero Range and false. If either range is empty, the
+// other is returned.
+func (r Range) Union(o Range) (Range, bool) {
+	switch {
+	case r.IsEmpty():
+		return o, true
+	case o.IsEmpty():
+		return r, true
+	case r.End < o.Start || o.End < r.Start:
+		return Range{}, false
+	}
+	return Range{min(r.Start, o.Start), max(r.End, o.End)}, true
+}
+
+// OverlapDays returns the number of dates contained in both a and b. As ranges
+// are half-open, the End of either range is not counted: for a subscription
+// covering [2024-01-15,2024-02-15) and an invoice period covering
+// [2024-02-01,2024-03-01), OverlapDays returns 14.
+func OverlapDays(a, b Range) int {
+	x, _ := a.Intersect(b)
+	return x.Days()
+}
+
+// Dates returns an iterator over all dates in r, in ascending order.
+func (r Range) Dates() iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for d := r.Start; d < r.End; d++ {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// Weekdays returns an iterator over all dates in r falling on wd, in ascending
+// order. For example, every Monday of a quarter is given by
+//
+//	QuarterRange(2024, 2).Weekdays(time.Monday)
+func (r Range) Weekdays(wd time.Weekday) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for d := r.Start + Date((wd-r.Start.Weekday()+7)%7); d < r.End; d += 7 {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// Months returns an iterator over the chunks of r, as returned by
+// [Range.SplitByMonth].
+func (r Range) Months() iter.Seq[Range] {
+	return r.chunks(nextMonth)
+}
+
+// Weeks returns an iterator over the chunks of r, as returned by
+// [Range.SplitByWeek].
+func (r Range) Weeks(weekStart time.Weekday) iter.Seq[Range] {
+	return r.chunks(nextWeek(weekStart))
+}
+
+// SplitByMonth splits r at the start of each calendar month. The first and
+// last chunk may cover only part of a month. If r is empty, SplitByMonth
+// returns nil.
+func (r Range) SplitByMonth() []Range {
+	return r.split(nextMonth)
+}
+
+// nextMonth returns the first day of the month after the one containing d.
+func nextMonth(d Date) Date {
+	year, month, _ := d.Date()
+	return Of(year, month+1, 1)
+}
+
+// SplitByWeek splits r at each occurrence of weekStart. The first and last
+// chunk may cover only part of a week. If r is empty, SplitByWeek returns nil.
+// weekStart is taken modulo 7, as for [WeekdaysOf].
+func (r Range) SplitByWeek(weekStart time.Weekday) []Range {
+	return r.split(nextWeek(weekStart))
+}
+
+// nextWeek returns a function returning the first occurrence of weekStart
+// after a date.
+func nextWeek(weekStart time.Weekday) func(Date) Date {
+	weekStart = (weekStart%7 + 7) % 7
+	return func(d Date) Date {
+		return d + Date(7-(d.Weekday()-weekStart+7)%7)
+	}
+}
+
+// SplitByN splits r into chunks of n days, starting at r.Start. The last
+// chunk may be shorter. If r is empty, SplitByN returns nil. It panics if n
+// is not positive.
+func (r Range) SplitByN(n int) []Range {
+	if n <= 0 {
+		panic("SplitByN: non-positive chunk size")
+	}
+	return r.split(func(d Date) Date {
+		return d + Date(n)
+	})
+}
+
+// Windows returns an iterator over all ranges of size days contained in r,
+// starting at r.Start and advancing by step days. Only complete windows are
+// produced, so if r has fewer than size days, there are none. For example,
+// trailing 30-day windows moving daily over a year are given by
+//
+//	Windows(YearRange(2024), 30, 1)
+//
+// It panics if size or step is not positive.
+func Windows(r Range, size, step int) iter.Seq[Range] {
+	if size <= 0 || step <= 0 {
+		panic("Windows: non-positive size or step")
+	}
+	return func(yield func(Range) bool) {
+		for d := r.Start; d <= r.End-Date(size); d += Date(step) {
+			if !yield(Range{d, d + Date(size)}) {
+				return
+			}
+		}
+	}
+}
+
+// Coalesce groups the dates in ds into ranges of consecutive days. The
+// result is sorted and contains no overlapping or adjacent ranges. The order
+// of ds does not matter and duplicates are ignored. ds is not modified.
+func Coalesce(ds []Date) []Range {
+	ds = slices.Clone(ds)
+	slices.Sort(ds)
+	var out []Range
+	for _, d := range ds {
+		if n := len(out); n > 0 && d <= out[n-1].End {
+			out[n-1].End = max(out[n-1].End, d+1)
+			continue
+		}
+		out = append(out, Range{d, d + 1})
+	}
+	return out
+}
+
+// Expand returns all dates in rs, in order. It is the inverse of [Coalesce].
+func Expand(rs []Range) []Date {
+	n := 0
+	for _, r := range rs {
+		n += r.Days()
+	}
+	out := make([]Date, 0, n)
+	for _, r := range rs {
+		out = slices.AppendSeq(out, r.Dates())
+	}
+	return out
+}
+
+// split splits r into chunks. next returns the start of the chunk after the
+// one containing d and must return a date after d.
+func (r Range) split(next func(Date) Date) []Range {
+	return slices.Collect(r.chunks(next))
+}
+
+// chunks is like split, but returns an iterator.
+func (r Range) chunks(next func(Date) Date) iter.Seq[Range] {
+	return func(yield func(Range) bool) {
+		for d := r.Start; d < r.End; {
+			e := min(next(d), r.End)
+			if !yield(Range{d, e}) {
+				return
+			}
+			d = e
+		}
+	}
+}
+
+// String returns r in interval notation, like "[2024-05-01,2024-06-01)".
+//
+// The returned string is meant for debugging.
+func (r Range) String() string {
+	b := make([]byte, 0, 2*len(RFC3339)+3)
+	b = append(b, '[')
+	b = r.Start.AppendFormat(b, RFC3339)
+	b = append(b, ',')
+	b = r.End.AppendFormat(b, RFC3339)
+	b = append(b, ')')
+	return string(b)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The range is
+// formatted as an ISO 8601 time interval of its first and last day, like
+// "2024-05-01/2024-05-31" for the month of May 2024. An empty range is
+// formatted with the day before its start as the last day, like
+// "2024-05-14/2024-05-13".
+func (r Range) MarshalText() ([]byte, error) {
+	last := r.Last()
+	if r.IsEmpty() {
+		last = r.Start - 1
+	}
+	b := make([]byte, 0, 2*len(RFC3339)+1)
+	b = r.Start.AppendFormat(b, RFC3339)
+	b = append(b, '/')
+	b = last.AppendFormat(b, RFC3339)
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The range
+// must be an ISO 8601 time interval of its first and last day, as formatted
+// by MarshalText. The last day must not be before the first, except for the
+// day immediately before it, denoting an empty range.
+func (r *Range) UnmarshalText(b []byte) error {
+	v, err := parseInterval(string(b))
+	if err == nil {
+		*r = v
+	}
+	return err
+}
+
+// parseInterval parses s as an ISO 8601 time interval of dates, like
+// "2024-05-01/2024-05-31".
+func parseInterval(s string) (Range, error) {
+	first, last, ok := strings.Cut(s, "/")
+	if !ok {
+		return Range{}, &ParseError{Value: s, Message: "missing / in interval", Err: ErrSyntax}
+	}
+	a, err := Parse(RFC3339, first)
+	if err != nil {
+		return Range{}, err
+	}
+	b, err := Parse(RFC3339, last)
+	if err != nil {
+		return Range{}, err
+	}
+	if b < a-1 {
+		return Range{}, &ParseError{Value: s, Message: "end of interval before its start", Offset: len(first) + 1, Err: ErrRange}
+	}
+	return ClosedRange(a, b), nil
+}
+
+// RangeObject is a Range, which is represented in JSON as an object of its
+// first and last day, like {"from":"2024-05-01","to":"2024-05-31"}. Convert a
+// Range to RangeObject to use that representation:
+//
+//	type Booking struct {
+//		Stay date.RangeObject `json:"stay"`
+//	}
+type RangeObject Range
+
+// rangeObject is the JSON representation of a RangeObject.
+type rangeObject struct {
+	From *Date `json:"from"`
+	To   *Date `json:"to"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. Like for
+// [Range.MarshalText], the last day of an empty range is the day before its
+// start.
+func (r RangeObject) MarshalJSON() ([]byte, error) {
+	last := Range(r).Last()
+	if Range(r).IsEmpty() {
+		last = r.Start - 1
+	}
+	return json.Marshal(rangeObject{&r.Start, &last})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Both fields are
+// required and, like for [Range.UnmarshalText], to must not be before from,
+// except for the day immediately before it, denoting an empty range. As is the
+// convention, null is a no-op.
+func (r *RangeObject) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var v rangeObject
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v.From == nil || v.To == nil {
+		return errors.New("range must have fields from and to")
+	}
+	if *v.To < *v.From-1 {
+		return &ParseError{Value: string(b), Message: "end of interval before its start", Err: ErrRange}
+	}
+	*r = RangeObject(ClosedRange(*v.From, *v.To))
+	return nil
+}
+
+// String returns r in interval notation, as by [Range.String].
+func (r RangeObject) String() string {
+	return Range(r).String()
+}
@@ -0,0 +1,415 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRange(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		r     Range
+		days  int
+		dates []Date
+		str   string
+	}{
+		{Range{}, 0, nil, "[0001-01-01,0001-01-01)"},
+		{Range{Of(2024, 5, 14), Of(2024, 5, 13)}, 0, nil, "[2024-05-14,2024-05-13)"},
+		{Range{Of(2024, 5, 14), Of(2024, 5, 15)}, 1, []Date{Of(2024, 5, 14)}, "[2024-05-14,2024-05-15)"},
+		{ClosedRange(Of(2024, 2, 28), Of(2024, 3, 1)), 3, []Date{Of(2024, 2, 28), Of(2024, 2, 29), Of(2024, 3, 1)}, "[2024-02-28,2024-03-02)"},
+	}
+	for _, tc := range tcs {
+		if got := tc.r.Days(); got != tc.days {
+			t.Errorf("%v.Days() = %d, want %d", tc.r, got, tc.days)
+		}
+		if got := tc.r.IsEmpty(); got != (tc.days == 0) {
+			t.Errorf("%v.IsEmpty() = %v, want %v", tc.r, got, tc.days == 0)
+		}
+		if got := slices.Collect(tc.r.Dates()); !slices.Equal(got, tc.dates) {
+			t.Errorf("%v.Dates() = %v, want %v", tc.r, got, tc.dates)
+		}
+		if got := tc.r.String(); got != tc.str {
+			t.Errorf("%v.String() = %q, want %q", tc.r, got, tc.str)
+		}
+		for d := tc.r.Start - 2; d < tc.r.End+2; d++ {
+			if got, want := tc.r.Contains(d), slices.Contains(tc.dates, d); got != want {
+				t.Errorf("%v.Contains(%v) = %v, want %v", tc.r, d, got, want)
+			}
+		}
+		if tc.days > 0 && tc.r.Last() != tc.dates[len(tc.dates)-1] {
+			t.Errorf("%v.Last() = %v, want %v", tc.r, tc.r.Last(), tc.dates[len(tc.dates)-1])
+		}
+	}
+}
+
+func TestMonthRange(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		year int
+		m    time.Month
+		want Range
+		days int
+	}{
+		{2024, time.January, Range{Of(2024, 1, 1), Of(2024, 2, 1)}, 31},
+		{2024, time.February, Range{Of(2024, 2, 1), Of(2024, 3, 1)}, 29},
+		{2023, time.February, Range{Of(2023, 2, 1), Of(2023, 3, 1)}, 28},
+		{2024, time.December, Range{Of(2024, 12, 1), Of(2025, 1, 1)}, 31},
+		{2024, 13, Range{Of(2025, 1, 1), Of(2025, 2, 1)}, 31},
+	}
+	for _, tc := range tcs {
+		got := MonthRange(tc.year, tc.m)
+		if got != tc.want {
+			t.Errorf("MonthRange(%d, %v) = %v, want %v", tc.year, tc.m, got, tc.want)
+		}
+		if got.Days() != tc.days {
+			t.Errorf("MonthRange(%d, %v).Days() = %d, want %d", tc.year, tc.m, got.Days(), tc.days)
+		}
+	}
+}
+
+func TestYearRange(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		year int
+		days int
+	}{
+		{2023, 365},
+		{2024, 366},
+		{1900, 365},
+		{2000, 366},
+	}
+	for _, tc := range tcs {
+		got := YearRange(tc.year)
+		want := Range{Of(tc.year, 1, 1), Of(tc.year+1, 1, 1)}
+		if got != want {
+			t.Errorf("YearRange(%d) = %v, want %v", tc.year, got, want)
+		}
+		if got.Days() != tc.days {
+			t.Errorf("YearRange(%d).Days() = %d, want %d", tc.year, got.Days(), tc.days)
+		}
+	}
+}
+
+func TestRangeSplit(t *testing.T) {
+	t.Parallel()
+	d := func(m time.Month, day int) Date { return Of(2024, m, day) }
+	r := Range{d(1, 30), d(3, 2)}
+	tcs := []struct {
+		name string
+		got  []Range
+		want []Range
+	}{
+		{"SplitByMonth", r.SplitByMonth(), []Range{
+			{d(1, 30), d(2, 1)},
+			{d(2, 1), d(3, 1)},
+			{d(3, 1), d(3, 2)},
+		}},
+		{"SplitByMonth(aligned)", Range{d(1, 1), d(3, 1)}.SplitByMonth(), []Range{
+			{d(1, 1), d(2, 1)},
+			{d(2, 1), d(3, 1)},
+		}},
+		{"SplitByMonth(empty)", Range{d(3, 1), d(1, 1)}.SplitByMonth(), nil},
+		// 2024-05-14 is a Tuesday.
+		{"SplitByWeek(Monday)", Range{d(5, 14), d(5, 29)}.SplitByWeek(time.Monday), []Range{
+			{d(5, 14), d(5, 20)},
+			{d(5, 20), d(5, 27)},
+			{d(5, 27), d(5, 29)},
+		}},
+		{"SplitByWeek(Tuesday)", Range{d(5, 14), d(5, 29)}.SplitByWeek(time.Tuesday), []Range{
+			{d(5, 14), d(5, 21)},
+			{d(5, 21), d(5, 28)},
+			{d(5, 28), d(5, 29)},
+		}},
+		{"SplitByWeek(Sunday)", Range{d(5, 14), d(5, 15)}.SplitByWeek(time.Sunday), []Range{
+			{d(5, 14), d(5, 15)},
+		}},
+		{"SplitByWeek(Monday+14)", Range{d(5, 14), d(5, 29)}.SplitByWeek(time.Monday + 14), []Range{
+			{d(5, 14), d(5, 20)},
+			{d(5, 20), d(5, 27)},
+			{d(5, 27), d(5, 29)},
+		}},
+		{"SplitByWeek(Monday-7)", Range{d(5, 14), d(5, 29)}.SplitByWeek(time.Monday - 7), []Range{
+			{d(5, 14), d(5, 20)},
+			{d(5, 20), d(5, 27)},
+			{d(5, 27), d(5, 29)},
+		}},
+		{"SplitByN(10)", r.SplitByN(10), []Range{
+			{d(1, 30), d(2, 9)},
+			{d(2, 9), d(2, 19)},
+			{d(2, 19), d(2, 29)},
+			{d(2, 29), d(3, 2)},
+		}},
+		{"SplitByN(100)", r.SplitByN(100), []Range{r}},
+	}
+	for _, tc := range tcs {
+		if !slices.Equal(tc.got, tc.want) {
+			t.Errorf("%s = %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+}
+
+func TestSplitByNPanics(t *testing.T) {
+	t.Parallel()
+	defer func() {
+		if recover() == nil {
+			t.Error("SplitByN(0) did not panic")
+		}
+	}()
+	Range{0, 10}.SplitByN(0)
+}
+
+func TestWindows(t *testing.T) {
+	t.Parallel()
+	d := func(day int) Date { return Of(2024, 5, day) }
+	tcs := []struct {
+		r          Range
+		size, step int
+		want       []Range
+	}{
+		{Range{d(1), d(6)}, 3, 1, []Range{{d(1), d(4)}, {d(2), d(5)}, {d(3), d(6)}}},
+		{Range{d(1), d(8)}, 3, 2, []Range{{d(1), d(4)}, {d(3), d(6)}, {d(5), d(8)}}},
+		{Range{d(1), d(9)}, 3, 3, []Range{{d(1), d(4)}, {d(4), d(7)}}},
+		{Range{d(1), d(6)}, 5, 1, []Range{{d(1), d(6)}}},
+		{Range{d(1), d(6)}, 6, 1, nil},
+		{Range{d(6), d(1)}, 1, 1, nil},
+	}
+	for _, tc := range tcs {
+		if got := slices.Collect(Windows(tc.r, tc.size, tc.step)); !slices.Equal(got, tc.want) {
+			t.Errorf("Windows(%v, %d, %d) = %v, want %v", tc.r, tc.size, tc.step, got, tc.want)
+		}
+	}
+	if got := slices.Collect(Windows(YearRange(2024), 30, 1)); len(got) != 366-29 {
+		t.Errorf("Windows(YearRange(2024), 30, 1) has %d windows, want %d", len(got), 366-29)
+	}
+}
+
+func TestWindowsPanics(t *testing.T) {
+	t.Parallel()
+	for _, args := range [][2]int{{0, 1}, {1, 0}, {-1, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Windows(_, %d, %d) did not panic", args[0], args[1])
+				}
+			}()
+			Windows(Range{0, 10}, args[0], args[1])
+		}()
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	t.Parallel()
+	d := func(day int) Date { return Of(2024, 3, day) }
+	tcs := []struct {
+		ds   []Date
+		want []Range
+	}{
+		{nil, nil},
+		{[]Date{d(3)}, []Range{{d(3), d(4)}}},
+		{[]Date{d(3), d(4), d(5), d(6), d(7), d(10)}, []Range{ClosedRange(d(3), d(7)), ClosedRange(d(10), d(10))}},
+		{[]Date{d(10), d(5), d(3), d(4), d(4), d(7), d(6)}, []Range{ClosedRange(d(3), d(7)), ClosedRange(d(10), d(10))}},
+		{[]Date{d(1), d(3), d(5)}, []Range{{d(1), d(2)}, {d(3), d(4)}, {d(5), d(6)}}},
+	}
+	for _, tc := range tcs {
+		in := slices.Clone(tc.ds)
+		got := Coalesce(tc.ds)
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("Coalesce(%v) = %v, want %v", tc.ds, got, tc.want)
+		}
+		if !slices.Equal(tc.ds, in) {
+			t.Errorf("Coalesce(%v) modified its argument", in)
+		}
+		want := slices.Compact(slices.Sorted(slices.Values(tc.ds)))
+		if got := Expand(got); !slices.Equal(got, want) {
+			t.Errorf("Expand(%v) = %v, want %v", tc.want, got, want)
+		}
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	t.Parallel()
+	d := func(m time.Month, day int) Date { return Of(2024, m, day) }
+	tcs := []struct {
+		a, b Range
+		want Range
+		ok   bool
+	}{
+		{Range{d(1, 15), d(2, 15)}, Range{d(2, 1), d(3, 1)}, Range{d(2, 1), d(2, 15)}, true},
+		{Range{d(2, 1), d(3, 1)}, Range{d(1, 15), d(2, 15)}, Range{d(2, 1), d(2, 15)}, true},
+		{Range{d(1, 1), d(3, 1)}, Range{d(2, 1), d(2, 2)}, Range{d(2, 1), d(2, 2)}, true},
+		{Range{d(1, 1), d(2, 1)}, Range{d(2, 1), d(3, 1)}, Range{}, false},
+		{Range{d(1, 1), d(2, 1)}, Range{d(2, 5), d(3, 1)}, Range{}, false},
+		{Range{d(1, 1), d(3, 1)}, Range{d(2, 5), d(2, 5)}, Range{}, false},
+		{ClosedRange(d(2, 1), d(2, 1)), ClosedRange(d(2, 1), d(2, 1)), Range{d(2, 1), d(2, 2)}, true},
+	}
+	for _, tc := range tcs {
+		got, ok := tc.a.Intersect(tc.b)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%v.Intersect(%v) = %v, %v, want %v, %v", tc.a, tc.b, got, ok, tc.want, tc.ok)
+		}
+		if got, want := OverlapDays(tc.a, tc.b), tc.want.Days(); got != want {
+			t.Errorf("OverlapDays(%v, %v) = %d, want %d", tc.a, tc.b, got, want)
+		}
+		if got := tc.a.Overlaps(tc.b); got != tc.ok {
+			t.Errorf("%v.Overlaps(%v) = %v, want %v", tc.a, tc.b, got, tc.ok)
+		}
+	}
+}
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+	d := func(m time.Month, day int) Date { return Of(2024, m, day) }
+	tcs := []struct {
+		a, b Range
+		want Range
+		ok   bool
+	}{
+		{Range{d(1, 15), d(2, 15)}, Range{d(2, 1), d(3, 1)}, Range{d(1, 15), d(3, 1)}, true},
+		{Range{d(1, 1), d(3, 1)}, Range{d(2, 1), d(2, 2)}, Range{d(1, 1), d(3, 1)}, true},
+		{Range{d(1, 1), d(2, 1)}, Range{d(2, 1), d(3, 1)}, Range{d(1, 1), d(3, 1)}, true},
+		{Range{d(2, 1), d(3, 1)}, Range{d(1, 1), d(2, 1)}, Range{d(1, 1), d(3, 1)}, true},
+		{Range{d(1, 1), d(2, 1)}, Range{d(2, 5), d(3, 1)}, Range{}, false},
+		{Range{d(1, 1), d(2, 1)}, Range{d(5, 5), d(5, 5)}, Range{d(1, 1), d(2, 1)}, true},
+		{Range{d(5, 5), d(5, 1)}, Range{d(1, 1), d(2, 1)}, Range{d(1, 1), d(2, 1)}, true},
+	}
+	for _, tc := range tcs {
+		got, ok := tc.a.Union(tc.b)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%v.Union(%v) = %v, %v, want %v, %v", tc.a, tc.b, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestRangeWeekdays(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		r    Range
+		wd   time.Weekday
+		want []Date
+	}{
+		{MonthRange(2024, time.May), time.Monday, []Date{Of(2024, 5, 6), Of(2024, 5, 13), Of(2024, 5, 20), Of(2024, 5, 27)}},
+		{MonthRange(2024, time.May), time.Wednesday, []Date{Of(2024, 5, 1), Of(2024, 5, 8), Of(2024, 5, 15), Of(2024, 5, 22), Of(2024, 5, 29)}},
+		{Range{Of(2024, 5, 14), Of(2024, 5, 20)}, time.Monday, nil},
+		{Range{Of(2024, 5, 14), Of(2024, 5, 1)}, time.Monday, nil},
+	}
+	for _, tc := range tcs {
+		if got := slices.Collect(tc.r.Weekdays(tc.wd)); !slices.Equal(got, tc.want) {
+			t.Errorf("%v.Weekdays(%v) = %v, want %v", tc.r, tc.wd, got, tc.want)
+		}
+	}
+}
+
+func TestRangeChunks(t *testing.T) {
+	t.Parallel()
+	for _, r := range []Range{{}, {Of(2024, 1, 15), Of(2024, 4, 3)}, YearRange(2024)} {
+		if got, want := slices.Collect(r.Months()), r.SplitByMonth(); !slices.Equal(got, want) {
+			t.Errorf("%v.Months() = %v, want %v", r, got, want)
+		}
+		if got, want := slices.Collect(r.Weeks(time.Monday)), r.SplitByWeek(time.Monday); !slices.Equal(got, want) {
+			t.Errorf("%v.Weeks(Monday) = %v, want %v", r, got, want)
+		}
+	}
+}
+
+func TestRangeText(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		r   Range
+		txt string
+	}{
+		{MonthRange(2024, time.May), "2024-05-01/2024-05-31"},
+		{Range{Of(2024, 5, 14), Of(2024, 5, 15)}, "2024-05-14/2024-05-14"},
+		{Range{Of(2024, 5, 14), Of(2024, 5, 14)}, "2024-05-14/2024-05-13"},
+	}
+	for _, tc := range tcs {
+		b, err := tc.r.MarshalText()
+		if err != nil || string(b) != tc.txt {
+			t.Errorf("%v.MarshalText() = %q, %v, want %q, <nil>", tc.r, b, err, tc.txt)
+		}
+		var got Range
+		if err := got.UnmarshalText([]byte(tc.txt)); err != nil || got != tc.r {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want %v, <nil>", tc.txt, got, err, tc.r)
+		}
+	}
+	// Empty ranges are normalized, as only the start is kept.
+	r := Range{Of(2024, 5, 14), Of(2024, 5, 1)}
+	if b, err := r.MarshalText(); err != nil || string(b) != "2024-05-14/2024-05-13" {
+		t.Errorf("%v.MarshalText() = %q, %v, want %q, <nil>", r, b, err, "2024-05-14/2024-05-13")
+	}
+	for _, s := range []string{"", "2024-05-01", "2024-05-01/", "2024-05-01--2024-05-31", "2024-05-01/2024-05-31/2024-06-30", "2024-05-14/2024-05-01", "2024-05-14/2024-05-12"} {
+		var r Range
+		if err := r.UnmarshalText([]byte(s)); err == nil {
+			t.Errorf("UnmarshalText(%q) = %v, <nil>, want error", s, r)
+		}
+	}
+	var pe *ParseError
+	if err := r.UnmarshalText([]byte("2024-05-14/2024-05-01")); !errors.As(err, &pe) || !errors.Is(err, ErrRange) {
+		t.Errorf("UnmarshalText(%q) = %v, want *ParseError wrapping ErrRange", "2024-05-14/2024-05-01", err)
+	}
+}
+
+func TestRangeObjectJSON(t *testing.T) {
+	t.Parallel()
+	type S struct {
+		R RangeObject `json:"r"`
+	}
+	may := RangeObject(MonthRange(2024, time.May))
+	b, err := json.Marshal(S{may})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"r":{"from":"2024-05-01","to":"2024-05-31"}}`; got != want {
+		t.Errorf("json.Marshal = %s, want %s", got, want)
+	}
+
+	tcs := []struct {
+		in   string
+		want RangeObject
+		err  bool
+	}{
+		{`{"r":{"from":"2024-05-01","to":"2024-05-31"}}`, may, false},
+		{`{"r":{"to":"2024-05-31","from":"2024-05-01"}}`, may, false},
+		{`{"r":null}`, RangeObject{1, 2}, false},
+		{`{"r":{"from":"2024-05-01"}}`, RangeObject{}, true},
+		{`{"r":{"from":"2024-05-01","to":"May 31"}}`, RangeObject{}, true},
+		{`{"r":"2024-05-01/2024-05-31"}`, RangeObject{}, true},
+		{`{"r":{"from":"2024-05-14","to":"2024-05-13"}}`, RangeObject{Of(2024, 5, 14), Of(2024, 5, 14)}, false},
+		{`{"r":{"from":"2024-05-14","to":"2024-05-01"}}`, RangeObject{}, true},
+	}
+	for _, tc := range tcs {
+		s := S{RangeObject{1, 2}}
+		err := json.Unmarshal([]byte(tc.in), &s)
+		if (err != nil) != tc.err {
+			t.Errorf("json.Unmarshal(%s) = %v, want error: %v", tc.in, err, tc.err)
+			continue
+		}
+		if err == nil && s.R != tc.want {
+			t.Errorf("json.Unmarshal(%s) = %v, want %v", tc.in, s.R, tc.want)
+		}
+	}
+
+	// Empty ranges are normalized, as only the start is kept.
+	empty := RangeObject{Of(2024, 5, 14), Of(2024, 5, 1)}
+	b, err = json.Marshal(empty)
+	if got, want := string(b), `{"from":"2024-05-14","to":"2024-05-13"}`; err != nil || got != want {
+		t.Errorf("json.Marshal(%v) = %s, %v, want %s, <nil>", empty, got, err, want)
+	}
+	var pe *ParseError
+	if err := json.Unmarshal([]byte(`{"from":"2024-05-14","to":"2024-05-01"}`), &empty); !errors.As(err, &pe) || !errors.Is(err, ErrRange) {
+		t.Errorf("json.Unmarshal(reversed range) = %v, want *ParseError wrapping ErrRange", err)
+	}
+
+	// A Range is represented as an interval string.
+	b, err = json.Marshal(Range(may))
+	if got, want := string(b), `"2024-05-01/2024-05-31"`; err != nil || got != want {
+		t.Errorf("json.Marshal(%v) = %s, %v, want %s, <nil>", Range(may), got, err, want)
+	}
+}
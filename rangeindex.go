@@ -0,0 +1,122 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"slices"
+)
+
+// A RangeIndex is an immutable index over a list of possibly overlapping
+// ranges, answering membership and overlap queries in logarithmic time.
+//
+// Unlike a [RangeSet], which merges its ranges, a RangeIndex keeps track of
+// the individual ranges it was built from. This makes it possible to ask which
+// of the ranges contain a given date.
+type RangeIndex struct {
+	// union is the set of all dates contained in any range.
+	union *RangeSet
+	// items are the non-empty ranges, sorted by Start. They form an implicit
+	// balanced search tree: the root of items[lo:hi] is at (lo+hi)/2.
+	items []indexItem
+}
+
+type indexItem struct {
+	r Range
+	i int // index in the original list
+	// maxEnd is the maximum End in the subtree rooted at this item.
+	maxEnd Date
+}
+
+// NewRangeIndex builds a RangeIndex over rs. Empty ranges are ignored.
+func NewRangeIndex(rs []Range) *RangeIndex {
+	idx := &RangeIndex{union: new(RangeSet)}
+	for i, r := range rs {
+		if r.IsEmpty() {
+			continue
+		}
+		idx.items = append(idx.items, indexItem{r: r, i: i})
+		idx.union.Add(r)
+	}
+	slices.SortFunc(idx.items, func(a, b indexItem) int {
+		return cmp.Compare(a.r.Start, b.r.Start)
+	})
+	idx.build(0, len(idx.items))
+	return idx
+}
+
+// build computes maxEnd for the subtree items[lo:hi] and returns it.
+func (idx *RangeIndex) build(lo, hi int) Date {
+	if lo >= hi {
+		return math.MinInt
+	}
+	mid := (lo + hi) / 2
+	m := max(idx.items[mid].r.End, idx.build(lo, mid), idx.build(mid+1, hi))
+	idx.items[mid].maxEnd = m
+	return m
+}
+
+// Len returns the number of non-empty ranges in idx.
+func (idx *RangeIndex) Len() int {
+	return len(idx.items)
+}
+
+// Union returns the set of dates contained in any of the ranges of idx. The
+// returned RangeSet must not be modified.
+func (idx *RangeIndex) Union() *RangeSet {
+	return idx.union
+}
+
+// Contains reports whether d is contained in any of the ranges of idx.
+func (idx *RangeIndex) Contains(d Date) bool {
+	return idx.union.Contains(d)
+}
+
+// Stab returns an iterator over the ranges containing d, together with their
+// index in the list passed to NewRangeIndex. The ranges are yielded in order
+// of their start date.
+func (idx *RangeIndex) Stab(d Date) iter.Seq2[int, Range] {
+	return idx.Overlapping(Range{d, d + 1})
+}
+
+// Overlapping returns an iterator over the ranges sharing at least one date
+// with r, together with their index in the list passed to NewRangeIndex. The
+// ranges are yielded in order of their start date.
+func (idx *RangeIndex) Overlapping(r Range) iter.Seq2[int, Range] {
+	return func(yield func(int, Range) bool) {
+		if r.IsEmpty() {
+			return
+		}
+		idx.query(0, len(idx.items), r, yield)
+	}
+}
+
+// query yields all ranges in items[lo:hi] overlapping r. It returns false, if
+// yield returned false.
+func (idx *RangeIndex) query(lo, hi int, r Range, yield func(int, Range) bool) bool {
+	if lo >= hi {
+		return true
+	}
+	mid := (lo + hi) / 2
+	it := idx.items[mid]
+	if it.maxEnd <= r.Start {
+		// No range in this subtree ends after r starts.
+		return true
+	}
+	if !idx.query(lo, mid, r, yield) {
+		return false
+	}
+	if it.r.Start >= r.End {
+		// This and all ranges in the right subtree start after r ends.
+		return true
+	}
+	if it.r.End > r.Start && !yield(it.i, it.r) {
+		return false
+	}
+	return idx.query(mid+1, hi, r, yield)
+}
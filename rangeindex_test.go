@@ -0,0 +1,100 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestRangeIndex(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 1)
+	rs := []Range{
+		{d, d + 10},
+		{d + 5, d + 6},
+		{d + 3, d + 3}, // empty
+		{d + 8, d + 20},
+		{d + 30, d + 31},
+	}
+	idx := NewRangeIndex(rs)
+	if got, want := idx.Len(), 4; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+	collect := func(seq func(func(int, Range) bool)) []int {
+		var out []int
+		for i := range seq {
+			out = append(out, i)
+		}
+		return out
+	}
+	tcs := []struct {
+		d    Date
+		want []int
+	}{
+		{d - 1, nil},
+		{d, []int{0}},
+		{d + 5, []int{0, 1}},
+		{d + 9, []int{0, 3}},
+		{d + 10, []int{3}},
+		{d + 25, nil},
+		{d + 30, []int{4}},
+	}
+	for _, tc := range tcs {
+		if got := collect(idx.Stab(tc.d)); !slices.Equal(got, tc.want) {
+			t.Errorf("Stab(%v) = %v, want %v", tc.d, got, tc.want)
+		}
+		if got, want := idx.Contains(tc.d), len(tc.want) > 0; got != want {
+			t.Errorf("Contains(%v) = %v, want %v", tc.d, got, want)
+		}
+	}
+	if got, want := collect(idx.Overlapping(Range{d + 6, d + 31})), []int{0, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("Overlapping = %v, want %v", got, want)
+	}
+}
+
+func TestRangeIndexExtremes(t *testing.T) {
+	t.Parallel()
+	rs := []Range{
+		{math.MaxInt - 1, math.MaxInt},
+		{0, 1},
+		{math.MinInt, math.MinInt + 1},
+	}
+	idx := NewRangeIndex(rs)
+	for i, r := range rs {
+		var got []int
+		for j := range idx.Stab(r.Start) {
+			got = append(got, j)
+		}
+		if want := []int{i}; !slices.Equal(got, want) {
+			t.Errorf("Stab(%d) = %v, want %v", int(r.Start), got, want)
+		}
+	}
+}
+
+func FuzzRangeIndex(f *testing.F) {
+	f.Add([]byte{1, 5, 10, 3, 4, 8, 0, 31}, byte(3), byte(5))
+	f.Fuzz(func(t *testing.T, b []byte, start, n byte) {
+		rs := decodeRanges(b)
+		idx := NewRangeIndex(rs)
+		q := Range{Date(start), Date(start) + Date(n%32)}
+		var want []int
+		for i, r := range rs {
+			if max(r.Start, q.Start) < min(r.End, q.End) {
+				want = append(want, i)
+			}
+		}
+		var got []int
+		for i := range idx.Overlapping(q) {
+			got = append(got, i)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Fatalf("Overlapping(%v) = %v, want %v", q, got, want)
+		}
+	})
+}
@@ -0,0 +1,330 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/binary"
+	"errors"
+	"iter"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// A RangeSet is a set of dates, represented as a sorted list of disjoint
+// ranges. Adjacent and overlapping ranges are merged when they are added, so
+// the representation of a set is unique.
+//
+// The zero value is an empty set, ready to use. A RangeSet must not be copied
+// after first use, use Clone instead.
+type RangeSet struct {
+	// ranges is sorted and contains no empty, overlapping or adjacent ranges.
+	ranges []Range
+}
+
+// NewRangeSet returns a RangeSet containing the union of rs.
+func NewRangeSet(rs ...Range) *RangeSet {
+	s := new(RangeSet)
+	for _, r := range rs {
+		s.Add(r)
+	}
+	return s
+}
+
+// Clone returns a copy of s.
+func (s *RangeSet) Clone() *RangeSet {
+	return &RangeSet{slices.Clone(s.ranges)}
+}
+
+// search returns the index of the first range in s for which f is true. f
+// must be monotonic.
+func (s *RangeSet) search(f func(Range) bool) int {
+	return sort.Search(len(s.ranges), func(i int) bool { return f(s.ranges[i]) })
+}
+
+// Add adds all dates in r to s.
+func (s *RangeSet) Add(r Range) {
+	if r.IsEmpty() {
+		return
+	}
+	// ranges[i:j] overlap or are adjacent to r and get merged into it.
+	i := s.search(func(x Range) bool { return x.End >= r.Start })
+	j := s.search(func(x Range) bool { return x.Start > r.End })
+	if i < j {
+		r.Start = min(r.Start, s.ranges[i].Start)
+		r.End = max(r.End, s.ranges[j-1].End)
+	}
+	s.ranges = slices.Replace(s.ranges, i, j, r)
+}
+
+// Subtract removes all dates in r from s.
+func (s *RangeSet) Subtract(r Range) {
+	if r.IsEmpty() {
+		return
+	}
+	// ranges[i:j] overlap r.
+	i := s.search(func(x Range) bool { return x.End > r.Start })
+	j := s.search(func(x Range) bool { return x.Start >= r.End })
+	if i == j {
+		return
+	}
+	var rest [2]Range
+	n := 0
+	if first := s.ranges[i]; first.Start < r.Start {
+		rest[n] = Range{first.Start, r.Start}
+		n++
+	}
+	if last := s.ranges[j-1]; last.End > r.End {
+		rest[n] = Range{r.End, last.End}
+		n++
+	}
+	s.ranges = slices.Replace(s.ranges, i, j, rest[:n]...)
+}
+
+// AddDate adds d to s.
+func (s *RangeSet) AddDate(d Date) {
+	s.Add(Range{d, d + 1})
+}
+
+// SubtractDate removes d from s.
+func (s *RangeSet) SubtractDate(d Date) {
+	s.Subtract(Range{d, d + 1})
+}
+
+// Contains reports whether d is in s.
+func (s *RangeSet) Contains(d Date) bool {
+	i := s.search(func(x Range) bool { return x.End > d })
+	return i < len(s.ranges) && s.ranges[i].Start <= d
+}
+
+// TotalDays returns the number of dates in s.
+func (s *RangeSet) TotalDays() int {
+	n := 0
+	for _, r := range s.ranges {
+		n += r.Days()
+	}
+	return n
+}
+
+// IsEmpty reports whether s contains no dates.
+func (s *RangeSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Ranges returns an iterator over the disjoint, non-adjacent ranges making up
+// s, in ascending order.
+func (s *RangeSet) Ranges() iter.Seq[Range] {
+	return func(yield func(Range) bool) {
+		for _, r := range s.ranges {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// Dates returns an iterator over all dates in s, in ascending order.
+func (s *RangeSet) Dates() iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for _, r := range s.ranges {
+			for d := r.Start; d < r.End; d++ {
+				if !yield(d) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Union returns a new RangeSet containing the dates in either s or o.
+func (s *RangeSet) Union(o *RangeSet) *RangeSet {
+	u := s.Clone()
+	for _, r := range o.ranges {
+		u.Add(r)
+	}
+	return u
+}
+
+// Intersect returns a new RangeSet containing the dates in both s and o.
+func (s *RangeSet) Intersect(o *RangeSet) *RangeSet {
+	out := new(RangeSet)
+	a, b := s.ranges, o.ranges
+	for len(a) > 0 && len(b) > 0 {
+		if r, ok := a[0].Intersect(b[0]); ok {
+			out.ranges = append(out.ranges, r)
+		}
+		if a[0].End < b[0].End {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return out
+}
+
+// Difference returns a new RangeSet containing the dates in s, but not in o.
+func (s *RangeSet) Difference(o *RangeSet) *RangeSet {
+	d := s.Clone()
+	for _, r := range o.ranges {
+		d.Subtract(r)
+	}
+	return d
+}
+
+// DiffSets returns the changes from old to new: the dates added, which are in
+// new but not in old, and the dates removed, which are in old but not in new.
+// This is useful to publish incremental updates of an edited calendar.
+func DiffSets(old, new *RangeSet) (added, removed *RangeSet) {
+	return new.Difference(old), old.Difference(new)
+}
+
+// Complement returns a new RangeSet containing the dates in bounds, which are
+// not in s.
+func (s *RangeSet) Complement(bounds Range) *RangeSet {
+	out := new(RangeSet)
+	next := bounds.Start
+	for _, r := range s.ranges {
+		if r.End <= next {
+			continue
+		}
+		if r.Start >= bounds.End {
+			break
+		}
+		if r.Start > next {
+			out.ranges = append(out.ranges, Range{next, r.Start})
+		}
+		next = r.End
+	}
+	if next < bounds.End {
+		out.ranges = append(out.ranges, Range{next, bounds.End})
+	}
+	return out
+}
+
+// Equal reports whether s and o contain the same dates.
+func (s *RangeSet) Equal(o *RangeSet) bool {
+	return slices.Equal(s.ranges, o.ranges)
+}
+
+// String returns the ranges of s, like "{[2024-05-01,2024-05-03)
+// [2024-05-10,2024-05-11)}".
+//
+// The returned string is meant for debugging.
+func (s *RangeSet) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, r := range s.ranges {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(r.String())
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. Each range
+// is encoded as its distance from the end of the previous range and its number
+// of days, as [binary.Uvarint]. The start of the first range is encoded as a
+// [binary.Varint] instead. An empty set is encoded as no data.
+func (s *RangeSet) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 2*binary.MaxVarintLen64*len(s.ranges))
+	for i, r := range s.ranges {
+		if i == 0 {
+			b = binary.AppendVarint(b, int64(r.Start))
+		} else {
+			b = binary.AppendUvarint(b, uint64(r.Start-s.ranges[i-1].End))
+		}
+		b = binary.AppendUvarint(b, uint64(r.Days()))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *RangeSet) UnmarshalBinary(b []byte) error {
+	// next reads a positive Uvarint and adds it to d.
+	next := func(d Date) (Date, error) {
+		v, i := binary.Uvarint(b)
+		switch {
+		case i == 0:
+			return 0, errors.New("encoded range set truncated")
+		case i < 0 || v > math.MaxInt64 || d+Date(v) < d:
+			return 0, errors.New("encoded range set overflows int")
+		case v == 0:
+			return 0, errors.New("encoded range set has empty or adjacent ranges")
+		}
+		b = b[i:]
+		return d + Date(v), nil
+	}
+	var ranges []Range
+	for len(b) > 0 {
+		var (
+			r   Range
+			err error
+		)
+		if len(ranges) == 0 {
+			v, i := binary.Varint(b)
+			if i <= 0 || int64(int(v)) != v {
+				return errors.New("invalid range set start")
+			}
+			r.Start, b = Date(v), b[i:]
+		} else if r.Start, err = next(ranges[len(ranges)-1].End); err != nil {
+			return err
+		}
+		if r.End, err = next(r.Start); err != nil {
+			return err
+		}
+		ranges = append(ranges, r)
+	}
+	s.ranges = ranges
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The set is
+// formatted as a comma separated list of its ranges, as by
+// [Range.MarshalText], with single days formatted as a date. For example
+// "2024-05-01/2024-05-03,2024-05-10".
+func (s *RangeSet) MarshalText() ([]byte, error) {
+	var b []byte
+	for i, r := range s.ranges {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = r.Start.AppendFormat(b, RFC3339)
+		if r.Days() > 1 {
+			b = append(b, '/')
+			b = r.Last().AppendFormat(b, RFC3339)
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The set
+// must be a comma separated list of dates and ranges, as formatted by
+// MarshalText. The elements may be in any order and overlap.
+func (s *RangeSet) UnmarshalText(b []byte) error {
+	out := new(RangeSet)
+	if len(b) > 0 {
+		for _, v := range strings.Split(string(b), ",") {
+			if strings.Contains(v, "/") {
+				r, err := parseInterval(v)
+				if err != nil {
+					return err
+				}
+				out.Add(r)
+				continue
+			}
+			d, err := Parse(RFC3339, v)
+			if err != nil {
+				return err
+			}
+			out.AddDate(d)
+		}
+	}
+	s.ranges = out.ranges
+	return nil
+}
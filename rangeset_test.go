@@ -0,0 +1,205 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRangeSet(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 1)
+	s := NewRangeSet(Range{d, d + 3}, Range{d + 10, d + 12})
+	if got, want := s.String(), "{[2024-05-01,2024-05-04) [2024-05-11,2024-05-13)}"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	s.Add(Range{d + 3, d + 5}) // adjacent, merged
+	s.Add(Range{d + 7, d + 7}) // empty, ignored
+	if got, want := slices.Collect(s.Ranges()), []Range{{d, d + 5}, {d + 10, d + 12}}; !slices.Equal(got, want) {
+		t.Errorf("Ranges() = %v, want %v", got, want)
+	}
+	if got, want := s.TotalDays(), 7; got != want {
+		t.Errorf("TotalDays() = %d, want %d", got, want)
+	}
+	s.Subtract(Range{d + 2, d + 11})
+	if got, want := slices.Collect(s.Dates()), []Date{d, d + 1, d + 11}; !slices.Equal(got, want) {
+		t.Errorf("Dates() = %v, want %v", got, want)
+	}
+	c := s.Complement(Range{d - 1, d + 13})
+	if got, want := slices.Collect(c.Ranges()), []Range{{d - 1, d}, {d + 2, d + 11}, {d + 12, d + 13}}; !slices.Equal(got, want) {
+		t.Errorf("Complement() = %v, want %v", got, want)
+	}
+	if !new(RangeSet).IsEmpty() || s.IsEmpty() {
+		t.Errorf("IsEmpty() returned wrong result")
+	}
+}
+
+func TestDiffSets(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 1)
+	old := NewRangeSet(Range{d, d + 5}, Range{d + 10, d + 12})
+	new := NewRangeSet(Range{d + 2, d + 7}, Range{d + 10, d + 12}, Range{d + 20, d + 21})
+	added, removed := DiffSets(old, new)
+	if got, want := slices.Collect(added.Ranges()), []Range{{d + 5, d + 7}, {d + 20, d + 21}}; !slices.Equal(got, want) {
+		t.Errorf("DiffSets(%v, %v) added %v, want %v", old, new, got, want)
+	}
+	if got, want := slices.Collect(removed.Ranges()), []Range{{d, d + 2}}; !slices.Equal(got, want) {
+		t.Errorf("DiffSets(%v, %v) removed %v, want %v", old, new, got, want)
+	}
+	if added, removed := DiffSets(old, old.Clone()); !added.IsEmpty() || !removed.IsEmpty() {
+		t.Errorf("DiffSets(%v, %v) = %v, %v, want empty sets", old, old, added, removed)
+	}
+}
+
+// rangeSetModel is a trivial implementation of a set of dates, to compare
+// RangeSet against.
+type rangeSetModel map[Date]bool
+
+func (m rangeSetModel) set(r Range, v bool) {
+	for d := r.Start; d < r.End; d++ {
+		if v {
+			m[d] = true
+		} else {
+			delete(m, d)
+		}
+	}
+}
+
+func (m rangeSetModel) check(t *testing.T, name string, s *RangeSet) {
+	t.Helper()
+	for i, r := range s.ranges {
+		if r.IsEmpty() || (i > 0 && s.ranges[i-1].End >= r.Start) {
+			t.Fatalf("%s: invalid representation %v", name, s)
+		}
+	}
+	if got, want := s.TotalDays(), len(m); got != want {
+		t.Fatalf("%s: TotalDays() = %d, want %d", name, got, want)
+	}
+	for d := Date(-2); d < 300; d++ {
+		if got, want := s.Contains(d), m[d]; got != want {
+			t.Fatalf("%s: %v.Contains(%v) = %v, want %v", name, s, d, got, want)
+		}
+	}
+}
+
+// decodeRanges interprets b as a list of (start, length) byte pairs.
+func decodeRanges(b []byte) []Range {
+	var rs []Range
+	for ; len(b) >= 2; b = b[2:] {
+		start := Date(b[0])
+		rs = append(rs, Range{start, start + Date(b[1]%32)})
+	}
+	return rs
+}
+
+func FuzzRangeSet(f *testing.F) {
+	f.Add([]byte{1, 5, 10, 3, 4, 8}, []byte{3, 2, 9, 2})
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		ra, rb := decodeRanges(a), decodeRanges(b)
+		sa, sb := NewRangeSet(ra...), NewRangeSet(rb...)
+		ma, mb := rangeSetModel{}, rangeSetModel{}
+		for _, r := range ra {
+			ma.set(r, true)
+		}
+		for _, r := range rb {
+			mb.set(r, true)
+		}
+		ma.check(t, "a", sa)
+		mb.check(t, "b", sb)
+
+		union, inter, diff := rangeSetModel{}, rangeSetModel{}, rangeSetModel{}
+		for d := range ma {
+			union[d] = true
+			if mb[d] {
+				inter[d] = true
+			} else {
+				diff[d] = true
+			}
+		}
+		for d := range mb {
+			union[d] = true
+		}
+		union.check(t, "Union", sa.Union(sb))
+		inter.check(t, "Intersect", sa.Intersect(sb))
+		diff.check(t, "Difference", sa.Difference(sb))
+
+		bounds := Range{10, 200}
+		comp := rangeSetModel{}
+		for d := bounds.Start; d < bounds.End; d++ {
+			if !ma[d] {
+				comp[d] = true
+			}
+		}
+		comp.check(t, "Complement", sa.Complement(bounds))
+
+		// Check that the operations did not modify their operands.
+		ma.check(t, "a", sa)
+		mb.check(t, "b", sb)
+	})
+}
+
+func TestRangeSetDates(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 1)
+	s := new(RangeSet)
+	for _, x := range []Date{d + 2, d, d + 1, d + 5} {
+		s.AddDate(x)
+	}
+	if got, want := slices.Collect(s.Ranges()), []Range{{d, d + 3}, {d + 5, d + 6}}; !slices.Equal(got, want) {
+		t.Errorf("Ranges() = %v, want %v", got, want)
+	}
+	s.SubtractDate(d + 1)
+	s.SubtractDate(d + 4)
+	if got, want := slices.Collect(s.Ranges()), []Range{{d, d + 1}, {d + 2, d + 3}, {d + 5, d + 6}}; !slices.Equal(got, want) {
+		t.Errorf("Ranges() = %v, want %v", got, want)
+	}
+}
+
+func TestRangeSetMarshal(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 1)
+	tcs := []struct {
+		s    *RangeSet
+		text string
+	}{
+		{new(RangeSet), ""},
+		{NewRangeSet(Range{d, d + 1}), "2024-05-01"},
+		{NewRangeSet(Range{d, d + 3}, Range{d + 9, d + 10}, Range{d + 20, d + 50}), "2024-05-01/2024-05-03,2024-05-10,2024-05-21/2024-06-19"},
+		{NewRangeSet(Range{-5, -2}, Range{0, 1}), "0000-12-27/0000-12-29,0001-01-01"},
+	}
+	for _, tc := range tcs {
+		b, err := tc.s.MarshalText()
+		if err != nil || string(b) != tc.text {
+			t.Errorf("%v.MarshalText() = %q, %v, want %q, <nil>", tc.s, b, err, tc.text)
+		}
+		got := NewRangeSet(Range{d, d + 100})
+		if err := got.UnmarshalText(b); err != nil || !got.Equal(tc.s) {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want %v, <nil>", b, got, err, tc.s)
+		}
+		b, _ = tc.s.MarshalBinary()
+		got = NewRangeSet(Range{d, d + 100})
+		if err := got.UnmarshalBinary(b); err != nil || !got.Equal(tc.s) {
+			t.Errorf("UnmarshalBinary(%x) = %v, %v, want %v, <nil>", b, got, err, tc.s)
+		}
+	}
+
+	s := new(RangeSet)
+	if err := s.UnmarshalText([]byte("2024-05-10,2024-05-01/2024-05-10,2024-05-05")); err != nil || !s.Equal(NewRangeSet(Range{d, d + 10})) {
+		t.Errorf("UnmarshalText of unordered, overlapping elements = %v, %v, want %v, <nil>", s, err, Range{d, d + 10})
+	}
+	for _, text := range []string{",", "2024-05-01,", "2024-05-01/", "2024-05-32"} {
+		if err := s.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) = <nil>, want error", text)
+		}
+	}
+	// Truncated, empty range, adjacent ranges, overflow.
+	for _, b := range [][]byte{{0x02}, {0x02, 0x00}, {0x02, 0x01, 0x00, 0x01}, {0x02, 0x01, 0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}} {
+		if err := s.UnmarshalBinary(b); err == nil {
+			t.Errorf("UnmarshalBinary(%x) = <nil>, want error", b)
+		}
+	}
+}
@@ -0,0 +1,442 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Frequency is the unit of the periods in which a [Recurrence] repeats.
+type Frequency int
+
+// Frequencies of a [Recurrence].
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = [...]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+// String returns the name of f used by iCalendar, like "WEEKLY".
+func (f Frequency) String() string {
+	if f <= 0 || int(f) >= len(frequencyNames) {
+		return "Frequency(" + strconv.Itoa(int(f)) + ")"
+	}
+	return frequencyNames[f]
+}
+
+// An NthWeekday is an occurrence of a weekday, like the second Tuesday. N
+// counts from the start of a month or year if it is positive and from the end
+// if it is negative. If N is zero, every occurrence of the weekday is meant.
+type NthWeekday struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// A Recurrence repeats a date in regular periods, like "every other week on
+// Monday and Friday" or "the last Friday of every month". It implements the
+// date-related subset of the RRULE property of iCalendar (RFC 5545), which can
+// be converted with [ParseRRule] and [Recurrence.RRule].
+//
+// The periods are counted from Start, so with an Interval of 2 and a Weekly
+// Frequency, the dates are in the week of Start, two weeks later and so on.
+// Within each period, the dates are chosen by the By fields:
+//
+//   - ByMonth limits the dates to the given months. For a Yearly Frequency,
+//     it selects the months of the year to use.
+//   - ByMonthDay selects days of the month, counting from the end if they
+//     are negative. It can not be used with a Weekly Frequency.
+//   - ByDay selects days of the week. For a Monthly Frequency, or a Yearly
+//     Frequency with ByMonth, N counts within the month. For a Yearly
+//     Frequency without ByMonth, N counts within the year. If ByMonthDay
+//     is set as well, ByDay only limits the selected days.
+//
+// If none of them select days, the day of the month, the day of the week or
+// the month and day of Start is used, according to the Frequency. Dates which
+// do not exist, like February 30, are skipped.
+type Recurrence struct {
+	Freq Frequency
+
+	// Start is the first date of the recurrence. It corresponds to the
+	// DTSTART property. Start is only an occurrence itself, if it matches
+	// the rule.
+	Start Date
+
+	// Interval is the number of periods between repetitions. Values less
+	// than one are treated as one.
+	Interval int
+
+	ByMonth    []time.Month
+	ByMonthDay []int
+	ByDay      []NthWeekday
+
+	// Count limits the number of occurrences, if it is positive.
+	Count int
+
+	// Until is the last date on which the recurrence can occur, if it is
+	// not zero.
+	Until Date
+}
+
+// Occurrences returns an iterator over the dates on or after from on which rc
+// occurs, in ascending order. The iterator stops after Count occurrences,
+// counted from Start, after Until or if rc occurs on no date in 400 years of
+// consecutive periods.
+func (rc *Recurrence) Occurrences(from Date) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		if rc.Freq <= 0 || rc.Freq > Yearly {
+			return
+		}
+		iv := max(rc.Interval, 1)
+		i := 0
+		if rc.Count <= 0 && from > rc.Start {
+			// Without a Count, the periods before from can be skipped.
+			i = rc.periodsBetween(from) / iv
+		}
+		n, last := 0, rc.periodStart(i)
+		for ; ; i++ {
+			ps := rc.periodStart(i)
+			// The Gregorian calendar repeats every 400 years, so does
+			// the pattern of periods, after at most iv repetitions.
+			if ps-last > Date(iv)*146097 || (rc.Until != 0 && ps > rc.Until) {
+				return
+			}
+			for _, d := range rc.periodDates(ps) {
+				if d < rc.Start {
+					continue
+				}
+				if rc.Until != 0 && d > rc.Until {
+					return
+				}
+				last = d
+				if n++; d >= from && !yield(d) {
+					return
+				}
+				if rc.Count > 0 && n >= rc.Count {
+					return
+				}
+			}
+		}
+	}
+}
+
+// NextAfter returns the first date after d on which rc occurs. It returns
+// false, if there is no such date.
+func (rc *Recurrence) NextAfter(d Date) (Date, bool) {
+	for o := range rc.Occurrences(d + 1) {
+		return o, true
+	}
+	return 0, false
+}
+
+// periodsBetween returns the number of periods of length one between the
+// period containing Start and the one containing d.
+func (rc *Recurrence) periodsBetween(d Date) int {
+	switch rc.Freq {
+	case Daily:
+		return int(d - rc.Start)
+	case Weekly:
+		return int(d.ISOWeekStart()-rc.Start.ISOWeekStart()) / 7
+	case Monthly:
+		return (d.Year()-rc.Start.Year())*12 + int(d.Month()-rc.Start.Month())
+	default:
+		return d.Year() - rc.Start.Year()
+	}
+}
+
+// periodStart returns the first date of the i'th period.
+func (rc *Recurrence) periodStart(i int) Date {
+	i *= max(rc.Interval, 1)
+	switch rc.Freq {
+	case Daily:
+		return rc.Start + Date(i)
+	case Weekly:
+		return rc.Start.ISOWeekStart() + Date(7*i)
+	case Monthly:
+		return Of(rc.Start.Year(), rc.Start.Month()+time.Month(i), 1)
+	default:
+		return Of(rc.Start.Year()+i, time.January, 1)
+	}
+}
+
+// periodDates returns the sorted dates of the period starting at ps, on which
+// rc occurs.
+func (rc *Recurrence) periodDates(ps Date) []Date {
+	var ds []Date
+	switch rc.Freq {
+	case Daily:
+		if rc.matchMonthDay(ps) && rc.matchDay(ps, Range{ps, ps + 1}) {
+			ds = append(ds, ps)
+		}
+	case Weekly:
+		r := Range{ps, ps + 7}
+		if len(rc.ByDay) == 0 {
+			ds = append(ds, ps+Date(isoWeekday(rc.Start.Weekday())-1))
+			break
+		}
+		for d := range r.Dates() {
+			if rc.matchDay(d, r) {
+				ds = append(ds, d)
+			}
+		}
+	case Monthly:
+		ds = rc.monthDates(MonthRange(ps.Year(), ps.Month()), MonthRange(ps.Year(), ps.Month()))
+	default:
+		year := ps.Year()
+		switch {
+		case len(rc.ByMonth) > 0:
+			for _, m := range rc.ByMonth {
+				ds = append(ds, rc.monthDates(MonthRange(year, m), MonthRange(year, m))...)
+			}
+		case len(rc.ByMonthDay) > 0:
+			for m := time.January; m <= time.December; m++ {
+				ds = append(ds, rc.monthDates(MonthRange(year, m), YearRange(year))...)
+			}
+		case len(rc.ByDay) > 0:
+			ds = rc.monthDates(YearRange(year), YearRange(year))
+		default:
+			if d := Of(year, rc.Start.Month(), rc.Start.Day()); d.Day() == rc.Start.Day() {
+				ds = append(ds, d)
+			}
+		}
+	}
+	ds = slices.DeleteFunc(ds, func(d Date) bool {
+		return len(rc.ByMonth) > 0 && !slices.Contains(rc.ByMonth, d.Month())
+	})
+	slices.Sort(ds)
+	return slices.Compact(ds)
+}
+
+// monthDates returns the dates in r selected by ByMonthDay and ByDay, or the
+// day of the month of Start, if neither is set. r is a month, unless only ByDay
+// is set. Occurrences of weekdays are counted in scope.
+func (rc *Recurrence) monthDates(r, scope Range) []Date {
+	var ds []Date
+	switch {
+	case len(rc.ByMonthDay) > 0 || len(rc.ByDay) > 0:
+		for d := range r.Dates() {
+			if rc.matchMonthDay(d) && rc.matchDay(d, scope) {
+				ds = append(ds, d)
+			}
+		}
+	default:
+		if d := r.Start + Date(rc.Start.Day()-1); r.Contains(d) {
+			ds = append(ds, d)
+		}
+	}
+	return ds
+}
+
+// matchMonthDay reports whether d is selected by ByMonthDay, which is true if
+// it is empty.
+func (rc *Recurrence) matchMonthDay(d Date) bool {
+	if len(rc.ByMonthDay) == 0 {
+		return true
+	}
+	year, month, day := d.Date()
+	n := daysIn(month, year)
+	for _, md := range rc.ByMonthDay {
+		if md == day || md == day-n-1 {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDay reports whether d is selected by ByDay, which is true if it is
+// empty. Occurrences of weekdays are counted in scope.
+func (rc *Recurrence) matchDay(d Date, scope Range) bool {
+	if len(rc.ByDay) == 0 {
+		return true
+	}
+	wd := d.Weekday()
+	for _, nw := range rc.ByDay {
+		if nw.Weekday != wd {
+			continue
+		}
+		if nw.N == 0 || nw.N == int(d-scope.Start)/7+1 || nw.N == -(int(scope.End-1-d)/7+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// iCalendar abbreviations of the days of the week.
+var icalWeekdays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ParseRRule parses the value of an iCalendar RRULE property, like
+// "FREQ=MONTHLY;BYDAY=-1FR", into a Recurrence starting at start. The prefix
+// "RRULE:" is optional.
+//
+// The supported rule parts are FREQ with the values DAILY, WEEKLY, MONTHLY and
+// YEARLY, INTERVAL, COUNT, UNTIL, BYMONTH, BYMONTHDAY and BYDAY. UNTIL can be
+// a DATE or a DATE-TIME value, of which the date is used. WKST is accepted,
+// if it is MO, as weeks always start on Monday.
+func ParseRRule(s string, start Date) (*Recurrence, error) {
+	err := func(format string, args ...any) error {
+		return fmt.Errorf("invalid RRULE %q: %s", s, fmt.Sprintf(format, args...))
+	}
+	rc := &Recurrence{Start: start}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(strings.TrimPrefix(s, "RRULE:"), ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || value == "" {
+			return nil, err("missing value of %q", part)
+		}
+		if seen[name] {
+			return nil, err("duplicate %s", name)
+		}
+		seen[name] = true
+		var e error
+		switch name {
+		case "FREQ":
+			i := slices.Index(frequencyNames[:], strings.ToUpper(value))
+			if i <= 0 {
+				return nil, err("unsupported frequency %q", value)
+			}
+			rc.Freq = Frequency(i)
+		case "INTERVAL":
+			rc.Interval, e = strconv.Atoi(value)
+			if e != nil || rc.Interval < 1 {
+				return nil, err("invalid interval %q", value)
+			}
+		case "COUNT":
+			rc.Count, e = strconv.Atoi(value)
+			if e != nil || rc.Count < 1 {
+				return nil, err("invalid count %q", value)
+			}
+		case "UNTIL":
+			if len(value) > 8 && value[8] == 'T' {
+				value = value[:8]
+			}
+			if rc.Until, e = ParseICal(value); e != nil {
+				return nil, err("invalid until %q", value)
+			}
+		case "BYMONTH":
+			for _, f := range strings.Split(value, ",") {
+				m, e := strconv.Atoi(f)
+				if e != nil || m < 1 || m > 12 {
+					return nil, err("invalid month %q", f)
+				}
+				rc.ByMonth = append(rc.ByMonth, time.Month(m))
+			}
+		case "BYMONTHDAY":
+			for _, f := range strings.Split(value, ",") {
+				md, e := strconv.Atoi(f)
+				if e != nil || md == 0 || md < -31 || md > 31 {
+					return nil, err("invalid day of month %q", f)
+				}
+				rc.ByMonthDay = append(rc.ByMonthDay, md)
+			}
+		case "BYDAY":
+			for _, f := range strings.Split(value, ",") {
+				nw, ok := parseNthWeekday(f)
+				if !ok {
+					return nil, err("invalid day %q", f)
+				}
+				rc.ByDay = append(rc.ByDay, nw)
+			}
+		case "WKST":
+			if !strings.EqualFold(value, "MO") {
+				return nil, err("unsupported week start %q", value)
+			}
+		default:
+			return nil, err("unsupported rule part %s", name)
+		}
+	}
+	if rc.Freq == 0 {
+		return nil, err("missing FREQ")
+	}
+	if rc.Count > 0 && rc.Until != 0 {
+		return nil, err("both COUNT and UNTIL given")
+	}
+	if rc.Freq == Weekly && len(rc.ByMonthDay) > 0 {
+		return nil, err("BYMONTHDAY used with WEEKLY frequency")
+	}
+	for _, nw := range rc.ByDay {
+		if nw.N != 0 && (rc.Freq < Monthly || rc.Freq == Yearly && len(rc.ByMonthDay) > 0) {
+			return nil, err("numeric BYDAY used with %v frequency", rc.Freq)
+		}
+		if nw.N < -53 || nw.N > 53 || rc.Freq == Monthly && (nw.N < -5 || nw.N > 5) {
+			return nil, err("BYDAY occurrence %d out of range", nw.N)
+		}
+	}
+	return rc, nil
+}
+
+// parseNthWeekday parses an element of a BYDAY rule part, like "-1FR".
+func parseNthWeekday(s string) (NthWeekday, bool) {
+	if len(s) < 2 {
+		return NthWeekday{}, false
+	}
+	wd := slices.Index(icalWeekdays[:], strings.ToUpper(s[len(s)-2:]))
+	if wd < 0 {
+		return NthWeekday{}, false
+	}
+	nw := NthWeekday{Weekday: time.Weekday(wd)}
+	if s = s[:len(s)-2]; s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n == 0 {
+			return NthWeekday{}, false
+		}
+		nw.N = n
+	}
+	return nw, true
+}
+
+// RRule returns the value of an iCalendar RRULE property describing rc, like
+// "FREQ=MONTHLY;BYDAY=-1FR". Start is not included, as it corresponds to the
+// DTSTART property.
+func (rc *Recurrence) RRule() string {
+	b := append([]byte("FREQ="), rc.Freq.String()...)
+	if rc.Interval > 1 {
+		b = strconv.AppendInt(append(b, ";INTERVAL="...), int64(rc.Interval), 10)
+	}
+	if rc.Count > 0 {
+		b = strconv.AppendInt(append(b, ";COUNT="...), int64(rc.Count), 10)
+	}
+	if rc.Until != 0 {
+		b = rc.Until.AppendFormat(append(b, ";UNTIL="...), ISOBasic)
+	}
+	for i, m := range rc.ByMonth {
+		b = appendListItem(b, "BYMONTH", i)
+		b = strconv.AppendInt(b, int64(m), 10)
+	}
+	for i, md := range rc.ByMonthDay {
+		b = appendListItem(b, "BYMONTHDAY", i)
+		b = strconv.AppendInt(b, int64(md), 10)
+	}
+	for i, nw := range rc.ByDay {
+		b = appendListItem(b, "BYDAY", i)
+		if nw.N != 0 {
+			b = strconv.AppendInt(b, int64(nw.N), 10)
+		}
+		b = append(b, icalWeekdays[nw.Weekday%7]...)
+	}
+	return string(b)
+}
+
+// appendListItem appends the separator before the i'th value of the rule part
+// with the given name to b.
+func appendListItem(b []byte, name string, i int) []byte {
+	if i > 0 {
+		return append(b, ',')
+	}
+	return append(append(append(b, ';'), name...), '=')
+}
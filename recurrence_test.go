@@ -0,0 +1,102 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRecurrence(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		rule  string
+		start Date
+		from  Date
+		want  []Date
+	}{
+		{"FREQ=DAILY;COUNT=3", Of(2024, 5, 14), 0, []Date{Of(2024, 5, 14), Of(2024, 5, 15), Of(2024, 5, 16)}},
+		{"FREQ=DAILY;UNTIL=20240103", Of(2024, 1, 1), 0, []Date{Of(2024, 1, 1), Of(2024, 1, 2), Of(2024, 1, 3)}},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", Of(2024, 5, 14), 0, []Date{Of(2024, 5, 17), Of(2024, 5, 27), Of(2024, 5, 31), Of(2024, 6, 10), Of(2024, 6, 14)}},
+		{"FREQ=WEEKLY;INTERVAL=2", Of(2024, 5, 14), Of(2024, 6, 1), []Date{Of(2024, 6, 11), Of(2024, 6, 25), Of(2024, 7, 9), Of(2024, 7, 23), Of(2024, 8, 6)}},
+		{"FREQ=MONTHLY;COUNT=3;BYDAY=-1FR", Of(2024, 1, 1), 0, []Date{Of(2024, 1, 26), Of(2024, 2, 23), Of(2024, 3, 29)}},
+		{"FREQ=MONTHLY;COUNT=3", Of(2024, 1, 15), Of(2024, 2, 1), []Date{Of(2024, 2, 15), Of(2024, 3, 15)}},
+		{"FREQ=MONTHLY", Of(2024, 1, 31), 0, []Date{Of(2024, 1, 31), Of(2024, 3, 31), Of(2024, 5, 31), Of(2024, 7, 31), Of(2024, 8, 31)}},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1", Of(2024, 2, 1), 0, []Date{Of(2024, 2, 1), Of(2024, 2, 29), Of(2024, 3, 1), Of(2024, 3, 31), Of(2024, 4, 1)}},
+		{"FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR", Of(2024, 1, 1), 0, []Date{Of(2024, 9, 13), Of(2024, 12, 13), Of(2025, 6, 13), Of(2026, 2, 13), Of(2026, 3, 13)}},
+		{"FREQ=YEARLY", Of(2024, 2, 29), 0, []Date{Of(2024, 2, 29), Of(2028, 2, 29), Of(2032, 2, 29), Of(2036, 2, 29), Of(2040, 2, 29)}},
+		{"FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", Of(2024, 1, 1), 0, []Date{Of(2024, 11, 28), Of(2025, 11, 27), Of(2026, 11, 26), Of(2027, 11, 25), Of(2028, 11, 23)}},
+		{"FREQ=YEARLY;BYDAY=1MO", Of(2024, 1, 1), 0, []Date{Of(2024, 1, 1), Of(2025, 1, 6), Of(2026, 1, 5), Of(2027, 1, 4), Of(2028, 1, 3)}},
+		{"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", Of(2024, 1, 1), 0, nil},
+	}
+	for _, tc := range tcs {
+		rc, err := ParseRRule(tc.rule, tc.start)
+		if err != nil {
+			t.Errorf("ParseRRule(%q, %v) = _, %v, want <nil>", tc.rule, tc.start, err)
+			continue
+		}
+		if got := rc.RRule(); got != tc.rule {
+			t.Errorf("ParseRRule(%q, %v).RRule() = %q, want %q", tc.rule, tc.start, got, tc.rule)
+		}
+		var got []Date
+		for d := range rc.Occurrences(tc.from) {
+			if got = append(got, d); len(got) == 5 {
+				break
+			}
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%q.Occurrences(%v) = %v, want %v", tc.rule, tc.from, got, tc.want)
+		}
+	}
+}
+
+func TestRecurrenceNextAfter(t *testing.T) {
+	t.Parallel()
+	rc := &Recurrence{Freq: Yearly, Start: Of(2024, 1, 1), ByMonth: []time.Month{time.November}, ByDay: []NthWeekday{{4, time.Thursday}}}
+	if got, ok := rc.NextAfter(Of(2024, 11, 28)); !ok || got != Of(2025, 11, 27) {
+		t.Errorf("NextAfter(%v) = %v, %v, want %v, true", Of(2024, 11, 28), got, ok, Of(2025, 11, 27))
+	}
+	rc = &Recurrence{Freq: Daily, Start: Of(2024, 1, 1), Count: 2}
+	if got, ok := rc.NextAfter(Of(2024, 1, 2)); ok {
+		t.Errorf("NextAfter(%v) = %v, true, want false", Of(2024, 1, 2), got)
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	t.Parallel()
+	rc, err := ParseRRule("RRULE:freq=weekly;until=20240103T120000Z;wkst=MO;byday=tu", Of(2024, 1, 1))
+	if err != nil {
+		t.Fatalf("ParseRRule = _, %v, want <nil>", err)
+	}
+	if got, want := rc.RRule(), "FREQ=WEEKLY;UNTIL=20240103;BYDAY=TU"; got != want {
+		t.Errorf("RRule() = %q, want %q", got, want)
+	}
+
+	errs := []string{
+		"",
+		"FREQ=HOURLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;FREQ=DAILY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20240101",
+		"FREQ=DAILY;UNTIL=2024",
+		"FREQ=DAILY;BYMONTH=13",
+		"FREQ=DAILY;BYMONTHDAY=0",
+		"FREQ=DAILY;BYDAY=XX",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=DAILY;WKST=SU",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYDAY=0MO",
+	}
+	for _, s := range errs {
+		if _, err := ParseRRule(s, Of(2024, 1, 1)); err == nil {
+			t.Errorf("ParseRRule(%q) = _, <nil>, want error", s)
+		}
+	}
+}
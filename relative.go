@@ -0,0 +1,349 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A RelativeGrammar describes the vocabulary used by [RelativeGrammar.Parse]
+// to interpret natural-language date expressions.
+//
+// Every field lists the alternative spellings of a word or phrase. Phrases
+// consisting of multiple words are matched word by word, with any amount of
+// white space between them. All matching is case-insensitive.
+//
+// To support another language, create a new RelativeGrammar with the
+// appropriate vocabulary. See [English] for an example.
+type RelativeGrammar struct {
+	// Today, Tomorrow and Yesterday are the words for the base date and the
+	// days immediately after and before it.
+	Today, Tomorrow, Yesterday []string
+
+	// Next, Last and This select the next, previous or current weekday or
+	// period, as in "next Tuesday" or "last month".
+	Next, Last, This []string
+
+	// In precedes and Ago follows an amount of time, as in "in 3 weeks" or
+	// "3 weeks ago".
+	In, Ago []string
+
+	// FirstDayOf and LastDayOf precede a month or year, as in "first day of
+	// next month".
+	FirstDayOf, LastDayOf []string
+
+	// Days, Weeks, Months and Years are the units of time, in singular and
+	// plural.
+	Days, Weeks, Months, Years []string
+
+	// Weekdays are the names of the days of the week, indexed by
+	// [time.Weekday].
+	Weekdays [7][]string
+
+	// Numbers maps number words to their values, as in "in a week". Numbers
+	// written as decimal digits are always accepted.
+	Numbers map[string]int
+
+	// Noise are words which are ignored, like "the" in "last day of the
+	// month".
+	Noise []string
+}
+
+// English is the [RelativeGrammar] used by [ParseRelative].
+var English = &RelativeGrammar{
+	Today:      []string{"today", "now"},
+	Tomorrow:   []string{"tomorrow"},
+	Yesterday:  []string{"yesterday"},
+	Next:       []string{"next"},
+	Last:       []string{"last", "previous"},
+	This:       []string{"this", "current"},
+	In:         []string{"in"},
+	Ago:        []string{"ago"},
+	FirstDayOf: []string{"first day of", "start of", "beginning of"},
+	LastDayOf:  []string{"last day of", "end of"},
+	Days:       []string{"day", "days"},
+	Weeks:      []string{"week", "weeks"},
+	Months:     []string{"month", "months"},
+	Years:      []string{"year", "years"},
+	Weekdays: [7][]string{
+		{"sunday", "sun"},
+		{"monday", "mon"},
+		{"tuesday", "tue", "tues"},
+		{"wednesday", "wed"},
+		{"thursday", "thu", "thurs"},
+		{"friday", "fri"},
+		{"saturday", "sat"},
+	},
+	Numbers: map[string]int{
+		"a":     1,
+		"an":    1,
+		"one":   1,
+		"two":   2,
+		"three": 3,
+		"four":  4,
+		"five":  5,
+		"six":   6,
+		"seven": 7,
+		"eight": 8,
+		"nine":  9,
+		"ten":   10,
+	},
+	Noise: []string{"the", "of"},
+}
+
+// ParseRelative parses a natural-language date expression relative to base,
+// using the [English] grammar. See [RelativeGrammar.Parse] for details.
+func ParseRelative(s string, base Date) (Date, error) {
+	return English.Parse(s, base)
+}
+
+// Parse parses a natural-language date expression relative to base. The
+// following forms are understood, shown here in English:
+//
+//	today, tomorrow, yesterday
+//	next Tuesday, last Tuesday, this Tuesday
+//	next week, last month, this year
+//	in 3 days, in a week, in 2 months, in 1 year
+//	3 days ago, a week ago, 2 months ago, 1 year ago
+//	first day of next month, last day of the year
+//
+// "next Tuesday" is the first Tuesday after base and "last Tuesday" is the
+// last Tuesday before it. "this Tuesday" is base, if it is a Tuesday, and
+// "next Tuesday" otherwise.
+//
+// Adding months or years to a date keeps the day of the month, unless the
+// resulting month is too short, in which case its last day is used. So one
+// month after January 31st is the last day of February.
+//
+// As a fallback, a date in [RFC3339] format is accepted as well.
+//
+// If the resulting date is outside of [MinDate]…[MaxDate], the returned
+// [*ParseError] wraps [ErrRange].
+func (g *RelativeGrammar) Parse(s string, base Date) (Date, error) {
+	if d, err := Parse(RFC3339, strings.TrimSpace(s)); err == nil {
+		return d, nil
+	}
+	words := g.words(s)
+	d, rest, ok := g.parse(words, base)
+	if !ok || len(rest) > 0 {
+		return 0, &ParseError{
+			Value:   s,
+			Message: "unknown relative date expression",
+			Err:     ErrSyntax,
+		}
+	}
+	if !d.IsValid() {
+		return 0, &ParseError{Value: s, Message: "date out of range", Err: ErrRange}
+	}
+	return d, nil
+}
+
+// words splits s into lower-case words, omitting noise words.
+func (g *RelativeGrammar) words(s string) []string {
+	var words []string
+	for _, w := range strings.Fields(strings.ToLower(s)) {
+		if !g.isNoise(w) {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+func (g *RelativeGrammar) isNoise(w string) bool {
+	for _, n := range g.Noise {
+		if strings.EqualFold(w, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// match checks whether words start with one of the given phrases and returns
+// the remaining words.
+func (g *RelativeGrammar) match(words []string, phrases []string) (rest []string, ok bool) {
+outer:
+	for _, p := range phrases {
+		pw := g.words(p)
+		if len(pw) == 0 || len(words) < len(pw) {
+			continue
+		}
+		for i, w := range pw {
+			if words[i] != w {
+				continue outer
+			}
+		}
+		return words[len(pw):], true
+	}
+	return words, false
+}
+
+func (g *RelativeGrammar) unit(words []string) (Unit, []string, bool) {
+	units := [...]struct {
+		u       Unit
+		phrases []string
+	}{
+		{Days, g.Days},
+		{Weeks, g.Weeks},
+		{Months, g.Months},
+		{Years, g.Years},
+	}
+	for _, u := range units {
+		if rest, ok := g.match(words, u.phrases); ok {
+			return u.u, rest, true
+		}
+	}
+	return 0, words, false
+}
+
+func (g *RelativeGrammar) weekday(words []string) (time.Weekday, []string, bool) {
+	for wd, phrases := range g.Weekdays {
+		if rest, ok := g.match(words, phrases); ok {
+			return time.Weekday(wd), rest, true
+		}
+	}
+	return 0, words, false
+}
+
+// maxAmount is larger than the number of days between MinDate and MaxDate.
+// Larger amounts of time are clamped to it, as adding them to a valid date
+// leaves the range of valid dates either way, but could overflow.
+const maxAmount = int(MaxDate-MinDate) + 1
+
+func (g *RelativeGrammar) number(words []string) (int, []string, bool) {
+	if len(words) == 0 {
+		return 0, words, false
+	}
+	n, err := strconv.Atoi(words[0])
+	if errors.Is(err, strconv.ErrRange) && n > 0 {
+		err = nil
+	}
+	if err == nil && n >= 0 {
+		return min(n, maxAmount), words[1:], true
+	}
+	for w, n := range g.Numbers {
+		if strings.EqualFold(words[0], w) {
+			return n, words[1:], true
+		}
+	}
+	return 0, words, false
+}
+
+// direction parses one of Next, Last or This, returning +1, -1 or 0
+// respectively.
+func (g *RelativeGrammar) direction(words []string) (int, []string, bool) {
+	if rest, ok := g.match(words, g.Next); ok {
+		return 1, rest, true
+	}
+	if rest, ok := g.match(words, g.Last); ok {
+		return -1, rest, true
+	}
+	if rest, ok := g.match(words, g.This); ok {
+		return 0, rest, true
+	}
+	return 0, words, false
+}
+
+func (g *RelativeGrammar) parse(words []string, base Date) (Date, []string, bool) {
+	if rest, ok := g.match(words, g.Today); ok {
+		return base, rest, true
+	}
+	if rest, ok := g.match(words, g.Tomorrow); ok {
+		return base + 1, rest, true
+	}
+	if rest, ok := g.match(words, g.Yesterday); ok {
+		return base - 1, rest, true
+	}
+	// "last day of" has to be checked before "last".
+	if rest, ok := g.match(words, g.FirstDayOf); ok {
+		return g.period(rest, base, false)
+	}
+	if rest, ok := g.match(words, g.LastDayOf); ok {
+		return g.period(rest, base, true)
+	}
+	if dir, rest, ok := g.direction(words); ok {
+		if wd, rest, ok := g.weekday(rest); ok {
+			return relWeekday(base, wd, dir), rest, true
+		}
+		if u, rest, ok := g.unit(rest); ok {
+			return u.add(base, dir), rest, true
+		}
+		return 0, words, false
+	}
+	if rest, ok := g.match(words, g.In); ok {
+		n, rest, ok := g.number(rest)
+		if !ok {
+			return 0, words, false
+		}
+		u, rest, ok := g.unit(rest)
+		if !ok {
+			return 0, words, false
+		}
+		return u.add(base, n), rest, true
+	}
+	if n, rest, ok := g.number(words); ok {
+		u, rest, ok := g.unit(rest)
+		if !ok {
+			return 0, words, false
+		}
+		if rest, ok = g.match(rest, g.Ago); !ok {
+			return 0, words, false
+		}
+		return u.add(base, -n), rest, true
+	}
+	return 0, words, false
+}
+
+// period parses an optional direction followed by a month or year and returns
+// its first or last day.
+func (g *RelativeGrammar) period(words []string, base Date, last bool) (Date, []string, bool) {
+	dir, rest, _ := g.direction(words)
+	u, rest, ok := g.unit(rest)
+	if !ok || (u != Months && u != Years) {
+		return 0, words, false
+	}
+	year, month, _ := base.Date()
+	if u == Years {
+		month = time.January
+		dir *= 12
+	}
+	first := Of(year, month+time.Month(dir), 1)
+	if !last {
+		return first, rest, true
+	}
+	if u == Years {
+		return first.AddDate(1, 0, -1), rest, true
+	}
+	return first.AddDate(0, 1, -1), rest, true
+}
+
+// relWeekday returns the next (dir > 0), last (dir < 0) or current (dir == 0)
+// occurrence of wd relative to base.
+func relWeekday(base Date, wd time.Weekday, dir int) Date {
+	delta := int(wd-base.Weekday()+7) % 7
+	switch {
+	case dir < 0:
+		return base + Date(delta) - 7
+	case dir > 0 && delta == 0:
+		return base + 7
+	default:
+		return base + Date(delta)
+	}
+}
+
+// addMonthsClamped adds n months to d. If the resulting month has fewer days
+// than the day of the month of d, the last day of that month is used instead.
+func (d Date) addMonthsClamped(n int) Date {
+	year, month, day := d.Date()
+	first := Of(year, month+time.Month(n), 1)
+	year, month, _ = first.Date()
+	if max := daysIn(month, year); day > max {
+		day = max
+	}
+	return first + Date(day-1)
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRelative(t *testing.T) {
+	t.Parallel()
+	base := Of(2024, 5, 14) // a Tuesday
+	tcs := []struct {
+		value string
+		want  Date
+		err   bool
+	}{
+		{"today", base, false},
+		{"  Today ", base, false},
+		{"tomorrow", Of(2024, 5, 15), false},
+		{"yesterday", Of(2024, 5, 13), false},
+		{"next Tuesday", Of(2024, 5, 21), false},
+		{"next wednesday", Of(2024, 5, 15), false},
+		{"next mon", Of(2024, 5, 20), false},
+		{"last Tuesday", Of(2024, 5, 7), false},
+		{"last Monday", Of(2024, 5, 13), false},
+		{"last Wednesday", Of(2024, 5, 8), false},
+		{"this Tuesday", base, false},
+		{"this Friday", Of(2024, 5, 17), false},
+		{"next week", Of(2024, 5, 21), false},
+		{"last month", Of(2024, 4, 14), false},
+		{"next year", Of(2025, 5, 14), false},
+		{"in 3 days", Of(2024, 5, 17), false},
+		{"in 3 weeks", Of(2024, 6, 4), false},
+		{"in a week", Of(2024, 5, 21), false},
+		{"in two months", Of(2024, 7, 14), false},
+		{"in 1 year", Of(2025, 5, 14), false},
+		{"3 days ago", Of(2024, 5, 11), false},
+		{"a week ago", Of(2024, 5, 7), false},
+		{"14 months ago", Of(2023, 3, 14), false},
+		{"last day of month", Of(2024, 5, 31), false},
+		{"last day of the month", Of(2024, 5, 31), false},
+		{"last day of next month", Of(2024, 6, 30), false},
+		{"first day of next month", Of(2024, 6, 1), false},
+		{"first day of last month", Of(2024, 4, 1), false},
+		{"first day of the year", Of(2024, 1, 1), false},
+		{"last day of last year", Of(2023, 12, 31), false},
+		{"end of next year", Of(2025, 12, 31), false},
+		{"2023-10-25", Of(2023, 10, 25), false},
+		{"", 0, true},
+		{"next", 0, true},
+		{"in days", 0, true},
+		{"3 days", 0, true},
+		{"today tomorrow", 0, true},
+		{"first day of next week", 0, true},
+		{"the day after tomorrow", 0, true},
+	}
+	for _, tc := range tcs {
+		got, err := ParseRelative(tc.value, base)
+		if (err != nil) != tc.err {
+			t.Errorf("ParseRelative(%q, %v) = _, %v, want error: %v", tc.value, base, err, tc.err)
+			continue
+		}
+		if err == nil && got != tc.want {
+			t.Errorf("ParseRelative(%q, %v) = %v, want %v", tc.value, base, got, tc.want)
+		}
+	}
+}
+
+func TestParseRelativeRange(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		value string
+		base  Date
+	}{
+		{"in 999999999999999 days", Of(2024, 5, 14)},
+		{"99999999999999 weeks ago", Of(2024, 5, 14)},
+		{"in 99999999999999 months", Of(2024, 5, 14)},
+		{"in 99999999999999999999 years", Of(2024, 5, 14)},
+		{"in 99999999999999999999 days", MinDate},
+		{"tomorrow", MaxDate},
+		{"yesterday", MinDate},
+		{"next month", MaxDate},
+	}
+	for _, tc := range tcs {
+		if got, err := ParseRelative(tc.value, tc.base); !errors.Is(err, ErrRange) {
+			t.Errorf("ParseRelative(%q, %v) = %v, %v, want %v", tc.value, tc.base, got, err, ErrRange)
+		}
+	}
+	if got, err := ParseRelative("in 10 days", MaxDate-10); err != nil || got != MaxDate {
+		t.Errorf("ParseRelative(%q, %v) = %v, %v, want %v, <nil>", "in 10 days", MaxDate-10, got, err, MaxDate)
+	}
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d    Date
+		n    int
+		want Date
+	}{
+		{Of(2024, 1, 31), 1, Of(2024, 2, 29)},
+		{Of(2023, 1, 31), 1, Of(2023, 2, 28)},
+		{Of(2024, 3, 31), -1, Of(2024, 2, 29)},
+		{Of(2024, 2, 29), 12, Of(2025, 2, 28)},
+		{Of(2024, 5, 14), -17, Of(2022, 12, 14)},
+		{Of(2024, 5, 31), 0, Of(2024, 5, 31)},
+	}
+	for _, tc := range tcs {
+		if got := tc.d.addMonthsClamped(tc.n); got != tc.want {
+			t.Errorf("%v.addMonthsClamped(%d) = %v, want %v", tc.d, tc.n, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "iter"
+
+// A Rotation is a pattern of days repeating indefinitely in both directions
+// from an anchor date, like a rotating shift pattern. Every day of the pattern
+// has a label, like "early", "late" or "night". Days with an empty label are
+// off.
+//
+// For example, a pattern of two early shifts, two night shifts and three days
+// off, starting on May 6th 2024:
+//
+//	r := &Rotation{
+//		Anchor:  date.Of(2024, 5, 6),
+//		Pattern: []string{"early", "early", "night", "night", "", "", ""},
+//	}
+type Rotation struct {
+	// Anchor is a date on which the first day of Pattern falls.
+	Anchor Date
+
+	// Pattern are the labels of the days of the cycle. If it is empty,
+	// every day is off.
+	Pattern []string
+}
+
+// NewOnOffRotation returns a Rotation of on days on, labeled "on", followed by
+// off days off, starting at anchor. For example, a "four on, four off" pattern
+// is NewOnOffRotation(anchor, 4, 4).
+func NewOnOffRotation(anchor Date, on, off int) *Rotation {
+	p := make([]string, on+off)
+	for i := range on {
+		p[i] = "on"
+	}
+	return &Rotation{Anchor: anchor, Pattern: p}
+}
+
+// LabelFor returns the label of d in r. Dates before the anchor continue the
+// pattern backwards.
+func (r *Rotation) LabelFor(d Date) string {
+	if len(r.Pattern) == 0 {
+		return ""
+	}
+	return r.Pattern[CycleDay(d, r.Anchor, len(r.Pattern))-1]
+}
+
+// On reports whether d has a non-empty label in r.
+func (r *Rotation) On(d Date) bool {
+	return r.LabelFor(d) != ""
+}
+
+// Occurrences returns an iterator over the dates in rng which are on in r, in
+// ascending order, with their labels.
+func (r *Rotation) Occurrences(rng Range) iter.Seq2[Date, string] {
+	return func(yield func(Date, string) bool) {
+		for d := range rng.Dates() {
+			if l := r.LabelFor(d); l != "" && !yield(d, l) {
+				return
+			}
+		}
+	}
+}
+
+// CycleDay returns the position of d in a cycle of length days, which starts
+// at anchor and repeats indefinitely in both directions. The position is
+// 1-based, so anchor is day 1 and the day before anchor is day length. It
+// panics, if length is not positive.
+//
+// For example, for a 28-day medication cycle started on May 6th:
+//
+//	day := date.CycleDay(today, date.Of(2024, 5, 6), 28)
+func CycleDay(d, anchor Date, length int) int {
+	if length <= 0 {
+		panic("date: non-positive cycle length")
+	}
+	n := (d - anchor) % Date(length)
+	if n < 0 {
+		n += Date(length)
+	}
+	return int(n) + 1
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRotation(t *testing.T) {
+	t.Parallel()
+	anchor := Of(2024, 5, 6)
+	r := &Rotation{
+		Anchor:  anchor,
+		Pattern: []string{"E", "E", "N", "N", "", "", ""},
+	}
+	var b strings.Builder
+	for d := range ClosedRange(anchor-9, anchor+9).Dates() {
+		l := r.LabelFor(d)
+		if l == "" {
+			l = "-"
+		}
+		b.WriteString(l)
+		if r.On(d) != (l != "-") {
+			t.Errorf("On(%v) = %v, want %v", d, r.On(d), l != "-")
+		}
+	}
+	if got, want := b.String(), "--EENN---EENN---EEN"; got != want {
+		t.Errorf("labels = %q, want %q", got, want)
+	}
+
+	type occ struct {
+		d Date
+		l string
+	}
+	var got []occ
+	for d, l := range r.Occurrences(ClosedRange(anchor+3, anchor+8)) {
+		got = append(got, occ{d, l})
+	}
+	want := []occ{{anchor + 3, "N"}, {anchor + 7, "E"}, {anchor + 8, "E"}}
+	if len(got) != len(want) {
+		t.Fatalf("Occurrences = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("Occurrences = %v, want %v", got, want)
+			break
+		}
+	}
+
+	r = NewOnOffRotation(anchor, 4, 4)
+	for i, want := range []bool{true, true, true, true, false, false, false, false, true} {
+		if got := r.On(anchor + Date(i)); got != want {
+			t.Errorf("NewOnOffRotation(_, 4, 4).On(anchor+%d) = %v, want %v", i, got, want)
+		}
+	}
+	if (&Rotation{Anchor: anchor}).On(anchor) {
+		t.Error("empty Rotation is on")
+	}
+}
+
+func TestCycleDay(t *testing.T) {
+	t.Parallel()
+	anchor := Of(2024, 5, 6)
+	tcs := []struct {
+		d      Date
+		length int
+		want   int
+	}{
+		{anchor, 28, 1},
+		{anchor + 1, 28, 2},
+		{anchor + 27, 28, 28},
+		{anchor + 28, 28, 1},
+		{anchor - 1, 28, 28},
+		{anchor - 28, 28, 1},
+		{anchor - 29, 28, 28},
+		{anchor + 100, 1, 1},
+		{anchor - 100, 1, 1},
+		{anchor - 3, 7, 5},
+	}
+	for _, tc := range tcs {
+		if got := CycleDay(tc.d, anchor, tc.length); got != tc.want {
+			t.Errorf("CycleDay(%v, %v, %d) = %d, want %d", tc.d, anchor, tc.length, got, tc.want)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("CycleDay with length 0 did not panic")
+		}
+	}()
+	CycleDay(anchor, anchor, 0)
+}
@@ -0,0 +1,475 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Rule describes a recurring date in a small English language, like
+// "last Friday of month" or "Thursday after 4th Sunday of Advent". Rules can
+// be used to generate or to match dates. They can be stored in configuration
+// files, as a Rule implements [encoding.TextMarshaler] and
+// [encoding.TextUnmarshaler].
+//
+// A rule consists of an anchor, which occurs at most once per month or year,
+// optionally preceded by any number of shifts. The anchors are
+//
+//	March 15, 15th Mar     a fixed day of the year
+//	2nd Tuesday of month   an occurrence of a weekday in every month
+//	last Friday of March   an occurrence of a weekday in a given month
+//	15th day of month      a day of every month
+//	last day of February   a day of a given month
+//	Easter                 Easter Sunday, as computed by [Easter]
+//	4th Sunday of Advent   a Sunday of Advent
+//
+// Weekdays and months can be abbreviated, like "Fri" or "Mar". Ordinals can
+// be written as "1st", "first" and so on. "last" counts from the
+// end of the month. The shifts are
+//
+//	3 days before          a number of days or weeks
+//	Monday after           the next occurrence of a weekday
+//	2nd Sunday before      the n'th occurrence of a weekday
+//	Sunday on or after     the next occurrence, including the date itself
+//	Sunday on or before    the last occurrence, including the date itself
+//
+// Shifts are applied from right to left, so "Monday after 3 days before March
+// 15" is the first Monday after March 12. If an anchor does not exist in some
+// month or year, like "5th Friday of month" or "February 29", the rule skips
+// that month or year. Matching is case-insensitive.
+//
+// The zero Rule matches no dates.
+type Rule struct {
+	src     string
+	monthly bool
+	anchor  func(year int, month time.Month) (Date, bool)
+	shifts  []func(Date) Date
+	span    int // maximum number of days the shifts move a date
+}
+
+// ParseRule parses a Rule. See [Rule] for the syntax.
+func ParseRule(s string) (*Rule, error) {
+	r := new(Rule)
+	if err := r.parse(s); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// MustParseRule is like [ParseRule], but panics if s can not be parsed. It is
+// intended for rules given as constants.
+func MustParseRule(s string) *Rule {
+	r, err := ParseRule(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// String returns the source of r, with normalized white space.
+func (r *Rule) String() string {
+	return r.src
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (r *Rule) MarshalText() ([]byte, error) {
+	return []byte(r.src), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (r *Rule) UnmarshalText(b []byte) error {
+	return r.parse(string(b))
+}
+
+// Yearly reports whether r occurs at most once per year. Otherwise, it occurs
+// at most once per month.
+func (r *Rule) Yearly() bool {
+	return !r.monthly
+}
+
+// Dates returns an iterator over the dates in rg matching r, in ascending
+// order.
+func (r *Rule) Dates(rg Range) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		if r.anchor == nil || rg.IsEmpty() {
+			return
+		}
+		// The anchor of a month or year lies in that month or year. So
+		// periods starting after rg.End+span can not contain a match.
+		year, month, _ := (rg.Start - Date(r.span)).Date()
+		if !r.monthly {
+			month = time.January
+		}
+		for Of(year, month, 1) < rg.End+Date(r.span) {
+			if d, ok := r.date(year, month); ok && rg.Contains(d) {
+				if !yield(d) {
+					return
+				}
+			}
+			if month++; r.monthly && month <= time.December {
+				continue
+			}
+			year, month = year+1, time.January
+		}
+	}
+}
+
+// Match reports whether d matches r. The method value r.Match can be used as
+// a [Predicate].
+func (r *Rule) Match(d Date) bool {
+	for range r.Dates(Range{d, d + 1}) {
+		return true
+	}
+	return false
+}
+
+// date returns the date of r for the given year and month. The month is
+// ignored for yearly rules.
+func (r *Rule) date(year int, month time.Month) (Date, bool) {
+	if r.anchor == nil {
+		return 0, false
+	}
+	d, ok := r.anchor(year, month)
+	if !ok {
+		return 0, false
+	}
+	for i := len(r.shifts) - 1; i >= 0; i-- {
+		d = r.shifts[i](d)
+	}
+	return d, true
+}
+
+// parse parses s into r. r is only modified, if s is valid.
+func (r *Rule) parse(s string) error {
+	p := &ruleParser{words: strings.Fields(strings.ToLower(s))}
+	v := &Rule{src: strings.Join(strings.Fields(s), " ")}
+	for {
+		if p.anchor(v) {
+			if len(p.words) > 0 {
+				return p.errorf(s, "unexpected %q", strings.Join(p.words, " "))
+			}
+			*r = *v
+			return nil
+		}
+		if !p.shift(v) {
+			if len(p.words) == 0 {
+				return p.errorf(s, "missing anchor")
+			}
+			return p.errorf(s, "unknown anchor or shift %q", strings.Join(p.words, " "))
+		}
+	}
+}
+
+type ruleParser struct {
+	words []string
+}
+
+func (p *ruleParser) errorf(s, format string, args ...any) error {
+	return fmt.Errorf("invalid date rule %q: %s", s, fmt.Sprintf(format, args...))
+}
+
+// try calls f and restores the position of p, if f returns false.
+func (p *ruleParser) try(f func() bool) bool {
+	saved := p.words
+	if f() {
+		return true
+	}
+	p.words = saved
+	return false
+}
+
+// word consumes the words of phrase, if the input starts with them.
+func (p *ruleParser) word(phrase string) bool {
+	return p.try(func() bool {
+		for _, w := range strings.Fields(phrase) {
+			if len(p.words) == 0 || p.words[0] != w {
+				return false
+			}
+			p.words = p.words[1:]
+		}
+		return true
+	})
+}
+
+func (p *ruleParser) number() (int, bool) {
+	if len(p.words) == 0 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(p.words[0])
+	if err != nil {
+		var ok bool
+		if n, ok = English.Numbers[p.words[0]]; !ok {
+			return 0, false
+		}
+	}
+	if n < 0 {
+		return 0, false
+	}
+	p.words = p.words[1:]
+	return n, true
+}
+
+var ruleOrdinals = map[string]int{
+	"first":  1,
+	"second": 2,
+	"third":  3,
+	"fourth": 4,
+	"fifth":  5,
+	"last":   -1,
+}
+
+// ordinal parses an ordinal like "first", "2nd" or "last", which is returned
+// as -1.
+func (p *ruleParser) ordinal() (int, bool) {
+	if len(p.words) == 0 {
+		return 0, false
+	}
+	w := p.words[0]
+	n, ok := ruleOrdinals[w]
+	if !ok && len(w) > 2 {
+		var err error
+		n, err = strconv.Atoi(w[:len(w)-2])
+		ok = err == nil && n > 0 && w[len(w)-2:] == ordinalSuffix(n)
+	}
+	if ok {
+		p.words = p.words[1:]
+	}
+	return n, ok
+}
+
+func (p *ruleParser) weekday() (time.Weekday, bool) {
+	if len(p.words) == 0 {
+		return 0, false
+	}
+	for wd, names := range English.Weekdays {
+		for _, name := range names {
+			if p.words[0] == name {
+				p.words = p.words[1:]
+				return time.Weekday(wd), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (p *ruleParser) month() (time.Month, bool) {
+	if len(p.words) == 0 {
+		return 0, false
+	}
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		if p.words[0] == name || p.words[0] == name[:3] {
+			p.words = p.words[1:]
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+// dayOfMonth parses a day of the month, like "15" or "15th".
+func (p *ruleParser) dayOfMonth() (int, bool) {
+	var day int
+	ok := p.try(func() bool {
+		var ok bool
+		if day, ok = p.number(); !ok {
+			day, ok = p.ordinal()
+		}
+		return ok && 1 <= day && day <= 31
+	})
+	return day, ok
+}
+
+// period parses "of month" or "of <month>". It returns 0 for "month".
+func (p *ruleParser) period() (time.Month, bool) {
+	var m time.Month
+	ok := p.try(func() bool {
+		if !p.word("of") && !p.word("in") {
+			return false
+		}
+		if p.word("month") || p.word("every month") {
+			m = 0
+			return true
+		}
+		var ok bool
+		m, ok = p.month()
+		return ok
+	})
+	return m, ok
+}
+
+// inPeriod returns an anchor function, returning the result of f for the
+// month given by period, or the month passed to the anchor if period is 0.
+func inPeriod(r *Rule, period time.Month, f func(year int, month time.Month) (Date, bool)) {
+	r.monthly = period == 0
+	r.anchor = func(year int, month time.Month) (Date, bool) {
+		if period != 0 {
+			month = period
+		}
+		return f(year, month)
+	}
+}
+
+func (p *ruleParser) anchor(r *Rule) bool {
+	if p.word("easter") {
+		r.anchor = func(year int, _ time.Month) (Date, bool) {
+			return Easter(year), true
+		}
+		return true
+	}
+	return p.try(func() bool {
+		// March 15
+		if m, ok := p.month(); ok {
+			day, ok := p.dayOfMonth()
+			if ok {
+				r.anchor = fixedAnchor(m, day)
+			}
+			return ok
+		}
+		n, ok := p.ordinal()
+		if !ok {
+			// 15 March
+			day, ok := p.dayOfMonth()
+			m, ok2 := p.month()
+			if ok && ok2 {
+				r.anchor = fixedAnchor(m, day)
+			}
+			return ok && ok2
+		}
+		// 15th March
+		if m, ok := p.month(); ok {
+			if n < 0 || n > 31 {
+				return false
+			}
+			r.anchor = fixedAnchor(m, n)
+			return true
+		}
+		// 4th Sunday of Advent
+		advent := p.try(func() bool {
+			wd, ok := p.weekday()
+			return ok && wd == time.Sunday && p.word("of advent")
+		})
+		if advent {
+			if n < 0 {
+				n = 4
+			}
+			if n > 4 {
+				return false
+			}
+			r.anchor = func(year int, _ time.Month) (Date, bool) {
+				fourth := relWeekday(Of(year, time.December, 25), time.Sunday, -1)
+				return fourth.AddWeeks(n - 4), true
+			}
+			return true
+		}
+		// 2nd Tuesday of month
+		if wd, ok := p.weekday(); ok {
+			period, ok := p.period()
+			if !ok || n > 5 {
+				return false
+			}
+			adj := NthInMonth(n, wd)
+			inPeriod(r, period, func(year int, month time.Month) (Date, bool) {
+				d := adj.Adjust(Of(year, month, 1))
+				return d, d.Month() == month
+			})
+			return true
+		}
+		// 15th day of month
+		p.word("day")
+		period, ok := p.period()
+		if !ok || n > 31 {
+			return false
+		}
+		inPeriod(r, period, func(year int, month time.Month) (Date, bool) {
+			if n < 0 {
+				return Of(year, month+1, 0), true
+			}
+			d := Of(year, month, n)
+			return d, d.Day() == n
+		})
+		return true
+	})
+}
+
+func fixedAnchor(m time.Month, day int) func(int, time.Month) (Date, bool) {
+	return func(year int, _ time.Month) (Date, bool) {
+		d := Of(year, m, day)
+		return d, d.Day() == day
+	}
+}
+
+// direction parses "after", "before", "on or after" or "on or before".
+func (p *ruleParser) direction() (dir int, orSame bool, ok bool) {
+	switch {
+	case p.word("after"):
+		return 1, false, true
+	case p.word("before"):
+		return -1, false, true
+	case p.word("on or after"):
+		return 1, true, true
+	case p.word("on or before"):
+		return -1, true, true
+	}
+	return 0, false, false
+}
+
+func (p *ruleParser) shift(r *Rule) bool {
+	return p.try(func() bool {
+		// 3 days before
+		if n, ok := p.number(); ok {
+			days := 0
+			switch {
+			case p.word("day") || p.word("days"):
+				days = n
+			case p.word("week") || p.word("weeks"):
+				days = 7 * n
+			default:
+				return false
+			}
+			dir, orSame, ok := p.direction()
+			if !ok || orSame {
+				return false
+			}
+			days *= dir
+			r.shifts = append(r.shifts, func(d Date) Date { return d + Date(days) })
+			r.span += max(days, -days)
+			return true
+		}
+		// 2nd Sunday before
+		n, ok := p.ordinal()
+		if !ok {
+			n = 1
+		} else if n < 0 {
+			return false
+		}
+		wd, ok := p.weekday()
+		if !ok {
+			return false
+		}
+		dir, orSame, ok := p.direction()
+		if !ok {
+			return false
+		}
+		step := dir
+		if orSame {
+			step = 0
+		}
+		r.shifts = append(r.shifts, func(d Date) Date {
+			if dir < 0 && orSame {
+				d = relWeekday(d+1, wd, -1)
+			} else {
+				d = relWeekday(d, wd, step)
+			}
+			return d.AddWeeks(dir * (n - 1))
+		})
+		r.span += 7 * n
+		return true
+	})
+}
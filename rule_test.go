@@ -0,0 +1,162 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestRule(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		rule string
+		r    Range
+		want []Date
+	}{
+		{"March 15", ClosedRange(Of(2023, 1, 1), Of(2025, 3, 14)), []Date{Of(2023, 3, 15), Of(2024, 3, 15)}},
+		{"15 mar", YearRange(2024), []Date{Of(2024, 3, 15)}},
+		{"15th March", YearRange(2024), []Date{Of(2024, 3, 15)}},
+		{"Feb 29", ClosedRange(Of(2023, 1, 1), Of(2025, 12, 31)), []Date{Of(2024, 2, 29)}},
+		{
+			"last Fri of month",
+			ClosedRange(Of(2024, 1, 1), Of(2024, 3, 31)),
+			[]Date{Of(2024, 1, 26), Of(2024, 2, 23), Of(2024, 3, 29)},
+		},
+		{
+			"5th Friday of month",
+			ClosedRange(Of(2024, 1, 1), Of(2024, 5, 31)),
+			[]Date{Of(2024, 3, 29), Of(2024, 5, 31)},
+		},
+		{"4th Thursday of November", ClosedRange(Of(2023, 1, 1), Of(2024, 12, 31)), []Date{Of(2023, 11, 23), Of(2024, 11, 28)}},
+		{"Tuesday after first Monday in November", YearRange(2024), []Date{Of(2024, 11, 5)}},
+		{"Tuesday after first Monday in November", YearRange(2022), []Date{Of(2022, 11, 8)}},
+		{"Monday before May 25", YearRange(2024), []Date{Of(2024, 5, 20)}},
+		{"Monday before May 25", YearRange(2025), []Date{Of(2025, 5, 19)}},
+		{"Wednesday before November 23", YearRange(2022), []Date{Of(2022, 11, 16)}},
+		{"Sunday on or after Jun 2", YearRange(2024), []Date{Of(2024, 6, 2)}},
+		{"Sunday on or before Jun 1", YearRange(2024), []Date{Of(2024, 5, 26)}},
+		{"Sunday on or before Jun 2", YearRange(2024), []Date{Of(2024, 6, 2)}},
+		{"2nd Sunday before Jun 2", YearRange(2024), []Date{Of(2024, 5, 19)}},
+		{"15th day of month", ClosedRange(Of(2024, 1, 15), Of(2024, 3, 14)), []Date{Of(2024, 1, 15), Of(2024, 2, 15)}},
+		{"31st day of month", ClosedRange(Of(2024, 1, 1), Of(2024, 5, 31)), []Date{Of(2024, 1, 31), Of(2024, 3, 31), Of(2024, 5, 31)}},
+		{"last day of February", ClosedRange(Of(2023, 1, 1), Of(2024, 12, 31)), []Date{Of(2023, 2, 28), Of(2024, 2, 29)}},
+		{"first of month", ClosedRange(Of(2024, 1, 2), Of(2024, 3, 1)), []Date{Of(2024, 2, 1), Of(2024, 3, 1)}},
+		{"easter", ClosedRange(Of(2024, 1, 1), Of(2025, 12, 31)), []Date{Of(2024, 3, 31), Of(2025, 4, 20)}},
+		{"2 days before Easter", YearRange(2024), []Date{Of(2024, 3, 29)}},
+		{"7 weeks after easter", YearRange(2024), []Date{Of(2024, 5, 19)}},
+		{"1st Sunday of Advent", ClosedRange(Of(2023, 1, 1), Of(2024, 12, 31)), []Date{Of(2023, 12, 3), Of(2024, 12, 1)}},
+		{"4th Sunday of Advent", YearRange(2023), []Date{Of(2023, 12, 24)}},
+		{"Thursday after 4th Sunday of Advent", YearRange(2024), []Date{Of(2024, 12, 26)}},
+		{"Thursday after 4th Sunday of Advent", YearRange(2023), []Date{Of(2023, 12, 28)}},
+		{"Thu after 4th Sun of Advent", YearRange(2024), []Date{Of(2024, 12, 26)}},
+		// Shifts can move dates across the boundary of the year.
+		{"1 day after Dec 31", YearRange(2024), []Date{Of(2024, 1, 1)}},
+		{"2 weeks before Jan 3", ClosedRange(Of(2023, 12, 1), Of(2024, 12, 31)), []Date{Of(2023, 12, 20), Of(2024, 12, 20)}},
+		{"Monday after 3 days before March 15", YearRange(2024), []Date{Of(2024, 3, 18)}},
+		{"3 days before march 15", Range{}, nil},
+	}
+	for _, tc := range tcs {
+		r, err := ParseRule(tc.rule)
+		if err != nil {
+			t.Errorf("ParseRule(%q) = %v", tc.rule, err)
+			continue
+		}
+		got := slices.Collect(r.Dates(tc.r))
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("ParseRule(%q).Dates(%v) = %v, want %v", tc.rule, tc.r, got, tc.want)
+		}
+		for d := range tc.r.Dates() {
+			if got, want := r.Match(d), slices.Contains(tc.want, d); got != want {
+				t.Errorf("ParseRule(%q).Match(%v) = %v, want %v", tc.rule, d, got, want)
+			}
+		}
+	}
+}
+
+func TestParseRuleErrors(t *testing.T) {
+	t.Parallel()
+	tcs := []string{
+		"",
+		"Monday after",
+		"day after Dec 31",
+		"March 32",
+		"6th Friday of month",
+		"5th Sunday of Advent",
+		"last Friday",
+		"Easter Monday",
+		"Sunday on or after",
+		"3 days on or after Easter",
+		"last Sunday before Easter",
+		"15th of Smarch",
+	}
+	for _, s := range tcs {
+		if r, err := ParseRule(s); err == nil {
+			t.Errorf("ParseRule(%q) = %v, want error", s, r)
+		}
+	}
+}
+
+func TestRuleText(t *testing.T) {
+	t.Parallel()
+	var cfg struct {
+		Rules []*Rule
+	}
+	in := `{"Rules":["last  Friday of month", "Monday before May 25"]}`
+	if err := json.Unmarshal([]byte(in), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cfg.Rules[0].String(), "last Friday of month"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if cfg.Rules[0].Yearly() || !cfg.Rules[1].Yearly() {
+		t.Errorf("Yearly() = %v, %v, want false, true", cfg.Rules[0].Yearly(), cfg.Rules[1].Yearly())
+	}
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(out), `{"Rules":["last Friday of month","Monday before May 25"]}`; got != want {
+		t.Errorf("json.Marshal = %s, want %s", got, want)
+	}
+	if err := json.Unmarshal([]byte(`{"Rules":["Smarch 1"]}`), &cfg); err == nil {
+		t.Error("json.Unmarshal of invalid rule succeeded")
+	}
+
+	// A failed UnmarshalText leaves the rule unchanged.
+	r := MustParseRule("Easter")
+	if err := r.UnmarshalText([]byte("Monday after Smarch 1")); err == nil {
+		t.Error("UnmarshalText of invalid rule succeeded")
+	}
+	if got, want := r.String(), "Easter"; got != want || !r.Match(Easter(2024)) {
+		t.Errorf("after failed UnmarshalText, rule is %q, want %q", got, want)
+	}
+}
+
+func TestRuleHoliday(t *testing.T) {
+	t.Parallel()
+	cal := NewHolidayCalendar(
+		RuleHoliday("Victoria Day", MustParseRule("Monday before May 25"), nil),
+		RuleHoliday("Good Friday", MustParseRule("2 days before Easter"), nil),
+	)
+	want := []Holiday{
+		{Name: "Good Friday", Date: Of(2024, 3, 29), Observed: Of(2024, 3, 29)},
+		{Name: "Victoria Day", Date: Of(2024, 5, 20), Observed: Of(2024, 5, 20)},
+	}
+	if got := cal.Holidays(2024); !slices.Equal(got, want) {
+		t.Errorf("Holidays(2024) = %v, want %v", got, want)
+	}
+	if d, ok := RuleHoliday("Never", new(Rule), nil).Date(2024); ok {
+		t.Errorf("RuleHoliday with zero Rule: Date(2024) = %v, true, want false", d)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("RuleHoliday with monthly rule did not panic")
+		}
+	}()
+	RuleHoliday("Payday", MustParseRule("last day of month"), nil)
+}
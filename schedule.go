@@ -0,0 +1,96 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"iter"
+	"slices"
+)
+
+// A Schedule is a recurring event with exceptions. It corresponds to the
+// RRULE, RDATE and EXDATE properties of an iCalendar event.
+//
+// All fields of a Schedule implement [encoding.TextMarshaler], so it can be
+// stored using encoding/json and similar packages.
+type Schedule struct {
+	// Rule is the recurrence of the event. If it is nil, the event occurs
+	// only on the dates in Add.
+	Rule *Rule
+
+	// Add lists additional dates on which the event occurs. The dates do not
+	// need to be sorted.
+	Add []Date
+
+	// Exclude lists dates on which the event does not occur. It takes
+	// precedence over Rule and Add. The dates do not need to be sorted.
+	Exclude []Date
+}
+
+// Occurrences returns an iterator over the dates in r on which the event
+// occurs, in ascending order. Every date is yielded at most once, even if it
+// is both matched by Rule and listed in Add.
+func (s *Schedule) Occurrences(r Range) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		add := inRange(s.Add, r)
+		excl := inRange(s.Exclude, r)
+		var rule iter.Seq[Date] = func(func(Date) bool) {}
+		if s.Rule != nil {
+			rule = s.Rule.Dates(r)
+		}
+		emit := func(d Date) bool {
+			if _, ok := slices.BinarySearch(excl, d); ok {
+				return true
+			}
+			return yield(d)
+		}
+		for d := range rule {
+			for len(add) > 0 && add[0] < d {
+				if !emit(add[0]) {
+					return
+				}
+				add = add[1:]
+			}
+			if len(add) > 0 && add[0] == d {
+				add = add[1:]
+			}
+			if !emit(d) {
+				return
+			}
+		}
+		for _, d := range add {
+			if !emit(d) {
+				return
+			}
+		}
+	}
+}
+
+// Next returns the first date after the given date on which the event
+// occurs. It returns false, if there is no such date.
+func (s *Schedule) Next(after Date) (Date, bool) {
+	// The Gregorian calendar repeats every 400 years, so if Rule does not
+	// match within 400 years after the last exception, it never matches.
+	last := after
+	for _, d := range slices.Concat(s.Add, s.Exclude) {
+		last = max(last, d)
+	}
+	for d := range s.Occurrences(ClosedRange(after+1, last+400*365+97)) {
+		return d, true
+	}
+	return 0, false
+}
+
+// inRange returns the sorted, de-duplicated dates of ds in r.
+func inRange(ds []Date, r Range) []Date {
+	var out []Date
+	for _, d := range ds {
+		if r.Contains(d) {
+			out = append(out, d)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
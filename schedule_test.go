@@ -0,0 +1,83 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	t.Parallel()
+	s := &Schedule{
+		Rule:    MustParseRule("2nd Tuesday of month"),
+		Add:     []Date{Of(2024, 6, 20), Of(2024, 5, 14), Of(2024, 4, 1), Of(2024, 4, 1)},
+		Exclude: []Date{Of(2024, 6, 11), Of(2024, 6, 20), Of(2023, 1, 1)},
+	}
+	got := slices.Collect(s.Occurrences(ClosedRange(Of(2024, 3, 13), Of(2024, 7, 31))))
+	want := []Date{Of(2024, 4, 1), Of(2024, 4, 9), Of(2024, 5, 14), Of(2024, 7, 9)}
+	if !slices.Equal(got, want) {
+		t.Errorf("Occurrences = %v, want %v", got, want)
+	}
+
+	nexts := []struct {
+		after Date
+		want  Date
+	}{
+		{Of(2024, 3, 31), Of(2024, 4, 1)},
+		{Of(2024, 4, 1), Of(2024, 4, 9)},
+		{Of(2024, 5, 14), Of(2024, 7, 9)},
+	}
+	for _, tc := range nexts {
+		if got, ok := s.Next(tc.after); !ok || got != tc.want {
+			t.Errorf("Next(%v) = %v, %v, want %v, true", tc.after, got, ok, tc.want)
+		}
+	}
+
+	s = &Schedule{Add: []Date{Of(2024, 5, 14), Of(2024, 5, 1)}}
+	if got, ok := s.Next(Of(2024, 5, 1)); !ok || got != Of(2024, 5, 14) {
+		t.Errorf("Next(%v) = %v, %v, want %v, true", Of(2024, 5, 1), got, ok, Of(2024, 5, 14))
+	}
+	if got, ok := s.Next(Of(2024, 5, 14)); ok {
+		t.Errorf("Next(%v) = %v, true, want false", Of(2024, 5, 14), got)
+	}
+
+	// Leap days are rare, but Next still finds them.
+	s = &Schedule{Rule: MustParseRule("Feb 29"), Exclude: []Date{Of(2028, 2, 29)}}
+	if got, ok := s.Next(Of(2024, 3, 1)); !ok || got != Of(2032, 2, 29) {
+		t.Errorf("Next(%v) = %v, %v, want %v, true", Of(2024, 3, 1), got, ok, Of(2032, 2, 29))
+	}
+	s = &Schedule{Rule: MustParseRule("5th Monday of February")}
+	if got, ok := s.Next(Of(2024, 3, 1)); !ok || got != Of(2044, 2, 29) {
+		t.Errorf("Next(%v) = %v, %v, want %v, true", Of(2024, 3, 1), got, ok, Of(2044, 2, 29))
+	}
+	s = &Schedule{Rule: MustParseRule("February 30")}
+	if got, ok := s.Next(Of(2024, 3, 1)); ok {
+		t.Errorf("Next(%v) = %v, true, want false", Of(2024, 3, 1), got)
+	}
+}
+
+func TestScheduleJSON(t *testing.T) {
+	t.Parallel()
+	in := `{"Rule":"last Friday of month","Add":["2024-05-14"],"Exclude":["2024-05-31"]}`
+	var s Schedule
+	if err := json.Unmarshal([]byte(in), &s); err != nil {
+		t.Fatal(err)
+	}
+	got := slices.Collect(s.Occurrences(MonthRange(2024, time.May)))
+	if want := []Date{Of(2024, 5, 14)}; !slices.Equal(got, want) {
+		t.Errorf("Occurrences = %v, want %v", got, want)
+	}
+	out, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("json.Marshal = %s, want %s", out, in)
+	}
+}
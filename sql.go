@@ -0,0 +1,85 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Value implements the driver.Valuer interface. The date is passed to the
+// driver as a string in ISO 8601 format, which databases convert to a DATE
+// without involving time zones.
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts a [time.Time], which
+// is converted to its date in its location, as drivers return DATE columns at
+// midnight in the location of the connection. It also accepts a string or
+// []byte in ISO 8601 format, optionally followed by a time of day, like
+// "2024-05-14 00:00:00". Use [NullDate] to scan nullable columns.
+func (d *Date) Scan(src any) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = Of(v.Date())
+		return nil
+	case string:
+		return d.scanText(v)
+	case []byte:
+		return d.scanText(string(v))
+	case nil:
+		return errors.New("cannot scan NULL into date")
+	default:
+		return fmt.Errorf("cannot scan %T into date", src)
+	}
+}
+
+// scanText parses an ISO 8601 date, optionally followed by a time.
+func (d *Date) scanText(s string) error {
+	if len(s) > len(RFC3339) && (s[len(RFC3339)] == ' ' || s[len(RFC3339)] == 'T') {
+		s = s[:len(RFC3339)]
+	}
+	v, err := Parse(RFC3339, s)
+	if err == nil {
+		*d = v
+	}
+	return err
+}
+
+// NullDate is a Date, which may be NULL in a database. It is analogous to
+// [database/sql.NullTime].
+type NullDate struct {
+	Date Date
+
+	// Valid is true if Date is not NULL.
+	Valid bool
+}
+
+// Value implements the driver.Valuer interface. It returns nil, if n is not
+// valid, and the value of the Date otherwise.
+func (n NullDate) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Date.Value()
+}
+
+// Scan implements the sql.Scanner interface, as documented for [Date.Scan].
+// NULL is scanned as a NullDate which is not valid.
+func (n *NullDate) Scan(src any) error {
+	if src == nil {
+		*n = NullDate{}
+		return nil
+	}
+	if err := n.Date.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
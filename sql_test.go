@@ -0,0 +1,61 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+var (
+	_ sql.Scanner   = new(Date)
+	_ driver.Valuer = Date(0)
+	_ sql.Scanner   = new(NullDate)
+	_ driver.Valuer = NullDate{}
+)
+
+func TestSQL(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 14)
+	if v, err := d.Value(); err != nil || v != "2024-05-14" {
+		t.Errorf("%v.Value() = %v, %v, want %q, <nil>", d, v, err, "2024-05-14")
+	}
+	if v, err := (NullDate{d, true}).Value(); err != nil || v != "2024-05-14" {
+		t.Errorf("NullDate{%v, true}.Value() = %v, %v, want %q, <nil>", d, v, err, "2024-05-14")
+	}
+	if v, err := (NullDate{d, false}).Value(); err != nil || v != nil {
+		t.Errorf("NullDate{%v, false}.Value() = %v, %v, want <nil>, <nil>", d, v, err)
+	}
+
+	tcs := []struct {
+		src  any
+		want Date
+		ok   bool
+	}{
+		{"2024-05-14", d, true},
+		{[]byte("2024-05-14"), d, true},
+		{"2024-05-14 00:00:00", d, true},
+		{"2024-05-14T00:00:00Z", d, true},
+		{time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC), d, true},
+		{time.Date(2024, 5, 14, 0, 0, 0, 0, time.FixedZone("", -5*60*60)), d, true},
+		{"05/14/2024", 0, false},
+		{"2024-05-14x", 0, false},
+		{int64(19857), 0, false},
+		{nil, 0, false},
+	}
+	for _, tc := range tcs {
+		var got Date
+		if err := got.Scan(tc.src); (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("Scan(%#v) = %v, %v, want %v, %v", tc.src, got, err, tc.want, tc.ok)
+		}
+		var n NullDate
+		if err := n.Scan(tc.src); (err == nil) != (tc.ok || tc.src == nil) || n != (NullDate{tc.want, tc.ok}) {
+			t.Errorf("NullDate.Scan(%#v) = %v, %v, want %v", tc.src, n, err, NullDate{tc.want, tc.ok})
+		}
+	}
+}
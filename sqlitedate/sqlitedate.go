@@ -0,0 +1,139 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sqlitedate provides the representations of dates commonly used with
+// SQLite, for use with database/sql.
+//
+// SQLite has no dedicated date type. Its date functions accept dates as TEXT
+// in ISO 8601 format, as INTEGER seconds since the Unix epoch or as REAL
+// Julian day numbers. Convert a Date to [Text], [Unix] or [JulianDay] to
+// choose the representation stored:
+//
+//	_, err := db.Exec("INSERT INTO events (day) VALUES (?)", sqlitedate.JulianDay(d))
+//
+// When scanning, all types accept all three representations, as well as a
+// [time.Time], which some drivers return for columns declared as DATE. A
+// datetime is converted to its date in UTC, discarding the time of day. Use
+// [database/sql.Null] to scan nullable columns.
+package sqlitedate
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gonih.org/date"
+)
+
+// julianEpoch is the Julian day number of the Unix epoch, 1970-01-01T00:00Z.
+const julianEpoch = 2440587.5
+
+const secondsPerDay = 24 * 60 * 60
+
+// Text is a Date, which is stored as TEXT in ISO 8601 format, like
+// "2024-05-14". This is the format returned by the date function of SQLite.
+type Text date.Date
+
+// Value implements the driver.Valuer interface.
+func (t Text) Value() (driver.Value, error) {
+	return date.Date(t).String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (t *Text) Scan(src any) error {
+	return scan((*date.Date)(t), src)
+}
+
+// String returns the date formatted as ISO 8601.
+func (t Text) String() string {
+	return date.Date(t).String()
+}
+
+// Unix is a Date, which is stored as an INTEGER number of seconds since the
+// Unix epoch, at midnight UTC. This is the format returned by the unixepoch
+// function of SQLite.
+type Unix date.Date
+
+// Value implements the driver.Valuer interface.
+func (u Unix) Value() (driver.Value, error) {
+	return date.Date(u).UnixDays() * secondsPerDay, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (u *Unix) Scan(src any) error {
+	return scan((*date.Date)(u), src)
+}
+
+// String returns the date formatted as ISO 8601.
+func (u Unix) String() string {
+	return date.Date(u).String()
+}
+
+// JulianDay is a Date, which is stored as a REAL Julian day number, at
+// midnight UTC. This is the format returned by the julianday function of
+// SQLite. For example, 2024-05-14 is stored as 2460444.5.
+type JulianDay date.Date
+
+// Value implements the driver.Valuer interface.
+func (j JulianDay) Value() (driver.Value, error) {
+	return float64(date.Date(j).UnixDays()) + julianEpoch, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (j *JulianDay) Scan(src any) error {
+	return scan((*date.Date)(j), src)
+}
+
+// String returns the date formatted as ISO 8601.
+func (j JulianDay) String() string {
+	return date.Date(j).String()
+}
+
+// scan converts a value returned by a driver to a date.
+func scan(d *date.Date, src any) error {
+	switch v := src.(type) {
+	case string:
+		return scanText(d, v)
+	case []byte:
+		return scanText(d, string(v))
+	case int64:
+		days := v / secondsPerDay
+		if v%secondsPerDay < 0 {
+			days--
+		}
+		*d = date.FromUnixDays(days)
+		return nil
+	case float64:
+		days := math.Floor(v - julianEpoch)
+		if math.IsNaN(days) || math.Abs(days) > 1e15 {
+			return fmt.Errorf("invalid Julian day number %v", v)
+		}
+		*d = date.FromUnixDays(int64(days))
+		return nil
+	case time.Time:
+		*d = date.Of(v.UTC().Date())
+		return nil
+	case nil:
+		return errors.New("cannot scan NULL into date")
+	default:
+		return fmt.Errorf("cannot scan %T into date", src)
+	}
+}
+
+// scanText parses an ISO 8601 date, optionally followed by a time, as
+// accepted by the date functions of SQLite.
+func scanText(d *date.Date, s string) error {
+	if len(s) > 10 && (s[10] == ' ' || s[10] == 'T') {
+		s = s[:10]
+	}
+	v, err := date.Parse(date.RFC3339, s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlitedate
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"testing"
+	"time"
+
+	"gonih.org/date"
+)
+
+func TestValue(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		v    driver.Valuer
+		want driver.Value
+	}{
+		{Text(date.Of(2024, 5, 14)), "2024-05-14"},
+		{Unix(date.Of(2024, 5, 14)), int64(1715644800)},
+		{Unix(date.Of(1969, 12, 31)), int64(-86400)},
+		{JulianDay(date.Of(2024, 5, 14)), 2460444.5},
+		{JulianDay(date.Of(1970, 1, 1)), 2440587.5},
+		{JulianDay(date.Of(-4713, 11, 24)), -0.5},
+	}
+	for _, tc := range tcs {
+		if got, err := tc.v.Value(); err != nil || got != tc.want {
+			t.Errorf("%T(%v).Value() = %v, %v, want %v, <nil>", tc.v, tc.v, got, err, tc.want)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		src  any
+		want date.Date
+		err  bool
+	}{
+		{"2024-05-14", date.Of(2024, 5, 14), false},
+		{[]byte("2024-05-14"), date.Of(2024, 5, 14), false},
+		{"2024-05-14 23:59:59", date.Of(2024, 5, 14), false},
+		{"2024-05-14T12:00:00Z", date.Of(2024, 5, 14), false},
+		{int64(1715644800), date.Of(2024, 5, 14), false},
+		{int64(1715644800 + 86399), date.Of(2024, 5, 14), false},
+		{int64(-1), date.Of(1969, 12, 31), false},
+		{2460444.5, date.Of(2024, 5, 14), false},
+		{2460445.25, date.Of(2024, 5, 14), false},
+		{2460444.4, date.Of(2024, 5, 13), false},
+		{time.Date(2024, 5, 14, 23, 0, 0, 0, time.FixedZone("", -3600)), date.Of(2024, 5, 15), false},
+		{"2024-05-14x", 0, true},
+		{"14.05.2024", 0, true},
+		{math.NaN(), 0, true},
+		{math.Inf(1), 0, true},
+		{true, 0, true},
+		{nil, 0, true},
+	}
+	for _, tc := range tcs {
+		var (
+			text  Text
+			unix  Unix
+			julia JulianDay
+		)
+		for _, s := range []interface {
+			sql.Scanner
+			String() string
+		}{&text, &unix, &julia} {
+			err := s.Scan(tc.src)
+			if tc.err {
+				if err == nil {
+					t.Errorf("%T.Scan(%#v) succeeded", s, tc.src)
+				}
+				continue
+			}
+			if err != nil || s.String() != tc.want.String() {
+				t.Errorf("%T.Scan(%#v) = %v, result %v, want <nil>, %v", s, tc.src, err, s, tc.want)
+			}
+		}
+	}
+
+	var n sql.Null[Text]
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("sql.Null[Text].Scan(nil) = %v, Valid = %v, want <nil>, false", err, n.Valid)
+	}
+}
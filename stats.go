@@ -0,0 +1,39 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "gonih.org/date/internal/cache"
+
+// CacheStats are statistics about the use of an internal cache. A high rate
+// of evictions means the cache is thrashing, for example because layouts are
+// constructed dynamically.
+type CacheStats struct {
+	Hits      int64 // number of lookups of cached values
+	Misses    int64 // number of lookups computing the value
+	Evictions int64 // number of values evicted to make room for others
+	Len       int   // number of values currently cached
+	Size      int64 // approximate memory used by the cached values, in bytes
+}
+
+// LayoutCacheStats returns statistics about the cache of compiled layouts,
+// used by parsing and formatting with a layout. The package
+// gonih.org/date/datemetrics exposes them as metrics.
+func LayoutCacheStats() CacheStats {
+	return cacheStats(memo.Stats(), memoExtended.Stats(), memoCompat.Stats(), memoOptions.Stats())
+}
+
+// cacheStats returns the sum of stats.
+func cacheStats(stats ...cache.Stats) CacheStats {
+	var s CacheStats
+	for _, c := range stats {
+		s.Hits += c.Hits
+		s.Misses += c.Misses
+		s.Evictions += c.Evictions
+		s.Len += c.Len
+		s.Size += c.Size
+	}
+	return s
+}
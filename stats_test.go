@@ -0,0 +1,57 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLayoutCacheStats(t *testing.T) {
+	// Not parallel, as other tests use the cache as well.
+	const layout = "2006 ~ 01 ~ 02 (stats)"
+	before := LayoutCacheStats()
+	for range 3 {
+		Of(2024, 5, 14).Format(layout)
+	}
+	after := LayoutCacheStats()
+	if after.Misses-before.Misses < 1 {
+		t.Errorf("LayoutCacheStats().Misses = %d after formatting with new layout, want > %d", after.Misses, before.Misses)
+	}
+	if after.Hits-before.Hits < 2 {
+		t.Errorf("LayoutCacheStats().Hits = %d after formatting with cached layout, want >= %d", after.Hits, before.Hits+2)
+	}
+	if after.Len < 1 || after.Size < int64(len(layout)) {
+		t.Errorf("LayoutCacheStats() = %+v, want Len > 0 and Size >= %d", after, len(layout))
+	}
+}
+
+func TestSetLayoutCacheSize(t *testing.T) {
+	// Not parallel, as other tests use the cache as well.
+	defer SetLayoutCacheSize(-1)
+
+	SetLayoutCacheSize(0)
+	if s := LayoutCacheStats(); s.Len != 0 || s.Size != 0 {
+		t.Errorf("LayoutCacheStats() = %+v after disabling the cache, want Len and Size 0", s)
+	}
+	const layout = "2006 ~ 01 ~ 02 (uncached)"
+	before := LayoutCacheStats()
+	for range 3 {
+		Of(2024, 5, 14).Format(layout)
+	}
+	if after := LayoutCacheStats(); after.Misses-before.Misses != 3 || after.Len != 0 {
+		t.Errorf("LayoutCacheStats() = %+v after formatting 3 times with disabled cache, want 3 more misses and Len 0", after)
+	}
+
+	const limit = 2 << 10
+	SetLayoutCacheSize(limit)
+	for i := range 100 {
+		Of(2024, 5, 14).Format(strings.Repeat("-", i) + RFC3339)
+	}
+	if s := LayoutCacheStats(); s.Len == 0 || s.Size > 2*limit {
+		t.Errorf("LayoutCacheStats() = %+v with limit %d, want Len > 0 and Size <= %d", s, limit, 2*limit)
+	}
+}
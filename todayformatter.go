@@ -0,0 +1,61 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// A TodayFormatter returns the current date, formatted using a layout. The
+// formatted date is cached until the date changes, so it is cheap to call, for
+// example to prefix every line of a log. It is safe for concurrent use.
+type TodayFormatter struct {
+	clock  Clock
+	loc    *time.Location
+	layout string
+	cur    atomic.Pointer[todayEntry]
+}
+
+// todayEntry is the cached formatted date of a TodayFormatter.
+type todayEntry struct {
+	start, end time.Time // the first instants of the date and the next date
+	s          string
+}
+
+// NewTodayFormatter returns a TodayFormatter, formatting the current date in
+// loc using layout. If c is nil, [SystemClock] is used.
+//
+// The formatted date is updated at the start of the next day, as determined
+// for a [Watcher], or when the clock is set back to an earlier date.
+func NewTodayFormatter(c Clock, loc *time.Location, layout string) *TodayFormatter {
+	if c == nil {
+		c = SystemClock
+	}
+	return &TodayFormatter{clock: c, loc: loc, layout: layout}
+}
+
+// String returns the current date, formatted using the layout of f.
+func (f *TodayFormatter) String() string {
+	now := f.clock.Now()
+	if e := f.cur.Load(); e != nil && !now.Before(e.start) && now.Before(e.end) {
+		return e.s
+	}
+	d := Of(now.In(f.loc).Date())
+	e := &todayEntry{
+		start: startOfDay(d, f.loc),
+		end:   startOfDay(d+1, f.loc),
+		s:     d.Format(f.layout),
+	}
+	f.cur.Store(e)
+	return e.s
+}
+
+// AppendFormat appends the current date, formatted using the layout of f, to b
+// and returns the extended buffer.
+func (f *TodayFormatter) AppendFormat(b []byte) []byte {
+	return append(b, f.String()...)
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTodayFormatter(t *testing.T) {
+	// Not parallel, as testing.AllocsPerRun panics in parallel tests.
+	loc := time.FixedZone("CEST", 2*60*60)
+	c := newFakeClock(time.Date(2024, 5, 14, 23, 59, 0, 0, loc))
+	f := NewTodayFormatter(c, loc, "Jan 2")
+	steps := []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2024, 5, 14, 23, 59, 0, 0, loc), "May 14"},
+		{time.Date(2024, 5, 14, 23, 59, 59, 0, loc), "May 14"},
+		{time.Date(2024, 5, 15, 0, 0, 0, 0, loc), "May 15"},
+		{time.Date(2024, 5, 14, 22, 30, 0, 0, time.UTC), "May 15"},
+		{time.Date(2024, 5, 14, 12, 0, 0, 0, loc), "May 14"},
+		{time.Date(2024, 12, 24, 12, 0, 0, 0, loc), "Dec 24"},
+	}
+	for _, s := range steps {
+		c.set(s.now)
+		if got := f.String(); got != s.want {
+			t.Errorf("String() at %v = %q, want %q", s.now, got, s.want)
+		}
+	}
+	if got, want := string(f.AppendFormat([]byte("date: "))), "date: Dec 24"; got != want {
+		t.Errorf("AppendFormat(%q) = %q, want %q", "date: ", got, want)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { _ = f.String() }); n != 0 {
+		t.Errorf("String allocates %v times, want 0", n)
+	}
+}
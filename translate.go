@@ -0,0 +1,277 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gonih.org/date/internal/cache"
+)
+
+// LayoutFromStrftime translates a strftime format, like "%Y-%m-%d", into a
+// layout for use with [Date.Format] and [Parse]. The supported conversion
+// specifications are
+//
+//	%Y %y           year
+//	%m %-m %b %h %B month
+//	%d %-d %e %-e   day of the month
+//	%j              day of the year
+//	%a %A %u        day of the week
+//	%F %D %x        2006-01-02, 01/02/06 and 01/02/06
+//	%% %n %t        "%", newline and tab
+//
+// %u is translated to the extended element "Mon#", which requires
+// [Parser.Extended] or [Formatter.SetExtended]. Specifications of times, time
+// zones and ISO weeks are not supported and cause an error. As layouts can not
+// escape literal text, it is also an error, if format contains literal text
+// which would be interpreted as a layout element, like "1" or "Jan".
+func LayoutFromStrftime(format string) (string, error) {
+	var lb layoutBuilder
+	for s := format; len(s) > 0; {
+		i := strings.IndexByte(s, '%')
+		if i < 0 {
+			lb.lit(s)
+			break
+		}
+		lb.lit(s[:i])
+		s = s[i+1:]
+		noPad := strings.HasPrefix(s, "-")
+		if noPad {
+			s = s[1:]
+		}
+		if s == "" {
+			return "", fmt.Errorf("strftime format %q ends with incomplete conversion", format)
+		}
+		c := s[0]
+		s = s[1:]
+		if noPad && !strings.ContainsRune("Ymde", rune(c)) {
+			return "", fmt.Errorf("strftime conversion %%-%c in %q not supported", c, format)
+		}
+		switch c {
+		case 'Y':
+			lb.op(opLongYear)
+		case 'y':
+			lb.op(opYear)
+		case 'm':
+			lb.op(pick(noPad, opNumMonth, opZeroMonth))
+		case 'b', 'h':
+			lb.op(opMonth)
+		case 'B':
+			lb.op(opLongMonth)
+		case 'd':
+			lb.op(pick(noPad, opDay, opZeroDay))
+		case 'e':
+			lb.op(pick(noPad, opDay, opUnderDay))
+		case 'j':
+			lb.op(opZeroYearDay)
+		case 'a':
+			lb.op(opWeekDay)
+		case 'A':
+			lb.op(opLongWeekDay)
+		case 'u':
+			lb.op(opISOWeekDay)
+		case 'F':
+			lb.op(opLongYear)
+			lb.lit("-")
+			lb.op(opZeroMonth)
+			lb.lit("-")
+			lb.op(opZeroDay)
+		case 'D', 'x':
+			lb.op(opZeroMonth)
+			lb.lit("/")
+			lb.op(opZeroDay)
+			lb.lit("/")
+			lb.op(opYear)
+		case '%':
+			lb.lit("%")
+		case 'n':
+			lb.lit("\n")
+		case 't':
+			lb.lit("\t")
+		case 'H', 'I', 'k', 'l', 'M', 'S', 'p', 'P', 'r', 'R', 'T', 'X', 'c', 's', 'z', 'Z', 'f', 'L', 'N':
+			return "", fmt.Errorf("strftime conversion %%%c in %q is a time or time zone", c, format)
+		default:
+			return "", fmt.Errorf("strftime conversion %%%c in %q not supported", c, format)
+		}
+	}
+	return lb.layout(format)
+}
+
+// LayoutFromCLDR translates a Unicode CLDR date pattern, like "yyyy-MM-dd" or
+// "EEEE, d. MMMM y", into a layout for use with [Date.Format] and [Parse]. The
+// supported fields are
+//
+//	y yy yyy yyyy               year
+//	M MM MMM MMMM MMMMM         month, also as L
+//	d dd                        day of the month
+//	DDD                         day of the year
+//	E EE EEE EEEE EEEEE EEEEEE  day of the week, also as ccc… and eee…
+//
+// MMMMM, EEEEE and EEEEEE are translated to the extended elements "J", "M"
+// and "Mo", which require [Parser.Extended] or [Formatter.SetExtended]. Text
+// in single quotes is literal, and two single quotes are a literal single
+// quote. Fields of times, time zones, eras, quarters and weeks are not
+// supported and cause an error. As layouts can not escape literal text, it is
+// also an error, if pattern contains literal text which would be interpreted
+// as a layout element, like "1" or "Jan".
+func LayoutFromCLDR(pattern string) (string, error) {
+	var lb layoutBuilder
+	for s := pattern; len(s) > 0; {
+		switch c := s[0]; {
+		case c == '\'':
+			if strings.HasPrefix(s, "''") {
+				lb.lit("'")
+				s = s[2:]
+				continue
+			}
+			// Quoted text, in which '' is a single quote.
+			s = s[1:]
+			for {
+				i := strings.IndexByte(s, '\'')
+				if i < 0 {
+					return "", fmt.Errorf("CLDR pattern %q has unterminated quote", pattern)
+				}
+				lb.lit(s[:i])
+				s = s[i+1:]
+				if !strings.HasPrefix(s, "'") {
+					break
+				}
+				lb.lit("'")
+				s = s[1:]
+			}
+		case 'a' <= c|0x20 && c|0x20 <= 'z':
+			n := 1
+			for n < len(s) && s[n] == c {
+				n++
+			}
+			op, err := cldrField(c, n)
+			if err != nil {
+				return "", fmt.Errorf("CLDR pattern %q: %w", pattern, err)
+			}
+			lb.op(op)
+			s = s[n:]
+		default:
+			i := strings.IndexFunc(s, func(r rune) bool {
+				return r == '\'' || 'a' <= r|0x20 && r|0x20 <= 'z'
+			})
+			if i < 0 {
+				i = len(s)
+			}
+			lb.lit(s[:i])
+			s = s[i:]
+		}
+	}
+	return lb.layout(pattern)
+}
+
+// cldrField returns the layout element for the CLDR field consisting of n
+// repetitions of c.
+func cldrField(c byte, n int) (fmtOp, error) {
+	field := strings.Repeat(string(c), n)
+	switch c {
+	case 'y':
+		switch n {
+		case 1, 3, 4:
+			return opLongYear, nil
+		case 2:
+			return opYear, nil
+		}
+	case 'M', 'L':
+		if n <= 5 {
+			return [...]fmtOp{opNumMonth, opZeroMonth, opMonth, opLongMonth, opNarrowMonth}[n-1], nil
+		}
+	case 'd':
+		if n <= 2 {
+			return pick(n == 1, opDay, opZeroDay), nil
+		}
+	case 'D':
+		if n == 3 {
+			return opZeroYearDay, nil
+		}
+	case 'E', 'c', 'e':
+		if c != 'E' && n < 3 {
+			break
+		}
+		if n <= 6 {
+			return [...]fmtOp{opWeekDay, opWeekDay, opWeekDay, opLongWeekDay, opNarrowWeekDay, opMinWeekDay}[n-1], nil
+		}
+	case 'a', 'b', 'B', 'h', 'H', 'k', 'K', 'm', 's', 'S', 'A', 'z', 'Z', 'O', 'v', 'V', 'X', 'x':
+		return 0, fmt.Errorf("field %q is a time or time zone", field)
+	}
+	return 0, fmt.Errorf("field %q not supported", field)
+}
+
+// pick returns a, if cond is true and b otherwise.
+func pick(cond bool, a, b fmtOp) fmtOp {
+	if cond {
+		return a
+	}
+	return b
+}
+
+// layoutBuilder assembles a layout translated from another pattern syntax.
+type layoutBuilder struct {
+	sb   strings.Builder
+	prog []inst
+}
+
+// op appends the layout element op.
+func (lb *layoutBuilder) op(op fmtOp) {
+	lb.sb.WriteString(op.String())
+	lb.prog = append(lb.prog, inst{op: op})
+}
+
+// lit appends the literal text s.
+func (lb *layoutBuilder) lit(s string) {
+	if s == "" {
+		return
+	}
+	lb.sb.WriteString(s)
+	if n := len(lb.prog); n > 0 && lb.prog[n-1].op == opLiteral {
+		lb.prog[n-1].lit += s
+		return
+	}
+	lb.prog = append(lb.prog, inst{lit: s})
+}
+
+// layout returns the assembled layout. It returns an error, if the layout
+// would not be compiled into the appended elements and literals, because
+// literal text would be interpreted as a layout element. Literal text is
+// checked against the extended elements as well, so the layout can be used
+// with and without them.
+func (lb *layoutBuilder) layout(pattern string) (string, error) {
+	layout := lb.sb.String()
+	markStandalone(lb.prog)
+	if !slices.Equal(compileLayout(layout, modeExtended), lb.prog) {
+		return "", fmt.Errorf("literal text in %q can not be represented in a layout", pattern)
+	}
+	return layout, nil
+}
+
+// memoize layouts translated by LayoutFromStrftime.
+var memoStrftime cache.Cache[string, string]
+
+// FormatStrftime returns d formatted according to the strftime format, as
+// translated by [LayoutFromStrftime]. The extended elements are recognized.
+func FormatStrftime(d Date, format string) (string, error) {
+	layout, err := memoStrftime.GetErr(format, LayoutFromStrftime)
+	if err != nil {
+		return "", err
+	}
+	return d.formatExtended(layout), nil
+}
+
+// ParseStrftime parses value according to the strftime format, as translated
+// by [LayoutFromStrftime]. The extended elements are recognized.
+func ParseStrftime(format, value string) (Date, error) {
+	layout, err := memoStrftime.GetErr(format, LayoutFromStrftime)
+	if err != nil {
+		return 0, err
+	}
+	return Parser{Extended: true}.Parse(layout, value)
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import "testing"
+
+func TestLayoutFromStrftime(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		format string
+		want   string
+		ok     bool
+	}{
+		{"%Y-%m-%d", RFC3339, true},
+		{"%F", RFC3339, true},
+		{"%Y%m%d", ISOBasic, true},
+		{"%D", "01/02/06", true},
+		{"%A, %B %-d, %Y", "Monday, January 2, 2006", true},
+		{"%a %e %b %y", "Mon _2 Jan 06", true},
+		{"%d.%m.%Y (day %j, %u)", "02.01.2006 (day 002, Mon#)", true},
+		{"100%% on %-m/%-d", "", false}, // literal "1" would be a month
+		{"%Y-%m-%dT%H:%M:%S", "", false},
+		{"%G-W%V", "", false},
+		{"%-y", "", false},
+		{"%Y-%", "", false},
+		{"", "", true},
+	}
+	for _, tc := range tcs {
+		got, err := LayoutFromStrftime(tc.format)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("LayoutFromStrftime(%q) = %q, %v, want %q, %v", tc.format, got, err, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestLayoutFromCLDR(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		pattern string
+		want    string
+		ok      bool
+	}{
+		{"yyyy-MM-dd", RFC3339, true},
+		{"y-MM-dd", RFC3339, true},
+		{"EEEE, d. MMMM y", "Monday, 2. January 2006", true},
+		{"EEE, dd MMM yy", "Mon, 02 Jan 06", true},
+		{"M/d/yy", "1/2/06", true},
+		{"LLLL yyyy", "January 2006", true},
+		{"EEEEEE dd", "Mo 02", true},
+		{"yyyy 'day' DDD", "2006 day 002", true},
+		{"d MMM ''yy", "2 Jan '06", true},
+		{"'o''clock' y", "o'clock 2006", true},
+		{"yyyy-MM-dd HH:mm", "", false},
+		{"yyyy-MM-dd zzz", "", false},
+		{"QQQ yyyy", "", false},
+		{"D.M.y", "", false},
+		{"e.M.y", "", false},
+		{"'Jan' yyyy", "", false}, // quoted "Jan" would be a month
+		{"'unterminated yyyy", "", false},
+	}
+	for _, tc := range tcs {
+		got, err := LayoutFromCLDR(tc.pattern)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("LayoutFromCLDR(%q) = %q, %v, want %q, %v", tc.pattern, got, err, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestStrftime(t *testing.T) {
+	t.Parallel()
+	d := Of(2024, 5, 14)
+	if got, err := FormatStrftime(d, "%a, %d %b %Y"); err != nil || got != "Tue, 14 May 2024" {
+		t.Errorf("FormatStrftime(%v, %q) = %q, %v, want %q, <nil>", d, "%a, %d %b %Y", got, err, "Tue, 14 May 2024")
+	}
+	if got, err := ParseStrftime("%d/%m/%Y", "14/05/2024"); err != nil || got != d {
+		t.Errorf("ParseStrftime(%q, %q) = %v, %v, want %v, <nil>", "%d/%m/%Y", "14/05/2024", got, err, d)
+	}
+	if _, err := FormatStrftime(d, "%H:%M"); err == nil {
+		t.Errorf("FormatStrftime(%v, %q) = _, <nil>, want error", d, "%H:%M")
+	}
+	if _, err := ParseStrftime("%s", "1715644800"); err == nil {
+		t.Errorf("ParseStrftime(%q, %q) = _, <nil>, want error", "%s", "1715644800")
+	}
+}
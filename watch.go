@@ -0,0 +1,150 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"sync"
+	"time"
+)
+
+// maxWatchInterval is the maximum time a Watcher sleeps before re-checking the
+// current date. Timers measure elapsed time, so without a limit, changes to
+// the wall clock would not be noticed until the timer fires.
+const maxWatchInterval = time.Minute
+
+// A Watcher notifies about changes of the current date in a location.
+type Watcher struct {
+	// C receives the new date whenever the current date changes. If the
+	// receiver falls behind, only the latest date is kept.
+	C <-chan Date
+
+	c     chan Date
+	f     func(Date)
+	clock Clock
+	loc   *time.Location
+	stop  chan struct{}
+
+	mu      sync.Mutex // held while sending on c
+	stopped bool
+}
+
+// NewWatcher returns a new Watcher, sending the date on its channel whenever
+// the current date in loc changes. The date at the time of the call is not
+// sent. If c is nil, [SystemClock] is used.
+//
+// The Watcher wakes up at midnight in loc, or whenever the date starts if a
+// daylight savings time transition skips midnight. It also re-checks the date
+// at least once a minute, so changes to the system clock are noticed in a
+// timely fashion. If the clock is set back, the earlier date is delivered as
+// well.
+//
+// The Watcher must be stopped by calling Stop, to release its resources.
+func NewWatcher(c Clock, loc *time.Location) *Watcher {
+	ch := make(chan Date, 1)
+	w := newWatcher(c, loc)
+	w.C, w.c = ch, ch
+	go w.run()
+	return w
+}
+
+// WatchFunc is like [NewWatcher], but instead of sending the new date on a
+// channel, it calls f in its own goroutine. Calls to f are sequential and the
+// Watcher waits for f to return before checking the date again. The C field of
+// the returned Watcher is nil.
+func WatchFunc(c Clock, loc *time.Location, f func(Date)) *Watcher {
+	w := newWatcher(c, loc)
+	w.f = f
+	go w.run()
+	return w
+}
+
+func newWatcher(c Clock, loc *time.Location) *Watcher {
+	if c == nil {
+		c = SystemClock
+	}
+	return &Watcher{
+		clock: c,
+		loc:   loc,
+		stop:  make(chan struct{}),
+	}
+}
+
+// Stop turns off the Watcher. After Stop returns, no more dates are sent. Stop
+// does not close the channel.
+//
+// Stop does not wait for a call to f, so f can call Stop itself. If f is
+// currently running or the date changes while Stop is called, f might be
+// called once more.
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.stopped {
+		w.stopped = true
+		close(w.stop)
+	}
+}
+
+func (w *Watcher) run() {
+	now := w.clock.Now().In(w.loc)
+	today := Of(now.Date())
+	for {
+		wait := startOfDay(today+1, w.loc).Sub(now)
+		if wait > maxWatchInterval {
+			wait = maxWatchInterval
+		}
+		select {
+		case <-w.clock.After(wait):
+		case <-w.stop:
+			return
+		}
+		now = w.clock.Now().In(w.loc)
+		if d := Of(now.Date()); d != today {
+			today = d
+			w.deliver(d)
+		}
+	}
+}
+
+// deliver sends d on the channel, replacing any date not yet received, or
+// calls the callback.
+func (w *Watcher) deliver(d Date) {
+	if w.f != nil {
+		select {
+		case <-w.stop:
+		default:
+			w.f(d)
+		}
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return
+	}
+	for {
+		select {
+		case w.c <- d:
+			return
+		default:
+		}
+		select {
+		case <-w.c:
+		default:
+		}
+	}
+}
+
+// startOfDay returns the first instant of d in loc. This is usually midnight,
+// but if a daylight savings time transition skips midnight, it is the moment
+// of the transition.
+func startOfDay(d Date, loc *time.Location) time.Time {
+	t := d.Time(0, 0, 0, 0, loc)
+	if Of(t.Date()) < d {
+		// time.Date normalized the non-existent midnight to the previous day.
+		_, t = t.ZoneBounds()
+	}
+	return t
+}
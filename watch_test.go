@@ -0,0 +1,225 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock for testing, which only advances when told to. Like
+// the system clock, it distinguishes between the wall clock, which can be set
+// arbitrarily, and elapsed time, which only moves forward.
+type fakeClock struct {
+	mu      sync.Mutex
+	cond    sync.Cond
+	now     time.Time
+	elapsed time.Duration
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Duration
+	ch       chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	c := &fakeClock{now: now}
+	c.cond.L = &c.mu
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeWaiter{c.elapsed + d, ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// set sets the wall clock to t. If t is after the current time, the elapsed
+// time advances accordingly, firing all expired waiters.
+func (c *fakeClock) set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d := t.Sub(c.now); d > 0 {
+		c.elapsed += d
+	}
+	c.now = t
+	c.fireLocked()
+}
+
+// advance lets d pass, without changing the wall clock.
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.elapsed += d
+	c.fireLocked()
+}
+
+func (c *fakeClock) fireLocked() {
+	t := c.now
+	ws := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline > c.elapsed {
+			ws = append(ws, w)
+			continue
+		}
+		w.ch <- t
+	}
+	c.waiters = ws
+}
+
+// waitForWaiter blocks until there is at least one waiter.
+func (c *fakeClock) waitForWaiter() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) == 0 {
+		c.cond.Wait()
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	t.Parallel()
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip(err)
+	}
+	// Brazil used to start DST at midnight, so 2018-11-04 started at 01:00.
+	start := time.Date(2018, 11, 3, 23, 59, 30, 0, loc)
+	c := newFakeClock(start)
+	w := NewWatcher(c, loc)
+	defer w.Stop()
+
+	expect := func(want Date) {
+		t.Helper()
+		select {
+		case got := <-w.C:
+			if got != want {
+				t.Fatalf("Watcher sent %v, want %v", got, want)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Watcher did not send %v", want)
+		}
+	}
+
+	// Each step sets the wall clock to t and then lets advance pass.
+	steps := []struct {
+		t       time.Time
+		advance time.Duration
+		want    Date
+	}{
+		{start.Add(10 * time.Second), 0, -1},
+		{start.Add(40 * time.Second), 0, Of(2018, 11, 4)},
+		{time.Date(2018, 11, 4, 12, 0, 0, 0, loc), 0, -1},
+		{time.Date(2018, 11, 5, 0, 0, 0, 0, loc), 0, Of(2018, 11, 5)},
+		// Clock is set back. The change is noticed within a minute.
+		{time.Date(2018, 11, 4, 23, 0, 0, 0, loc), maxWatchInterval, Of(2018, 11, 4)},
+		// Clock jumps forward by multiple days.
+		{time.Date(2018, 11, 20, 3, 0, 0, 0, loc), 0, Of(2018, 11, 20)},
+	}
+	for _, s := range steps {
+		c.waitForWaiter()
+		c.set(s.t)
+		if s.advance > 0 {
+			c.advance(s.advance)
+		}
+		if s.want >= 0 {
+			expect(s.want)
+		}
+	}
+	select {
+	case d := <-w.C:
+		t.Fatalf("Watcher sent unexpected %v", d)
+	default:
+	}
+}
+
+func TestWatchFunc(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 5, 14, 22, 0, 0, 0, time.UTC)
+	c := newFakeClock(start)
+	ch := make(chan Date)
+	w := WatchFunc(c, time.UTC, func(d Date) { ch <- d })
+	for i := 1; i <= 3; i++ {
+		c.waitForWaiter()
+		c.set(start.Add(time.Duration(i) * 24 * time.Hour))
+		if got, want := <-ch, Of(2024, 5, 14+i); got != want {
+			t.Fatalf("WatchFunc called f(%v), want f(%v)", got, want)
+		}
+	}
+	w.Stop()
+	w.Stop() // Stop is idempotent
+}
+
+func TestWatcherDropsStale(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 5, 14, 22, 0, 0, 0, time.UTC)
+	c := newFakeClock(start)
+	w := NewWatcher(c, time.UTC)
+	defer w.Stop()
+	for i := 1; i <= 3; i++ {
+		c.waitForWaiter()
+		c.set(start.Add(time.Duration(i) * 24 * time.Hour))
+	}
+	// Wait for the Watcher to pick up the last change.
+	c.waitForWaiter()
+	if got, want := <-w.C, Of(2024, 5, 17); got != want {
+		t.Fatalf("Watcher sent %v, want %v", got, want)
+	}
+}
+
+func TestWatcherStop(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 5, 14, 22, 0, 0, 0, time.UTC)
+	for i := 0; i < 100; i++ {
+		c := newFakeClock(start)
+		w := NewWatcher(c, time.UTC)
+		c.waitForWaiter()
+		// Change the date concurrently with Stop.
+		go c.set(start.Add(24 * time.Hour))
+		w.Stop()
+		select {
+		case <-w.C:
+		default:
+		}
+		time.Sleep(time.Millisecond)
+		select {
+		case d := <-w.C:
+			t.Fatalf("Watcher sent %v after Stop returned", d)
+		default:
+		}
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	t.Parallel()
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip(err)
+	}
+	tcs := []struct {
+		d    Date
+		want time.Time
+	}{
+		{Of(2018, 11, 3), time.Date(2018, 11, 3, 3, 0, 0, 0, time.UTC)},
+		{Of(2018, 11, 4), time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC)},
+		{Of(2018, 11, 5), time.Date(2018, 11, 5, 2, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range tcs {
+		if got := startOfDay(tc.d, loc); !got.Equal(tc.want) {
+			t.Errorf("startOfDay(%v, %v) = %v, want %v", tc.d, loc, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/binary"
+	"errors"
+	"iter"
+	"time"
+)
+
+// A Week is an ISO 8601 week, from Monday to Sunday, like 2024-W31. It is
+// useful as a key for data aggregated by week.
+//
+// A Week is represented as the number of weeks since the week of 0001-01-01,
+// which is a Monday, so the zero value is 0001-W01. Weeks can be compared
+// using Go's arithmetic operators, adding n to a Week moves it n weeks forward
+// and subtracting two Week values returns the number of weeks between them.
+type Week int
+
+// WeekOf returns the ISO 8601 week of d.
+func WeekOf(d Date) Week {
+	return Week(d.ISOWeekStart() / 7)
+}
+
+// OfWeek returns the given ISO 8601 week of the given ISO year. The week is
+// normalized as by [OfISOWeek], so week 0 is the last week of the previous
+// year.
+func OfWeek(isoYear, week int) Week {
+	return WeekOf(OfISOWeek(isoYear, week, time.Monday))
+}
+
+// Date returns the ISO year and the number of w, as by [Date.ISOWeek].
+func (w Week) Date() (isoYear, week int) {
+	return w.Monday().ISOWeek()
+}
+
+// Monday returns the first day of w.
+func (w Week) Monday() Date {
+	return Date(7 * w)
+}
+
+// Sunday returns the last day of w.
+func (w Week) Sunday() Date {
+	return w.Monday() + 6
+}
+
+// Day returns the date of weekday wd in w.
+func (w Week) Day(wd time.Weekday) Date {
+	return w.Monday() + Date(isoWeekday(wd)-1)
+}
+
+// Range returns the Range containing the seven days of w.
+func (w Week) Range() Range {
+	return Range{w.Monday(), w.Monday() + 7}
+}
+
+// Dates returns an iterator over the days of w, from Monday to Sunday.
+func (w Week) Dates() iter.Seq[Date] {
+	return w.Range().Dates()
+}
+
+// Contains reports whether d is in w.
+func (w Week) Contains(d Date) bool {
+	return WeekOf(d) == w
+}
+
+// String returns w in ISO 8601 extended format, like "2024-W31".
+func (w Week) String() string {
+	return string(w.appendFormat(nil))
+}
+
+func (w Week) appendFormat(b []byte) []byte {
+	year, week := w.Date()
+	b = appendInt(b, year, 4)
+	b = append(b, '-', 'W')
+	return appendInt(b, week, 2)
+}
+
+// ParseWeek parses an ISO 8601 week in extended format, like "2024-W31", or
+// in basic format, like "2024W31". The year must have four digits and the
+// week must exist in that year.
+func ParseWeek(s string) (Week, error) {
+	year, week, _, err := parseISOWeek(s, false)
+	if err != nil {
+		return 0, err
+	}
+	return OfWeek(year, week), nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The week is
+// represented as a [binary.Varint] representing the number of weeks since
+// 0001-W01.
+func (w Week) MarshalBinary() ([]byte, error) {
+	b := make([]byte, binary.MaxVarintLen64)
+	return b[:binary.PutVarint(b, int64(w))], nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (w *Week) UnmarshalBinary(b []byte) error {
+	v, i := binary.Varint(b)
+	switch {
+	case i == 0:
+		return errors.New("encoded week truncated")
+	case i < 0 || int64(int(v)) != v:
+		return errors.New("encoded week overflows int")
+	case i != len(b):
+		return errors.New("extra data after week")
+	}
+	*w = Week(v)
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The week is
+// formatted in ISO 8601 extended format, like "2024-W31".
+func (w Week) MarshalText() ([]byte, error) {
+	return w.appendFormat(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The week
+// must be in one of the formats accepted by [ParseWeek].
+func (w *Week) UnmarshalText(b []byte) error {
+	v, err := ParseWeek(string(b))
+	if err == nil {
+		*w = v
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWeek(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d      Date
+		year   int
+		week   int
+		monday Date
+		str    string
+	}{
+		{0, 1, 1, 0, "0001-W01"},
+		{Of(2024, 7, 31), 2024, 31, Of(2024, 7, 29), "2024-W31"},
+		{Of(2021, 1, 3), 2020, 53, Of(2020, 12, 28), "2020-W53"},
+		{Of(2024, 12, 30), 2025, 1, Of(2024, 12, 30), "2025-W01"},
+		{Of(2027, 1, 1), 2026, 53, Of(2026, 12, 28), "2026-W53"},
+		{-1, 0, 52, -7, "0000-W52"},
+	}
+	for _, tc := range tcs {
+		w := WeekOf(tc.d)
+		if year, week := w.Date(); year != tc.year || week != tc.week {
+			t.Errorf("WeekOf(%v).Date() = %d, %d, want %d, %d", tc.d, year, week, tc.year, tc.week)
+		}
+		if got := OfWeek(tc.year, tc.week); got != w {
+			t.Errorf("OfWeek(%d, %d) = %v, want %v", tc.year, tc.week, got, w)
+		}
+		if got := w.Monday(); got != tc.monday {
+			t.Errorf("%v.Monday() = %v, want %v", w, got, tc.monday)
+		}
+		if got := w.Sunday(); got != tc.monday+6 {
+			t.Errorf("%v.Sunday() = %v, want %v", w, got, tc.monday+6)
+		}
+		if got := w.String(); got != tc.str {
+			t.Errorf("%v.String() = %q, want %q", w, got, tc.str)
+		}
+		if !w.Contains(tc.d) || w.Contains(tc.monday-1) || w.Contains(tc.monday+7) {
+			t.Errorf("%v.Contains does not match [%v, %v]", w, tc.monday, tc.monday+6)
+		}
+		if got, err := ParseWeek(tc.str); err != nil || got != w {
+			t.Errorf("ParseWeek(%q) = %v, %v, want %v, <nil>", tc.str, got, err, w)
+		}
+	}
+
+	w := OfWeek(2024, 31)
+	if got, want := slices.Collect(w.Dates()), slices.Collect(ClosedRange(Of(2024, 7, 29), Of(2024, 8, 4)).Dates()); !slices.Equal(got, want) {
+		t.Errorf("%v.Dates() = %v, want %v", w, got, want)
+	}
+	if got, want := w.Day(time.Wednesday), Of(2024, 7, 31); got != want {
+		t.Errorf("%v.Day(Wednesday) = %v, want %v", w, got, want)
+	}
+	if got, want := w+22, OfWeek(2025, 1); got != want {
+		t.Errorf("%v+22 = %v, want %v", w, got, want)
+	}
+	if got, want := OfWeek(2025, 0), OfWeek(2024, 52); got != want {
+		t.Errorf("OfWeek(2025, 0) = %v, want %v", got, want)
+	}
+}
+
+func TestParseWeek(t *testing.T) {
+	t.Parallel()
+	if got, err := ParseWeek("2024W31"); err != nil || got != OfWeek(2024, 31) {
+		t.Errorf("ParseWeek(%q) = %v, %v, want 2024-W31, <nil>", "2024W31", got, err)
+	}
+	for _, s := range []string{"", "2024", "2024-W", "2024-W3", "2024-W31-3", "2024W313", "2024-W00", "2024-W53", "24-W31", "2024-w31"} {
+		if got, err := ParseWeek(s); err == nil {
+			t.Errorf("ParseWeek(%q) = %v, <nil>, want error", s, got)
+		}
+	}
+
+	for _, w := range []Week{0, -1, OfWeek(2024, 31)} {
+		b, _ := w.MarshalBinary()
+		var got Week
+		if err := got.UnmarshalBinary(b); err != nil || got != w {
+			t.Errorf("UnmarshalBinary(MarshalBinary(%d)) = %d, %v, want %d, <nil>", w, got, err, w)
+		}
+		if w < 0 {
+			continue
+		}
+		b, _ = w.MarshalText()
+		if err := got.UnmarshalText(b); err != nil || got != w {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want %v, <nil>", b, got, err, w)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// A WeekdaySet is a set of days of the week.
+type WeekdaySet uint8
+
+const (
+	// Weekend contains Saturday and Sunday.
+	Weekend = WeekdaySet(1<<time.Saturday | 1<<time.Sunday)
+
+	// Workdays contains Monday through Friday.
+	Workdays = allWeekdays &^ Weekend
+
+	allWeekdays = WeekdaySet(1<<7 - 1)
+)
+
+// WeekdaysOf returns the WeekdaySet containing the given weekdays. Weekdays
+// outside of Sunday…Saturday are taken modulo 7, so 7 is Sunday and -1 is
+// Saturday.
+func WeekdaysOf(wds ...time.Weekday) WeekdaySet {
+	var s WeekdaySet
+	for _, wd := range wds {
+		s |= 1 << ((wd%7 + 7) % 7)
+	}
+	return s
+}
+
+// Contains reports whether wd is in s.
+func (s WeekdaySet) Contains(wd time.Weekday) bool {
+	return wd >= 0 && wd < 7 && s&(1<<wd) != 0
+}
+
+// Len returns the number of weekdays in s.
+func (s WeekdaySet) Len() int {
+	return bits.OnesCount8(uint8(s & allWeekdays))
+}
+
+// String returns the names of the weekdays in s, starting with Sunday, like
+// "Sunday|Saturday".
+func (s WeekdaySet) String() string {
+	var names []string
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if s.Contains(wd) {
+			names = append(names, wd.String())
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// CountWeekday returns the number of dates in r falling on wd.
+func CountWeekday(r Range, wd time.Weekday) int {
+	return CountWeekdays(r, WeekdaysOf(wd))
+}
+
+// CountWeekdays returns the number of dates in r falling on any weekday in s.
+// For example, CountWeekdays(r, Workdays) counts the days from Monday to
+// Friday in r.
+//
+// The result is computed in constant time, independent of the length of r.
+func CountWeekdays(r Range, s WeekdaySet) int {
+	n := r.Days()
+	count := n / 7 * s.Len()
+	// The remaining n%7 days start with the weekday of r.Start.
+	wd := r.Start.Weekday()
+	for i := 0; i < n%7; i++ {
+		if s.Contains(wd) {
+			count++
+		}
+		wd = (wd + 1) % 7
+	}
+	return count
+}
+
+// WeekdayCountInMonth returns the number of dates in the given month falling
+// on wd. The result is always 4 or 5.
+func WeekdayCountInMonth(year int, m time.Month, wd time.Weekday) int {
+	return CountWeekday(MonthRange(year, m), wd)
+}
+
+// WeekdayOrdinalInMonth returns n, such that d is the n'th occurrence of its
+// weekday in its month. For example, it returns 2 if d is the second Tuesday
+// of its month.
+func (d Date) WeekdayOrdinalInMonth() int {
+	return (d.Day()-1)/7 + 1
+}
+
+// WeekdayOccurrence returns the occurrence of the weekday of d in its month,
+// counted from the start and from the end of the month. For example,
+// 2024-05-14 is the second Tuesday of May and the third to last, so
+// WeekdayOccurrence returns 2, 3. n is the same as returned by
+// [Date.WeekdayOrdinalInMonth].
+func (d Date) WeekdayOccurrence() (n, fromEnd int) {
+	year, month, day := d.Date()
+	return d.WeekdayOrdinalInMonth(), (daysIn(month, year)-day)/7 + 1
+}
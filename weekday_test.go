@@ -0,0 +1,151 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekdaySet(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		s   WeekdaySet
+		len int
+		str string
+	}{
+		{0, 0, ""},
+		{Weekend, 2, "Sunday|Saturday"},
+		{Workdays, 5, "Monday|Tuesday|Wednesday|Thursday|Friday"},
+		{WeekdaysOf(time.Wednesday, time.Monday, time.Monday), 2, "Monday|Wednesday"},
+		{WeekdaysOf(7, -1, -13), 3, "Sunday|Monday|Saturday"},
+		{Weekend | Workdays, 7, "Sunday|Monday|Tuesday|Wednesday|Thursday|Friday|Saturday"},
+	}
+	for _, tc := range tcs {
+		if got := tc.s.Len(); got != tc.len {
+			t.Errorf("%v.Len() = %d, want %d", tc.s, got, tc.len)
+		}
+		if got := tc.s.String(); got != tc.str {
+			t.Errorf("WeekdaySet(%#x).String() = %q, want %q", uint8(tc.s), got, tc.str)
+		}
+	}
+	if Weekend.Contains(time.Monday) || !Weekend.Contains(time.Sunday) {
+		t.Errorf("Weekend.Contains is wrong")
+	}
+	if Weekend.Contains(-1) || Weekend.Contains(7) {
+		t.Errorf("Weekend.Contains accepts invalid weekdays")
+	}
+}
+
+func TestCountWeekday(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		r    Range
+		wd   time.Weekday
+		want int
+	}{
+		{Range{}, time.Monday, 0},
+		{Range{Of(2024, 5, 14), Of(2024, 5, 1)}, time.Monday, 0},
+		{MonthRange(2024, time.May), time.Wednesday, 5},
+		{MonthRange(2024, time.May), time.Tuesday, 4},
+		{MonthRange(2024, time.February), time.Thursday, 5},
+		{ClosedRange(Of(2024, 5, 13), Of(2024, 5, 13)), time.Monday, 1},
+		{ClosedRange(Of(2024, 5, 13), Of(2024, 5, 13)), time.Tuesday, 0},
+	}
+	for _, tc := range tcs {
+		if got := CountWeekday(tc.r, tc.wd); got != tc.want {
+			t.Errorf("CountWeekday(%v, %v) = %d, want %d", tc.r, tc.wd, got, tc.want)
+		}
+	}
+}
+
+func TestWeekdayCountInMonth(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		year int
+		m    time.Month
+		wd   time.Weekday
+		want int
+	}{
+		{2024, time.May, time.Wednesday, 5},
+		{2024, time.May, time.Friday, 5},
+		{2024, time.May, time.Saturday, 4},
+		{2024, time.February, time.Thursday, 5},
+		{2024, time.February, time.Friday, 4},
+		{2023, time.February, time.Monday, 4},
+	}
+	for _, tc := range tcs {
+		if got := WeekdayCountInMonth(tc.year, tc.m, tc.wd); got != tc.want {
+			t.Errorf("WeekdayCountInMonth(%d, %v, %v) = %d, want %d", tc.year, tc.m, tc.wd, got, tc.want)
+		}
+	}
+}
+
+func TestWeekdayOrdinalInMonth(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d    Date
+		want int
+	}{
+		{Of(2024, 5, 1), 1},
+		{Of(2024, 5, 7), 1},
+		{Of(2024, 5, 8), 2},
+		{Of(2024, 5, 14), 2},
+		{Of(2024, 5, 28), 4},
+		{Of(2024, 5, 29), 5},
+		{Of(2024, 5, 31), 5},
+	}
+	for _, tc := range tcs {
+		if got := tc.d.WeekdayOrdinalInMonth(); got != tc.want {
+			t.Errorf("%v.WeekdayOrdinalInMonth() = %d, want %d", tc.d, got, tc.want)
+		}
+	}
+}
+
+func TestWeekdayOccurrence(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		d          Date
+		n, fromEnd int
+	}{
+		{Of(2024, 5, 14), 2, 3},
+		{Of(2024, 5, 1), 1, 5},
+		{Of(2024, 5, 31), 5, 1},
+		{Of(2024, 5, 25), 4, 1},
+		{Of(2024, 5, 24), 4, 2},
+		{Of(2023, 2, 28), 4, 1},
+		{Of(2023, 2, 1), 1, 4},
+	}
+	for _, tc := range tcs {
+		n, fromEnd := tc.d.WeekdayOccurrence()
+		if n != tc.n || fromEnd != tc.fromEnd {
+			t.Errorf("%v.WeekdayOccurrence() = %d, %d, want %d, %d", tc.d, n, fromEnd, tc.n, tc.fromEnd)
+		}
+		wd := tc.d.Weekday()
+		if got := NthInMonth(n, wd).Adjust(tc.d); got != tc.d {
+			t.Errorf("NthInMonth(%d, %v).Adjust(%v) = %v", n, wd, tc.d, got)
+		}
+		if got := NthInMonth(-fromEnd, wd).Adjust(tc.d); got != tc.d {
+			t.Errorf("NthInMonth(%d, %v).Adjust(%v) = %v", -fromEnd, wd, tc.d, got)
+		}
+	}
+}
+
+func FuzzCountWeekdays(f *testing.F) {
+	f.Add(int64(739000), uint16(100), uint8(Weekend))
+	f.Fuzz(func(t *testing.T, start int64, n uint16, s uint8) {
+		r := Range{Date(start), Date(start) + Date(n%1000)}
+		want := 0
+		for d := range r.Dates() {
+			if WeekdaySet(s).Contains(d.Weekday()) {
+				want++
+			}
+		}
+		if got := CountWeekdays(r, WeekdaySet(s)); got != want {
+			t.Fatalf("CountWeekdays(%v, %v) = %d, want %d", r, WeekdaySet(s), got, want)
+		}
+	})
+}
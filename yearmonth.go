@@ -0,0 +1,149 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// A YearMonth is a month of a specific year, like July 2024. It is useful for
+// data with a granularity of months, like billing periods.
+//
+// A YearMonth is represented as the number of months since January of the
+// year 1, so the zero value is 0001-01, the month of the zero [Date]. Like
+// dates, YearMonth values can be compared using Go's arithmetic operators,
+// adding n to a YearMonth moves it n months forward and subtracting two
+// YearMonth values returns the number of months between them.
+type YearMonth int
+
+// OfYearMonth returns the YearMonth of the given year and month. The month is
+// normalized, so month 13 of 2024 is January 2025.
+func OfYearMonth(year int, month time.Month) YearMonth {
+	return YearMonth((year-1)*12 + int(month) - 1)
+}
+
+// YearMonth returns the month of the year in which d occurs.
+func (d Date) YearMonth() YearMonth {
+	year, month, _ := d.Date()
+	return OfYearMonth(year, month)
+}
+
+// Date returns the year and month of ym.
+func (ym YearMonth) Date() (year int, month time.Month) {
+	y, m := norm(0, int(ym), 12)
+	return y + 1, time.Month(m + 1)
+}
+
+// Year returns the year of ym.
+func (ym YearMonth) Year() int {
+	year, _ := ym.Date()
+	return year
+}
+
+// Month returns the month of the year of ym.
+func (ym YearMonth) Month() time.Month {
+	_, month := ym.Date()
+	return month
+}
+
+// First returns the first day of ym.
+func (ym YearMonth) First() Date {
+	year, month := ym.Date()
+	return Of(year, month, 1)
+}
+
+// Last returns the last day of ym.
+func (ym YearMonth) Last() Date {
+	return (ym + 1).First() - 1
+}
+
+// Days returns the number of days in ym.
+func (ym YearMonth) Days() int {
+	year, month := ym.Date()
+	return daysIn(month, year)
+}
+
+// Range returns the Range containing all dates of ym.
+func (ym YearMonth) Range() Range {
+	return Range{ym.First(), (ym + 1).First()}
+}
+
+// AddYears returns the YearMonth n years after ym, or before ym if n is
+// negative.
+func (ym YearMonth) AddYears(n int) YearMonth {
+	return ym + YearMonth(12*n)
+}
+
+// Format returns a textual representation of ym, formatted according to
+// layout, like "Jan 2006". See [Layout] for the elements of layouts. Elements
+// referring to the day are formatted for the first day of ym.
+func (ym YearMonth) Format(layout string) string {
+	return ym.First().Format(layout)
+}
+
+// AppendFormat is like Format but appends the textual representation to b and
+// returns the extended buffer.
+func (ym YearMonth) AppendFormat(b []byte, layout string) []byte {
+	return ym.First().AppendFormat(b, layout)
+}
+
+// String returns ym formatted as ISO 8601, like "2024-07".
+func (ym YearMonth) String() string {
+	return ym.Format("2006-01")
+}
+
+// ParseYearMonth parses a formatted string and returns the YearMonth it
+// represents, as by [Parse]. The layout does not need to contain a day. If it
+// does, the day is checked but otherwise ignored.
+func ParseYearMonth(layout, value string) (YearMonth, error) {
+	d, err := Parse(layout, value)
+	if err != nil {
+		return 0, err
+	}
+	return d.YearMonth(), nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The month
+// is represented as a [binary.Varint] representing the number of months since
+// 0001-01.
+func (ym YearMonth) MarshalBinary() ([]byte, error) {
+	b := make([]byte, binary.MaxVarintLen64)
+	return b[:binary.PutVarint(b, int64(ym))], nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (ym *YearMonth) UnmarshalBinary(b []byte) error {
+	v, i := binary.Varint(b)
+	switch {
+	case i == 0:
+		return errors.New("encoded month truncated")
+	case i < 0 || int64(int(v)) != v:
+		return errors.New("encoded month overflows int")
+	case i != len(b):
+		return errors.New("extra data after month")
+	}
+	*ym = YearMonth(v)
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The month is
+// formatted in ISO 8601 format, like "2024-07". This format is also used by
+// encoding/json.
+func (ym YearMonth) MarshalText() ([]byte, error) {
+	return []byte(ym.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The month
+// must be in ISO 8601 format, like "2024-07".
+func (ym *YearMonth) UnmarshalText(b []byte) error {
+	v, err := ParseYearMonth("2006-01", string(b))
+	if err == nil {
+		*ym = v
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Axel Wagner.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestYearMonth(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		year  int
+		month time.Month
+		first Date
+		last  Date
+		days  int
+		str   string
+	}{
+		{1, time.January, 0, 30, 31, "0001-01"},
+		{2024, time.February, Of(2024, 2, 1), Of(2024, 2, 29), 29, "2024-02"},
+		{2023, time.February, Of(2023, 2, 1), Of(2023, 2, 28), 28, "2023-02"},
+		{2024, time.December, Of(2024, 12, 1), Of(2024, 12, 31), 31, "2024-12"},
+		{0, time.June, Of(0, 6, 1), Of(0, 6, 30), 30, "0000-06"},
+	}
+	for _, tc := range tcs {
+		ym := OfYearMonth(tc.year, tc.month)
+		if year, month := ym.Date(); year != tc.year || month != tc.month {
+			t.Errorf("OfYearMonth(%d, %v).Date() = %d, %v, want %d, %v", tc.year, tc.month, year, month, tc.year, tc.month)
+		}
+		if got := ym.First(); got != tc.first {
+			t.Errorf("%v.First() = %v, want %v", ym, got, tc.first)
+		}
+		if got := ym.Last(); got != tc.last {
+			t.Errorf("%v.Last() = %v, want %v", ym, got, tc.last)
+		}
+		if got := ym.Days(); got != tc.days {
+			t.Errorf("%v.Days() = %d, want %d", ym, got, tc.days)
+		}
+		if got := ym.String(); got != tc.str {
+			t.Errorf("%v.String() = %q, want %q", ym, got, tc.str)
+		}
+		if got := tc.last.YearMonth(); got != ym {
+			t.Errorf("%v.YearMonth() = %v, want %v", tc.last, got, ym)
+		}
+	}
+
+	ym := OfYearMonth(2024, time.November)
+	if got, want := ym+3, OfYearMonth(2025, time.February); got != want {
+		t.Errorf("%v+3 = %v, want %v", ym, got, want)
+	}
+	if got, want := ym.AddYears(-2), OfYearMonth(2022, time.November); got != want {
+		t.Errorf("%v.AddYears(-2) = %v, want %v", ym, got, want)
+	}
+	if got, want := OfYearMonth(2024, 13), OfYearMonth(2025, time.January); got != want {
+		t.Errorf("OfYearMonth(2024, 13) = %v, want %v", got, want)
+	}
+	if got, want := ym.Range(), (Range{Of(2024, 11, 1), Of(2024, 12, 1)}); got != want {
+		t.Errorf("%v.Range() = %v, want %v", ym, got, want)
+	}
+}
+
+func TestYearMonthFormat(t *testing.T) {
+	t.Parallel()
+	ym := OfYearMonth(2024, time.July)
+	tcs := []struct {
+		layout string
+		value  string
+	}{
+		{"2006-01", "2024-07"},
+		{"Jan 2006", "Jul 2024"},
+		{"January 2006", "July 2024"},
+		{"01/06", "07/24"},
+	}
+	for _, tc := range tcs {
+		if got := ym.Format(tc.layout); got != tc.value {
+			t.Errorf("%v.Format(%q) = %q, want %q", ym, tc.layout, got, tc.value)
+		}
+		if got, err := ParseYearMonth(tc.layout, tc.value); err != nil || got != ym {
+			t.Errorf("ParseYearMonth(%q, %q) = %v, %v, want %v, <nil>", tc.layout, tc.value, got, err, ym)
+		}
+	}
+	if got, err := ParseYearMonth("2006-01-02", "2024-07-14"); err != nil || got != ym {
+		t.Errorf("ParseYearMonth(%q, %q) = %v, %v, want %v, <nil>", "2006-01-02", "2024-07-14", got, err, ym)
+	}
+	if _, err := ParseYearMonth("2006-01", "2024-13"); err == nil {
+		t.Errorf("ParseYearMonth(%q, %q) = _, <nil>, want error", "2006-01", "2024-13")
+	}
+}
+
+func TestYearMonthMarshal(t *testing.T) {
+	t.Parallel()
+	for _, ym := range []YearMonth{0, OfYearMonth(2024, time.July), -1, 1 << 30} {
+		b, err := ym.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got YearMonth
+		if err := got.UnmarshalBinary(b); err != nil || got != ym {
+			t.Errorf("UnmarshalBinary(MarshalBinary(%d)) = %d, %v, want %d, <nil>", ym, got, err, ym)
+		}
+	}
+	for _, b := range [][]byte{nil, {0x80}, {0x02, 0x00}} {
+		var ym YearMonth
+		if err := ym.UnmarshalBinary(b); err == nil {
+			t.Errorf("UnmarshalBinary(%x) = <nil>, want error", b)
+		}
+	}
+
+	type billing struct {
+		Period YearMonth `json:"period"`
+	}
+	in := billing{OfYearMonth(2024, time.July)}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"period":"2024-07"}`; got != want {
+		t.Errorf("json.Marshal(%v) = %s, want %s", in, got, want)
+	}
+	var out billing
+	if err := json.Unmarshal(b, &out); err != nil || out != in {
+		t.Errorf("json.Unmarshal(%s) = %v, %v, want %v, <nil>", b, out, err, in)
+	}
+}